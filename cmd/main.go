@@ -1,21 +1,29 @@
 package main
 
 import (
+	"context"
 	"embed"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
+	"salam-monitoring/internal/audit"
 	"salam-monitoring/internal/config"
+	"salam-monitoring/internal/history"
 	"salam-monitoring/internal/informatica"
 	"salam-monitoring/internal/logger"
 	"salam-monitoring/internal/nfs"
 	"salam-monitoring/internal/web"
 	"salam-monitoring/internal/yarn"
+
+	"gopkg.in/yaml.v3"
 )
 
 //go:embed static/* templates-deploy/*
@@ -26,6 +34,7 @@ var (
 	mode       = flag.String("mode", "", "Override mode (test|prod)")
 	showHelp   = flag.Bool("help", false, "Show help")
 	version    = flag.Bool("version", false, "Show version")
+	dev        = flag.Bool("dev", false, "Re-read page templates from disk on every request")
 )
 
 const appVersion = "1.0.0"
@@ -33,12 +42,6 @@ const appVersion = "1.0.0"
 func main() {
 	flag.Parse()
 
-	// Initialize logging first
-	if err := logger.InitLogger(); err != nil {
-		log.Fatalf("Failed to initialize logger: %v", err)
-	}
-	defer logger.CloseLogger()
-
 	// Setup graceful shutdown
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
@@ -79,6 +82,23 @@ func main() {
 	if *mode != "" {
 		cfg.Mode = *mode
 	}
+	if *dev {
+		cfg.Server.DevMode = true
+	}
+
+	// Initialize logging now that the log location is known
+	logger.InitLogger(cfg.Logging)
+	defer logger.CloseLogger()
+
+	// Misconfiguration in prod stops startup here rather than surfacing later as
+	// mock data or "client not available"; test mode only warns
+	if err := cfg.Validate(); err != nil {
+		if cfg.IsProdMode() {
+			logger.LogError("Invalid configuration", err)
+			log.Fatalf("Invalid configuration:\n%v", err)
+		}
+		logger.Error("Configuration problems (continuing in %s mode): %v", cfg.Mode, err)
+	}
 
 	logger.Info("Configuration loaded - Mode: %s, NFS Root: %s, Port: %d", cfg.Mode, cfg.GetNFSRoot(), cfg.Server.Port)
 	fmt.Printf("Starting Salam Monitoring Platform v%s in %s mode\n", appVersion, cfg.Mode)
@@ -86,13 +106,64 @@ func main() {
 	fmt.Printf("Server will start on port %d\n", cfg.Server.Port)
 
 	// Start web server
+	web.Version = appVersion
 	server := web.NewServer(cfg, staticFiles)
+	go reloadOnSIGHUP(server)
 	if err := server.Start(); err != nil {
 		logger.LogError("Server failed", err)
 		log.Fatalf("Server failed: %v", err)
 	}
 }
 
+// reloadOnSIGHUP reloads the configuration into the running server on each SIGHUP.
+// A configuration that fails to load, or fails validation in prod, is rejected
+// and the current one stays active.
+func reloadOnSIGHUP(server *web.Server) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		logger.Info("Received SIGHUP, reloading configuration")
+
+		cfg, err := config.LoadConfig(*configPath)
+		if err != nil {
+			logger.LogError("Config reload failed, keeping the current configuration", err)
+			continue
+		}
+		if *mode != "" {
+			cfg.Mode = *mode
+		}
+		if *dev {
+			cfg.Server.DevMode = true
+		}
+		if err := cfg.Validate(); err != nil {
+			if cfg.IsProdMode() {
+				logger.Error("Config reload rejected, keeping the current configuration: %v", err)
+				continue
+			}
+			logger.Error("Configuration problems (continuing in %s mode): %v", cfg.Mode, err)
+		}
+
+		server.Reload(cfg)
+	}
+}
+
+// handleConfigDump prints the fully resolved configuration as YAML, with secrets masked
+func handleConfigDump(cfg *config.Config) {
+	if *mode != "" {
+		cfg.Mode = *mode
+	}
+	if *dev {
+		cfg.Server.DevMode = true
+	}
+
+	out, err := yaml.Marshal(config.MaskSecrets(cfg))
+	if err != nil {
+		fmt.Printf("Error encoding configuration: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(string(out))
+}
+
 // getConfigSource returns a description of where config is loaded from
 func getConfigSource(configPath string) string {
 	if configPath == "" {
@@ -116,6 +187,11 @@ func handleCLI(args []string, configPath string) {
 			os.Exit(1)
 		}
 
+		if len(args) > 1 && args[1] == "dump" {
+			handleConfigDump(cfg)
+			return
+		}
+
 		fmt.Printf("Configuration Debug Info:\n")
 		fmt.Printf("  Config Source: %s\n", getConfigSource(configPath))
 		fmt.Printf("  Mode: %s\n", cfg.Mode)
@@ -131,6 +207,8 @@ func handleCLI(args []string, configPath string) {
 		handleYarnCommand(args[1:], configPath)
 	case "wf":
 		handleWorkflowCommand(args[1:], configPath)
+	case "health":
+		handleHealthCommand(configPath)
 	default:
 		fmt.Printf("Unknown command: %s\\n", command)
 		showUsage()
@@ -138,46 +216,205 @@ func handleCLI(args []string, configPath string) {
 	}
 }
 
+// handleHealthCommand probes NFS, Yarn and Informatica and prints one line per
+// dependency. Like /healthz, only NFS is critical: the process exits 1 when it is
+// down, while Yarn or Informatica failures are reported without failing.
+func handleHealthCommand(configPath string) {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	type check struct {
+		name     string
+		critical bool
+		status   string
+		detail   string
+	}
+	var checks []check
+
+	nfsCheck := check{name: "NFS", critical: true, status: "OK", detail: cfg.GetNFSRoot()}
+	if info, err := os.Stat(cfg.GetNFSRoot()); err != nil {
+		nfsCheck.status, nfsCheck.detail = "FAIL", err.Error()
+	} else if !info.IsDir() {
+		nfsCheck.status, nfsCheck.detail = "FAIL", cfg.GetNFSRoot()+" is not a directory"
+	}
+	checks = append(checks, nfsCheck)
+
+	yarnCheck := check{name: "Yarn", status: "OK", detail: strings.Join(cfg.GetYarnURLs(), ", ")}
+	if !web.NewYarnClient(cfg, nil).IsHealthy() {
+		yarnCheck.status = "FAIL"
+	}
+	checks = append(checks, yarnCheck)
+
+	infCheck := check{name: "Informatica", status: "OK", detail: fmt.Sprintf("%s:%d/%s",
+		cfg.Services.InformaticaDB.Host, cfg.Services.InformaticaDB.Port, cfg.Services.InformaticaDB.Database)}
+	infClient, err := web.NewInformaticaClient(cfg)
+	switch {
+	case err != nil:
+		infCheck.status, infCheck.detail = "FAIL", err.Error()
+	case !infClient.IsHealthy():
+		infCheck.status = "FAIL"
+	case infClient.IsMockMode():
+		infCheck.status, infCheck.detail = "DEGRADED", "serving mock data"
+	}
+	if infClient != nil {
+		infClient.Close()
+	}
+	checks = append(checks, infCheck)
+
+	failed := false
+	fmt.Printf("%-12s %-9s %s\n", "DEPENDENCY", "STATUS", "DETAIL")
+	for _, c := range checks {
+		fmt.Printf("%-12s %-9s %s\n", c.name, c.status, c.detail)
+		if c.critical && c.status == "FAIL" {
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
 func handleLogsCommand(args []string, configPath string) {
 	if len(args) == 0 {
-		fmt.Println("Usage: salam-monitor logs <subcommand>")
+		fmt.Println("Usage: salam-monitor logs <subcommand> [--errors-only]")
 		fmt.Println("Subcommands:")
-		fmt.Println("  today    Show today's logs")
+		fmt.Println("  today              Show today's logs")
+		fmt.Println("  date=YYYY-MM-DD    Show logs for a past date")
+		fmt.Println("  search keyword=\"<text>\"|regex=\"<pattern>\" [date=YYYY-MM-DD]")
+		fmt.Println("                     Search log lines")
 		return
 	}
 
-	switch args[0] {
-	case "today":
-		fmt.Println("Showing today's logs...")
-
-		// Load config to get NFS path
-		cfg, err := config.LoadConfig(configPath)
-		if err != nil {
-			fmt.Printf("Error loading config: %v\n", err)
-			return
+	errorsOnly := false
+	for _, arg := range args[1:] {
+		if arg == "--errors-only" {
+			errorsOnly = true
 		}
+	}
 
-		// Initialize NFS scanner and scan today's workflows
-		scanner := nfs.NewScanner(cfg.GetNFSRoot())
-		workflows, err := scanner.ScanTodaysLogs()
-		if err != nil {
-			fmt.Printf("Error scanning workflows: %v\n", err)
-			return
+	var date string
+	switch {
+	case args[0] == "today":
+		fmt.Println("Showing today's logs...")
+	case args[0] == "search":
+		handleLogsSearch(args[1:], configPath)
+		return
+	case strings.HasPrefix(args[0], "date="):
+		date = strings.Trim(strings.TrimPrefix(args[0], "date="), "\"")
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			fmt.Printf("Invalid date %q: expected YYYY-MM-DD, e.g. date=2024-11-20\n", date)
+			os.Exit(1)
 		}
+		fmt.Printf("Showing logs for %s...\n", date)
+	default:
+		fmt.Printf("Unknown logs subcommand: %s\n", args[0])
+		return
+	}
+
+	// Load config to get NFS path
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		return
+	}
 
-		fmt.Printf("Found %d workflows today:\n\n", len(workflows))
+	scanner := web.NewNFSScanner(cfg)
+	if date == "" {
+		// Today follows the configured business day, as in the web view
+		date = scanner.Today()
+	}
+	workflows, err := scanner.ScanLogsForDate(context.Background(), date)
+	if err != nil {
+		fmt.Printf("Error scanning workflows: %v\n", err)
+		return
+	}
+	if errorsOnly {
+		var failing []*nfs.WorkflowSummary
 		for _, wf := range workflows {
-			fmt.Printf("Workflow: %s\n", wf.Workflow)
-			fmt.Printf("  Source: %s\n", wf.Source)
-			fmt.Printf("  Status: %s\n", wf.Status)
-			fmt.Printf("  Log Entries: %d\n", len(wf.Logs))
 			if wf.HasErrors {
-				fmt.Printf("  ⚠️  HAS ERRORS\n")
+				failing = append(failing, wf)
 			}
-			fmt.Println()
 		}
-	default:
-		fmt.Printf("Unknown logs subcommand: %s\n", args[0])
+		workflows = failing
+	}
+
+	label := "today"
+	if args[0] != "today" {
+		label = "on " + date
+	}
+	if errorsOnly {
+		fmt.Printf("Found %d workflows with errors %s:\n\n", len(workflows), label)
+	} else {
+		fmt.Printf("Found %d workflows %s:\n\n", len(workflows), label)
+	}
+	for _, wf := range workflows {
+		fmt.Printf("Workflow: %s\n", wf.Workflow)
+		fmt.Printf("  Source: %s\n", wf.Source)
+		fmt.Printf("  Status: %s\n", wf.Status)
+		fmt.Printf("  Log Entries: %d\n", len(wf.Logs))
+		if wf.HasErrors {
+			fmt.Printf("  ⚠️  HAS ERRORS\n")
+		}
+		fmt.Println()
+	}
+}
+
+// handleLogsSearch prints the first matching line of each log file for a keyword
+// or regex search, today or on the given date
+func handleLogsSearch(args []string, configPath string) {
+	var keyword, pattern, date string
+	for _, arg := range args {
+		key, value, _ := strings.Cut(arg, "=")
+		value = strings.Trim(value, "\"")
+		switch key {
+		case "keyword":
+			keyword = value
+		case "regex":
+			pattern = value
+		case "date":
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				fmt.Printf("Invalid date %q: expected YYYY-MM-DD, e.g. date=2024-11-20\n", value)
+				os.Exit(1)
+			}
+			date = value
+		}
+	}
+	if (keyword == "") == (pattern == "") {
+		fmt.Println("Usage: logs search keyword=\"<text>\" [date=YYYY-MM-DD]")
+		fmt.Println("       logs search regex=\"<pattern>\" [date=YYYY-MM-DD]")
+		return
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		return
+	}
+
+	scanner := web.NewNFSScanner(cfg)
+	if date == "" {
+		date = scanner.Today()
+	}
+	var matches []*nfs.LogEntry
+	query := keyword
+	if pattern != "" {
+		query = pattern
+		matches, err = scanner.SearchLogsRegexForDate(context.Background(), date, pattern)
+	} else {
+		matches, err = scanner.SearchLogsForDate(context.Background(), date, keyword)
+	}
+	if err != nil {
+		fmt.Printf("Error searching logs: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Found %d matching log files for %q on %s:\n\n", len(matches), query, date)
+	for _, match := range matches {
+		fmt.Printf("%s / %s [%s]\n", match.Source, match.Workflow, match.LogType)
+		fmt.Printf("  %s\n\n", strings.TrimSpace(match.Content))
 	}
 }
 
@@ -186,6 +423,8 @@ func handleYarnCommand(args []string, configPath string) {
 		fmt.Println("Usage: salam-monitor yarn <subcommand>")
 		fmt.Println("Subcommands:")
 		fmt.Println("  kill pattern=\"<pattern>\"    Kill jobs matching pattern")
+		fmt.Println("  kill-ids <id1,id2,...>       Kill the listed applications")
+		fmt.Println("  kill-stale duration=<6h>     Kill applications running longer than duration")
 		fmt.Println("  list                         List running applications")
 		return
 	}
@@ -197,8 +436,22 @@ func handleYarnCommand(args []string, configPath string) {
 		return
 	}
 
+	// Kills are audited like those made through the web UI
+	store, err := history.Open(cfg.Database.SQLitePath)
+	if err != nil {
+		fmt.Printf("Warning: history database unavailable, audit entries go to the audit file only: %v\n", err)
+		store = &history.Store{}
+	}
+	defer store.Close()
+	auditLog, err := audit.Open(cfg.Logging.AuditFile, store)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	defer auditLog.Close()
+
 	// Initialize Yarn client
-	client := yarn.NewClient(cfg.GetYarnURL())
+	client := web.NewYarnClient(cfg, auditLog.ObserveKill)
+	ctx := audit.WithRequester(context.Background(), audit.CLIRequester())
 
 	switch args[0] {
 	case "kill":
@@ -210,7 +463,7 @@ func handleYarnCommand(args []string, configPath string) {
 		pattern = strings.Trim(pattern, "\"")
 
 		fmt.Printf("Killing Yarn applications matching pattern: %s\n", pattern)
-		killedApps, err := client.KillApplicationsByPattern(pattern)
+		killedApps, err := client.KillApplicationsByPatternContext(ctx, pattern)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 			return
@@ -219,6 +472,69 @@ func handleYarnCommand(args []string, configPath string) {
 		for _, appID := range killedApps {
 			fmt.Printf("  - %s\n", appID)
 		}
+	case "kill-ids":
+		if len(args) < 2 {
+			fmt.Println("Usage: yarn kill-ids <id1,id2,...>")
+			return
+		}
+		appIDs := config.SplitList(args[1])
+		if len(appIDs) == 0 {
+			fmt.Println("Usage: yarn kill-ids <id1,id2,...>")
+			return
+		}
+
+		fmt.Printf("Killing %d Yarn applications\n", len(appIDs))
+		results := client.KillApplicationsContext(ctx, appIDs)
+		killed := 0
+		for _, result := range results {
+			if result.Killed {
+				killed++
+				fmt.Printf("  - %s: killed\n", result.ID)
+			} else {
+				fmt.Printf("  - %s: failed: %s\n", result.ID, result.Error)
+			}
+		}
+		fmt.Printf("Killed %d of %d applications\n", killed, len(results))
+	case "kill-stale":
+		if len(args) < 2 || !strings.HasPrefix(args[1], "duration=") {
+			fmt.Println("Usage: yarn kill-stale duration=<6h> [--dry-run]")
+			return
+		}
+		maxDuration, err := time.ParseDuration(strings.TrimPrefix(args[1], "duration="))
+		if err != nil || maxDuration <= 0 {
+			fmt.Println("Error: duration must be a positive duration such as 6h or 90m")
+			return
+		}
+
+		if len(args) > 2 && args[2] == "--dry-run" {
+			apps, err := client.GetStaleApplicationsContext(ctx, maxDuration)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Printf("%d applications running longer than %v would be killed:\n", len(apps), maxDuration)
+			for _, app := range apps {
+				fmt.Printf("  - %s (%s)\n", app.ID, app.Name)
+			}
+			return
+		}
+
+		fmt.Printf("Killing Yarn applications running longer than %v\n", maxDuration)
+		results, err := client.KillStaleApplicationsContext(ctx, maxDuration)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		killed := 0
+		for _, result := range results {
+			if result.Killed {
+				killed++
+				fmt.Printf("  - %s: killed\n", result.ID)
+			} else {
+				fmt.Printf("  - %s: failed: %s\n", result.ID, result.Error)
+			}
+		}
+		fmt.Printf("Killed %d of %d applications\n", killed, len(results))
 	case "list":
 		fmt.Println("Listing running Yarn applications...")
 		apps, err := client.GetRunningApplications()
@@ -234,6 +550,13 @@ func handleYarnCommand(args []string, configPath string) {
 			fmt.Printf("  User: %s\n", app.User)
 			fmt.Printf("  Queue: %s\n", app.Queue)
 			fmt.Printf("  Progress: %.1f%%\n", app.Progress)
+			if started := app.StartedAt(); !started.IsZero() {
+				fmt.Printf("  Started: %s\n", started.Format("2006-01-02 15:04:05"))
+			}
+			if finished := app.FinishedAt(); finished != nil {
+				fmt.Printf("  Finished: %s\n", finished.Format("2006-01-02 15:04:05"))
+			}
+			fmt.Printf("  Elapsed: %s\n", yarn.FormatDuration(app.Elapsed().Milliseconds()))
 			fmt.Println()
 		}
 	default:
@@ -246,6 +569,7 @@ func handleWorkflowCommand(args []string, configPath string) {
 		fmt.Println("Usage: salam-monitor wf <subcommand>")
 		fmt.Println("Subcommands:")
 		fmt.Println("  tree platform=\"<platform>\"    Show workflow tree")
+		fmt.Println("  detail statId=<n>              Show a workflow run and its tasks")
 		return
 	}
 
@@ -269,16 +593,7 @@ func handleWorkflowCommand(args []string, configPath string) {
 
 		// Initialize Informatica client if available
 		if cfg.IsProdMode() {
-			infConfig := informatica.DatabaseConfig{
-				Host:       cfg.Services.InformaticaDB.Host,
-				Port:       cfg.Services.InformaticaDB.Port,
-				Database:   cfg.Services.InformaticaDB.Database,
-				Username:   cfg.Services.InformaticaDB.Username,
-				Password:   cfg.Services.InformaticaDB.Password,
-				TimeOffset: cfg.Services.InformaticaDB.TimeOffset,
-			}
-
-			infClient, err := informatica.NewClient(infConfig)
+			infClient, err := web.NewInformaticaClient(cfg)
 			if err != nil {
 				fmt.Printf("Error connecting to Informatica: %v\n", err)
 				return
@@ -286,7 +601,7 @@ func handleWorkflowCommand(args []string, configPath string) {
 			defer infClient.Close()
 
 			// Get today's workflows
-			workflows, err := infClient.GetWorkflowsToday()
+			workflows, err := infClient.GetWorkflowsToday(context.Background())
 			if err != nil {
 				fmt.Printf("Error getting workflows: %v\n", err)
 				return
@@ -300,7 +615,7 @@ func handleWorkflowCommand(args []string, configPath string) {
 					fmt.Printf("   Started: %s\n", wf.StartedAt.Format("2006-01-02 15:04:05"))
 
 					// Get tasks for this workflow
-					wfWithTasks, err := infClient.GetWorkflowWithTasks(wf.StatID)
+					wfWithTasks, err := infClient.GetWorkflowWithTasks(context.Background(), wf.StatID)
 					if err == nil && len(wfWithTasks.Tasks) > 0 {
 						fmt.Printf("   Tasks:\n")
 						for _, task := range wfWithTasks.Tasks {
@@ -315,8 +630,8 @@ func handleWorkflowCommand(args []string, configPath string) {
 			fmt.Println("Showing NFS-based workflow information instead...")
 
 			// Fall back to NFS scanning
-			scanner := nfs.NewScanner(cfg.GetNFSRoot())
-			workflows, err := scanner.ScanTodaysLogs()
+			scanner := web.NewNFSScanner(cfg)
+			workflows, err := scanner.ScanTodaysLogs(context.Background())
 			if err != nil {
 				fmt.Printf("Error scanning NFS: %v\n", err)
 				return
@@ -332,6 +647,54 @@ func handleWorkflowCommand(args []string, configPath string) {
 				}
 			}
 		}
+	case "detail":
+		if len(args) < 2 || !strings.HasPrefix(args[1], "statId=") {
+			fmt.Println("Usage: wf detail statId=<n>")
+			return
+		}
+		statID, err := strconv.ParseInt(strings.TrimPrefix(args[1], "statId="), 10, 64)
+		if err != nil {
+			fmt.Printf("Invalid stat ID: %s\n", strings.TrimPrefix(args[1], "statId="))
+			return
+		}
+
+		infClient, err := web.NewInformaticaClient(cfg)
+		if err != nil {
+			fmt.Printf("Error connecting to Informatica: %v\n", err)
+			return
+		}
+		defer infClient.Close()
+		if infClient.IsMockMode() {
+			fmt.Println("Informatica database not available, showing mock data")
+		}
+
+		detail, err := infClient.GetWorkflowWithTasks(context.Background(), statID)
+		if errors.Is(err, informatica.ErrWorkflowNotFound) {
+			fmt.Printf("No workflow found with stat ID %d\n", statID)
+			return
+		}
+		if err != nil {
+			fmt.Printf("Error getting workflow: %v\n", err)
+			return
+		}
+
+		wf := detail.Workflow
+		fmt.Printf("📁 %s (stat ID %d)\n", wf.WorkflowName, wf.StatID)
+		fmt.Printf("   Status:   %s\n", wf.Status)
+		fmt.Printf("   Started:  %s\n", wf.StartedAt.Format("2006-01-02 15:04:05"))
+		if wf.FinishedAt != nil {
+			fmt.Printf("   Finished: %s\n", wf.FinishedAt.Format("2006-01-02 15:04:05"))
+		}
+		fmt.Printf("   Elapsed:  %s\n", wf.Elapsed)
+
+		if len(detail.Tasks) == 0 {
+			fmt.Println("   No tasks recorded")
+			return
+		}
+		fmt.Printf("   Tasks:\n")
+		for _, task := range detail.Tasks {
+			fmt.Printf("   └─ %-30s %-12s %-10s %s\n", task.TaskName, task.ServiceName, task.Status, task.Elapsed)
+		}
 	default:
 		fmt.Printf("Unknown workflow subcommand: %s\n", args[0])
 	}
@@ -348,15 +711,23 @@ func showUsage() {
 	fmt.Println()
 	fmt.Println("Commands:")
 	fmt.Println("  config                                   Show current configuration")
+	fmt.Println("  config dump                              Print the resolved configuration as YAML")
+	fmt.Println("  health                                   Check NFS, Yarn and Informatica")
 	fmt.Println("  logs today                               Show today's logs")
+	fmt.Println("  logs date=2024-11-20 [--errors-only]     Show logs for a past date")
+	fmt.Println("  logs search keyword=\"timeout\"           Search log lines (regex= for a pattern)")
 	fmt.Println("  yarn kill pattern=\"spark_ingest\"         Kill jobs matching pattern")
+	fmt.Println("  yarn kill-ids app_1,app_2                Kill the listed applications")
+	fmt.Println("  yarn kill-stale duration=6h [--dry-run]  Kill applications running longer than 6h")
 	fmt.Println("  yarn list                                List running applications")
 	fmt.Println("  wf tree platform=\"miniboss\"             Show workflow tree for platform")
+	fmt.Println("  wf detail statId=1001                    Show a workflow run and its tasks")
 	fmt.Println()
 	fmt.Println("Configuration:")
 	fmt.Println("  Use .env file (recommended):             salam-monitor --config=path/to/.env")
 	fmt.Println("  Use YAML file (legacy):                  salam-monitor --config=config.yaml")
 	fmt.Println("  Environment variables override all settings")
+	fmt.Println("  Send SIGHUP to reload log level, credentials and Yarn/Informatica settings")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  salam-monitor --config=/opt/monitoring/.env")
@@ -52,4 +52,4 @@ echo "3. Check status: systemctl status salam-monitor"
 echo "4. View logs: journalctl -u salam-monitor -f"
 echo "5. Access web UI: http://localhost:8080"
 echo ""
-echo "Application logs: /home/$SERVICE_USER/nfs_backup/monitoring/monitoring_util/<date>/info.log"
\ No newline at end of file
+echo "Application logs: <logging.file_path in config.yaml>/<date>/info.log"
\ No newline at end of file
@@ -0,0 +1,45 @@
+package alert
+
+import (
+	"context"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// pollTimeout bounds one poll: the backend query plus the notification it triggers
+const pollTimeout = time.Minute
+
+// Message is one notification, such as a list of newly failed workflows
+type Message struct {
+	Title string
+	Text  string
+}
+
+// Notifier delivers alert messages to people, e.g. through a chat webhook or email
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// runEvery calls poll immediately and then every interval until ctx is cancelled,
+// logging failures and passing each outcome to report
+func runEvery(ctx context.Context, interval time.Duration, name string, poll func(context.Context) error, report func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		pollCtx, cancel := context.WithTimeout(ctx, pollTimeout)
+		err := poll(pollCtx)
+		cancel()
+		if err != nil {
+			logger.LogError(name+" failed", err)
+		}
+		report(err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package alert
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// smtpTimeout bounds the whole SMTP exchange when ctx has no earlier deadline
+const smtpTimeout = 30 * time.Second
+
+// EmailConfig holds the SMTP relay and addresses for EmailNotifier
+type EmailConfig struct {
+	Host     string
+	Port     int
+	Username string // empty skips authentication
+	Password string
+	From     string
+	To       []string
+}
+
+// EmailNotifier sends messages as plain-text email through an SMTP relay,
+// upgrading to TLS when the server offers STARTTLS
+type EmailNotifier struct {
+	config EmailConfig
+}
+
+// NewEmailNotifier creates a notifier that mails every recipient in config.To
+func NewEmailNotifier(config EmailConfig) *EmailNotifier {
+	return &EmailNotifier{config: config}
+}
+
+// Notify sends msg to all recipients in one email
+func (n *EmailNotifier) Notify(ctx context.Context, msg Message) error {
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+	conn, err := (&net.Dialer{Timeout: smtpTimeout}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+	deadline := time.Now().Add(smtpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, n.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session with %s: %w", addr, err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.config.Host}); err != nil {
+			return fmt.Errorf("SMTP STARTTLS failed: %w", err)
+		}
+	}
+	if n.config.Username != "" {
+		auth := smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(n.config.From); err != nil {
+		return fmt.Errorf("SMTP server rejected sender %s: %w", n.config.From, err)
+	}
+	for _, to := range n.config.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("SMTP server rejected recipient %s: %w", to, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	if _, err := w.Write(n.message(msg)); err != nil {
+		return fmt.Errorf("failed to write email body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected the email: %w", err)
+	}
+	return client.Quit()
+}
+
+// message renders msg as an RFC 5322 email with CRLF line endings
+func (n *EmailNotifier) message(msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.config.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", strings.NewReplacer("\r", " ", "\n", " ").Replace(msg.Title))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	for _, line := range strings.Split(msg.Text, "\n") {
+		// A lone "." would end the DATA section early
+		if strings.HasPrefix(line, ".") {
+			line = "." + line
+		}
+		b.WriteString(line + "\r\n")
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,101 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"salam-monitoring/internal/informatica"
+	"salam-monitoring/internal/logger"
+)
+
+// FailureSource returns today's failed workflow runs, e.g. Client.GetFailedWorkflowsToday
+type FailureSource func(ctx context.Context) ([]informatica.WorkflowStat, error)
+
+// WorkflowFailurePoller periodically fetches today's failed Informatica workflows
+// and notifies about the runs it has not alerted on yet. Runs are identified by
+// stat ID, so a workflow that fails again later in the day is a new alert. The
+// alerted set lives in memory; a restart re-alerts failures already seen today.
+type WorkflowFailurePoller struct {
+	source   FailureSource
+	notifier Notifier
+	interval time.Duration
+	allow    map[string]bool // lowercased workflow names; empty alerts on every workflow
+	alerted  map[int64]bool
+}
+
+// NewWorkflowFailurePoller creates a poller that checks every interval. When
+// workflows is non-empty only those workflow names (case-insensitive) alert.
+func NewWorkflowFailurePoller(source FailureSource, notifier Notifier, interval time.Duration, workflows []string) *WorkflowFailurePoller {
+	allow := make(map[string]bool, len(workflows))
+	for _, name := range workflows {
+		allow[strings.ToLower(name)] = true
+	}
+	return &WorkflowFailurePoller{
+		source:   source,
+		notifier: notifier,
+		interval: interval,
+		allow:    allow,
+		alerted:  make(map[int64]bool),
+	}
+}
+
+// Run polls until ctx is cancelled, passing each poll's outcome to report
+func (p *WorkflowFailurePoller) Run(ctx context.Context, report func(error)) {
+	logger.Info("Informatica failure alerts enabled, polling every %v", p.interval)
+	runEvery(ctx, p.interval, "Informatica failure alert poll", p.Poll, report)
+}
+
+// Poll fetches the current failures and sends one notification listing the new
+// ones. If the notification fails, the same runs are retried on the next poll.
+func (p *WorkflowFailurePoller) Poll(ctx context.Context) error {
+	failed, err := p.source(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch failed workflows: %w", err)
+	}
+
+	current := make(map[int64]bool, len(failed))
+	var fresh []informatica.WorkflowStat
+	for _, wf := range failed {
+		if len(p.allow) > 0 && !p.allow[strings.ToLower(wf.WorkflowName)] {
+			continue
+		}
+		current[wf.StatID] = true
+		if !p.alerted[wf.StatID] {
+			fresh = append(fresh, wf)
+		}
+	}
+
+	// Forget runs that dropped out of today's failures so the set doesn't grow across days
+	for id := range p.alerted {
+		if !current[id] {
+			delete(p.alerted, id)
+		}
+	}
+
+	if len(fresh) == 0 {
+		return nil
+	}
+	if err := p.notifier.Notify(ctx, failureMessage(fresh)); err != nil {
+		return fmt.Errorf("failed to send alert for %d failed workflow(s): %w", len(fresh), err)
+	}
+	for _, wf := range fresh {
+		p.alerted[wf.StatID] = true
+	}
+	logger.Info("Sent failure alert for %d Informatica workflow(s)", len(fresh))
+	return nil
+}
+
+// failureMessage lists the failed runs, one per line
+func failureMessage(failed []informatica.WorkflowStat) Message {
+	var text strings.Builder
+	for _, wf := range failed {
+		fmt.Fprintf(&text, "• %s (stat %d) started %s, ran %s\n",
+			wf.WorkflowName, wf.StatID, wf.StartedAt.Format("15:04:05"), wf.Elapsed)
+	}
+	return Message{
+		Title: fmt.Sprintf("%d Informatica workflow(s) failed", len(failed)),
+		Text:  strings.TrimSuffix(text.String(), "\n"),
+	}
+}
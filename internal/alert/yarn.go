@@ -0,0 +1,96 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/yarn"
+)
+
+// StaleSource returns the Yarn applications running longer than maxDuration,
+// e.g. Client.GetStaleApplicationsContext
+type StaleSource func(ctx context.Context, maxDuration time.Duration) ([]*yarn.Application, error)
+
+// StaleAppMonitor periodically looks for Yarn applications running longer than
+// a threshold and notifies about each one once. An application is only reported
+// again if it drops out of the stale list and later shows up in it again.
+type StaleAppMonitor struct {
+	source    StaleSource
+	notifier  Notifier
+	interval  time.Duration
+	threshold time.Duration
+	reported  map[string]bool
+}
+
+// NewStaleAppMonitor creates a monitor that checks every interval for
+// applications running longer than threshold
+func NewStaleAppMonitor(source StaleSource, notifier Notifier, interval, threshold time.Duration) *StaleAppMonitor {
+	return &StaleAppMonitor{
+		source:    source,
+		notifier:  notifier,
+		interval:  interval,
+		threshold: threshold,
+		reported:  make(map[string]bool),
+	}
+}
+
+// Run checks until ctx is cancelled, passing each check's outcome to report
+func (m *StaleAppMonitor) Run(ctx context.Context, report func(error)) {
+	logger.Info("Stale Yarn application alerts enabled for apps running over %v, checking every %v", m.threshold, m.interval)
+	runEvery(ctx, m.interval, "Stale Yarn application check", m.Poll, report)
+}
+
+// Poll fetches the stale applications and sends one notification listing those
+// not reported yet. If the notification fails, they are retried on the next poll.
+func (m *StaleAppMonitor) Poll(ctx context.Context) error {
+	apps, err := m.source(ctx, m.threshold)
+	if err != nil {
+		return fmt.Errorf("failed to fetch stale applications: %w", err)
+	}
+
+	current := make(map[string]bool, len(apps))
+	var fresh []*yarn.Application
+	for _, app := range apps {
+		current[app.ID] = true
+		if !m.reported[app.ID] {
+			fresh = append(fresh, app)
+		}
+	}
+
+	// Applications that finished or were killed can be reported again if they reappear
+	for id := range m.reported {
+		if !current[id] {
+			delete(m.reported, id)
+		}
+	}
+
+	if len(fresh) == 0 {
+		return nil
+	}
+	if err := m.notifier.Notify(ctx, m.staleMessage(fresh)); err != nil {
+		return fmt.Errorf("failed to send alert for %d stale application(s): %w", len(fresh), err)
+	}
+	for _, app := range fresh {
+		m.reported[app.ID] = true
+	}
+	logger.Info("Sent stale application alert for %d Yarn application(s)", len(fresh))
+	return nil
+}
+
+// staleMessage lists the stale applications, one per line
+func (m *StaleAppMonitor) staleMessage(apps []*yarn.Application) Message {
+	var text strings.Builder
+	fmt.Fprintf(&text, "These Yarn applications have been running longer than %v:\n\n", m.threshold)
+	for _, app := range apps {
+		elapsed := time.Since(app.StartedAt()).Round(time.Minute)
+		fmt.Fprintf(&text, "%s  %s  (user %s, queue %s) running for %v\n",
+			app.ID, app.Name, app.User, app.Queue, elapsed)
+	}
+	return Message{
+		Title: fmt.Sprintf("%d stale Yarn application(s)", len(apps)),
+		Text:  strings.TrimSuffix(text.String(), "\n"),
+	}
+}
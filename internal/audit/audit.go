@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"salam-monitoring/internal/history"
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/yarn"
+)
+
+// Operations recorded in the audit log
+const (
+	OpKillApplication           = "kill_application"
+	OpKillApplicationsByPattern = "kill_applications_by_pattern"
+)
+
+// Results recorded in the audit log
+const (
+	ResultSuccess = "success"
+	ResultFailed  = "failed"
+)
+
+// Log appends audit entries as JSON lines to a dedicated file and, when the
+// history store is enabled, to its audit_log table. A nil *Log discards entries.
+type Log struct {
+	mu    sync.Mutex
+	file  *os.File
+	store *history.Store
+}
+
+// Open opens (creating if needed) the audit file at path for appending. An empty
+// path keeps only the history store copy.
+func Open(path string, store *history.Store) (*Log, error) {
+	l := &Log{store: store}
+	if path == "" {
+		return l, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", path, err)
+	}
+	l.file = file
+	logger.Info("Audit log opened: %s", path)
+	return l, nil
+}
+
+// Close closes the audit file
+func (l *Log) Close() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+// Record writes an entry to the audit file and the history store. Failures are
+// logged rather than returned so auditing never blocks the operation itself.
+func (l *Log) Record(entry history.AuditEntry) {
+	if l == nil {
+		return
+	}
+
+	if l.file != nil {
+		line, err := json.Marshal(entry)
+		if err == nil {
+			l.mu.Lock()
+			_, err = l.file.Write(append(line, '\n'))
+			l.mu.Unlock()
+		}
+		if err != nil {
+			logger.LogError("Failed to write audit entry for "+entry.AppID, err)
+		}
+	}
+
+	if err := l.store.RecordAudit(entry); err != nil {
+		logger.LogError("Failed to store audit entry", err)
+	}
+}
+
+// ObserveKill records a Yarn kill; use it as yarn.ClientOptions.OnKill
+func (l *Log) ObserveKill(ctx context.Context, event yarn.KillEvent) {
+	entry := history.AuditEntry{
+		Time:      time.Now(),
+		Operation: OpKillApplication,
+		AppID:     event.AppID,
+		AppName:   event.AppName,
+		Pattern:   event.Pattern,
+		Requester: Requester(ctx),
+		Result:    ResultSuccess,
+	}
+	if event.Pattern != "" {
+		entry.Operation = OpKillApplicationsByPattern
+	}
+	if event.Err != nil {
+		entry.Result = ResultFailed
+		entry.Error = event.Err.Error()
+	}
+	l.Record(entry)
+}
+
+type requesterKey struct{}
+
+// WithRequester returns a context carrying who asked for the operation, such as
+// a client IP or "cli:<user>"
+func WithRequester(ctx context.Context, requester string) context.Context {
+	return context.WithValue(ctx, requesterKey{}, requester)
+}
+
+// Requester returns the requester stored in ctx, or "unknown"
+func Requester(ctx context.Context) string {
+	if requester, ok := ctx.Value(requesterKey{}).(string); ok && requester != "" {
+		return requester
+	}
+	return "unknown"
+}
+
+// CLIRequester identifies the OS user running a CLI command
+func CLIRequester() string {
+	if u, err := user.Current(); err == nil {
+		return "cli:" + u.Username
+	}
+	return "cli:" + os.Getenv("USER")
+}
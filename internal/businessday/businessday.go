@@ -0,0 +1,65 @@
+package businessday
+
+import (
+	"fmt"
+	"time"
+)
+
+// Boundary marks where one business day ends and the next begins: Offset after
+// midnight in Location, e.g. 06:00 Asia/Riyadh so that runs of the nightly ETL
+// count towards the day they load. The zero value is midnight local time.
+type Boundary struct {
+	Offset   time.Duration
+	Location *time.Location // nil means time.Local
+}
+
+// Parse builds a Boundary from a start time written HH:MM and an IANA zone
+// name; empty values mean midnight and the server's local time.
+func Parse(start, timezone string) (Boundary, error) {
+	var b Boundary
+	if start != "" {
+		t, err := time.Parse("15:04", start)
+		if err != nil {
+			return Boundary{}, fmt.Errorf("invalid business day start %q: expected HH:MM", start)
+		}
+		b.Offset = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return Boundary{}, fmt.Errorf("invalid business day timezone %q: %w", timezone, err)
+		}
+		b.Location = loc
+	}
+	return b, nil
+}
+
+// IsZero reports whether b is the default boundary, midnight local time
+func (b Boundary) IsZero() bool {
+	return b.Offset == 0 && b.Location == nil
+}
+
+func (b Boundary) location() *time.Location {
+	if b.Location != nil {
+		return b.Location
+	}
+	return time.Local
+}
+
+// Date returns the business date t falls on, formatted as 2006-01-02 like the
+// NFS date directories
+func (b Boundary) Date(t time.Time) string {
+	return t.In(b.location()).Add(-b.Offset).Format("2006-01-02")
+}
+
+// Today returns the current business date
+func (b Boundary) Today() string {
+	return b.Date(time.Now())
+}
+
+// Start returns the instant the business day containing t began
+func (b Boundary) Start(t time.Time) time.Time {
+	shifted := t.In(b.location()).Add(-b.Offset)
+	midnight := time.Date(shifted.Year(), shifted.Month(), shifted.Day(), 0, 0, 0, 0, b.location())
+	return midnight.Add(b.Offset)
+}
@@ -5,25 +5,80 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"salam-monitoring/internal/businessday"
 
 	"gopkg.in/yaml.v3"
 )
 
+// DefaultBrandName is the product name shown when brand_name is not configured
+const DefaultBrandName = "Salam"
+
+// DefaultRefreshInterval is the seconds between dashboard panel refreshes when
+// refresh_interval is not configured. MinRefreshInterval is the shortest
+// interval accepted, so a typo cannot make every open page poll the APIs
+// several times a second.
+const (
+	DefaultRefreshInterval = 30
+	MinRefreshInterval     = 5
+)
+
 // Config represents the application configuration
 type Config struct {
 	Mode        string            `yaml:"mode"` // test or prod
 	Server      ServerConfig      `yaml:"server"`
 	Paths       PathsConfig       `yaml:"paths"`
 	Services    ServicesConfig    `yaml:"services"`
+	NFS         NFSConfig         `yaml:"nfs"`
 	Informatica InformaticaConfig `yaml:"informatica"`
 	Logging     LoggingConfig     `yaml:"logging"`
 	Database    DatabaseConfig    `yaml:"database"`
+	Alerts      AlertsConfig      `yaml:"alerts"`
+	BusinessDay BusinessDayConfig `yaml:"business_day"`
+	SLA         SLAConfig         `yaml:"sla"`
+
+	// envErrors holds *_FILE secrets that could not be read; Validate reports them
+	envErrors []error
 }
 
 // ServerConfig holds server-related configuration
 type ServerConfig struct {
-	Port int    `yaml:"port"`
-	Host string `yaml:"host"`
+	Port                int       `yaml:"port"`
+	Host                string    `yaml:"host"`
+	WaitForDependencies bool      `yaml:"wait_for_dependencies"` // hold readiness until NFS and Informatica respond
+	DependencyTimeout   int       `yaml:"dependency_timeout"`    // seconds before missing dependencies are logged as an error; readiness waits regardless
+	StreamInterval      int       `yaml:"stream_interval"`       // seconds between dashboard stream updates
+	YarnPushInterval    int       `yaml:"yarn_push_interval"`    // seconds between RM polls while /ws/yarn clients are connected
+	EnableGzip          bool      `yaml:"enable_gzip"`           // compress large HTML/JSON responses
+	MetricsPrefix       string    `yaml:"metrics_prefix"`        // namespace for Prometheus metric names
+	DevMode             bool      `yaml:"dev_mode"`              // re-parse templates from TemplateDir on every request
+	TemplateDir         string    `yaml:"template_dir"`          // template directory on disk, used in dev mode
+	KillRatePerMinute   int       `yaml:"kill_rate_per_minute"`  // kill requests allowed per client IP per minute, 0 disables the limit
+	KillBurst           int       `yaml:"kill_burst"`            // kill requests a client IP may make back to back
+	StaticMaxAge        int       `yaml:"static_max_age"`        // seconds browsers may cache /static/ assets, 0 makes them revalidate every load
+	RequestTimeout      int       `yaml:"request_timeout"`       // seconds a request may run before it gets 503 and its work is canceled, 0 disables
+	BrandName           string    `yaml:"brand_name"`            // product name shown in page titles, the header and the login prompt
+	RefreshInterval     int       `yaml:"refresh_interval"`      // seconds between dashboard panel refreshes in the browser, 0 disables auto-refresh
+	TLS                 TLSConfig `yaml:"tls"`
+
+	// Basic authentication; leave AuthUser empty to disable. With AuthUser set,
+	// exactly one of AuthPasswordHash (bcrypt) or the plain AuthPassword is required.
+	AuthUser         string `yaml:"auth_user"`
+	AuthPassword     string `yaml:"auth_password"`
+	AuthPasswordHash string `yaml:"auth_password_hash"`
+
+	// CORSAllowedOrigins lists origins (scheme://host[:port]) allowed to call the
+	// API from another site; empty keeps the API same-origin only. "*" allows any
+	// site without credentials and is rejected while AuthUser is set.
+	CORSAllowedOrigins []string `yaml:"cors_allowed_origins"`
+}
+
+// TLSConfig holds HTTPS settings for the web server
+type TLSConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
 }
 
 // PathsConfig holds path configuration for different modes
@@ -39,16 +94,77 @@ type ServicesConfig struct {
 	YarnRMURL     string            `yaml:"yarn_rm_url"`
 	YarnRMURLTest string            `yaml:"yarn_rm_url_test"`
 	InformaticaDB InformaticaConfig `yaml:"informatica_db"`
+
+	YarnRetryAttempts int `yaml:"yarn_retry_attempts"` // total attempts for Yarn GET calls, 1 disables retries
+	YarnRetryDelayMs  int `yaml:"yarn_retry_delay_ms"` // backoff before the first retry, doubled each time
+	YarnTimeout       int `yaml:"yarn_timeout"`        // seconds before a single Yarn RM request is abandoned
+
+	YarnKerberos KerberosConfig `yaml:"yarn_kerberos"`
+}
+
+// KerberosConfig holds SPNEGO settings for a kerberized Yarn RM
+type KerberosConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	Keytab    string `yaml:"keytab"`
+	Principal string `yaml:"principal"`
+	Realm     string `yaml:"realm"`
+	SPN       string `yaml:"spn"`       // defaults to HTTP/<rm host>
+	Krb5Conf  string `yaml:"krb5_conf"` // defaults to /etc/krb5.conf
 }
 
 // InformaticaConfig holds Informatica database configuration
 type InformaticaConfig struct {
+	DBType     string `yaml:"db_type"` // "sqlserver" (default) or "oracle"
 	Host       string `yaml:"host"`
 	Port       int    `yaml:"port"`
 	Database   string `yaml:"database"`
 	Username   string `yaml:"username"`
 	Password   string `yaml:"password"`
-	TimeOffset int    `yaml:"time_offset"` // hours offset for timezone conversion
+	TimeOffset int    `yaml:"time_offset"` // hours offset for timezone conversion, used when timezone is empty
+	Timezone   string `yaml:"timezone"`    // IANA zone such as Asia/Riyadh; handles DST unlike time_offset
+
+	MaxOpenConns    int `yaml:"max_open_conns"`
+	MaxIdleConns    int `yaml:"max_idle_conns"`
+	ConnMaxLifetime int `yaml:"conn_max_lifetime"` // seconds before a pooled connection is recycled
+}
+
+// NFSConfig holds NFS log scanner configuration
+type NFSConfig struct {
+	ErrorPatterns []string `yaml:"error_patterns"` // matched as written; prefix with "re:" for a regex
+	ScanWorkers   int      `yaml:"scan_workers"`   // concurrent source scans, 0 means one per CPU
+	MaxOpenFiles  int      `yaml:"max_open_files"` // log files read at once across all scans, 0 means 64
+	CacheTTL      int      `yaml:"cache_ttl"`      // seconds to reuse today's scan results, 0 disables
+	PastCacheTTL  int      `yaml:"past_cache_ttl"` // seconds to reuse scans of past dates, 0 disables
+	ScanInterval  int      `yaml:"scan_interval"`  // minutes between background scans of today's logs, 0 disables
+
+	// ErrorPatternsIgnoreCase matches ErrorPatterns (or the defaults) regardless of case
+	ErrorPatternsIgnoreCase bool `yaml:"error_patterns_ignore_case"`
+
+	// FollowSymlinks scans symlinked source and workflow directories, e.g.
+	// sources mounted elsewhere, and serves their logs
+	FollowSymlinks bool `yaml:"follow_symlinks"`
+
+	// TimestampLayout is the Go time layout starting each log entry, used to
+	// split logs into entries; empty means 2006-01-02 15:04:05
+	TimestampLayout string `yaml:"timestamp_layout"`
+
+	// LogFiles lists the log file names scanned in each workflow directory;
+	// SourceLogFiles overrides it for individual sources
+	LogFiles       []string            `yaml:"log_files"`
+	SourceLogFiles map[string][]string `yaml:"source_log_files"`
+
+	// CompletionLog is the log file whose presence marks a workflow as finished
+	// and ErrorLog the one where any content marks it as failed; empty means
+	// run.log and error.log. SourceLogRoles overrides them for individual sources.
+	CompletionLog  string                    `yaml:"completion_log"`
+	ErrorLog       string                    `yaml:"error_log"`
+	SourceLogRoles map[string]LogRolesConfig `yaml:"source_log_roles"`
+}
+
+// LogRolesConfig names the completion and error logs of one NFS source
+type LogRolesConfig struct {
+	CompletionLog string `yaml:"completion_log"`
+	ErrorLog      string `yaml:"error_log"`
 }
 
 // LoggingConfig holds logging configuration
@@ -57,11 +173,89 @@ type LoggingConfig struct {
 	FilePath string `yaml:"file_path"`
 	FileLog  bool   `yaml:"file_log"`
 	JSONLog  bool   `yaml:"json_log"`
+
+	// AuditFile receives one JSON line per destructive operation (Yarn kills);
+	// empty keeps audit entries in the history database only
+	AuditFile string `yaml:"audit_file"`
 }
 
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
-	SQLitePath string `yaml:"sqlite_path"`
+	SQLitePath     string `yaml:"sqlite_path"`
+	RecordInterval int    `yaml:"record_interval"` // seconds between background history snapshots
+}
+
+// AlertsConfig holds failure alerting settings
+type AlertsConfig struct {
+	WebhookURL   string   `yaml:"webhook_url"`   // Slack-compatible incoming webhook; empty disables alerts
+	PollInterval int      `yaml:"poll_interval"` // seconds between checks for failed Informatica workflows
+	Workflows    []string `yaml:"workflows"`     // workflow names to alert on, empty alerts on every workflow
+
+	// Stale Yarn applications are emailed through SMTP when SMTP.Host is set
+	StaleAppThreshold int        `yaml:"stale_app_threshold"` // minutes a Yarn application may run before it is reported
+	StaleAppInterval  int        `yaml:"stale_app_interval"`  // seconds between checks for stale applications
+	SMTP              SMTPConfig `yaml:"smtp"`
+}
+
+// SMTPConfig holds the mail relay used for email alerts
+type SMTPConfig struct {
+	Host     string   `yaml:"host"` // empty disables email alerts
+	Port     int      `yaml:"port"`
+	Username string   `yaml:"username"` // empty sends without authentication
+	Password string   `yaml:"password"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+}
+
+// BusinessDayConfig sets when "today" starts for the Informatica and NFS views
+type BusinessDayConfig struct {
+	Start    string `yaml:"start"`    // HH:MM the business day starts at, empty means midnight
+	Timezone string `yaml:"timezone"` // IANA zone of start, empty means the server's local time
+}
+
+// SLAConfig holds the expected maximum durations of Informatica workflows
+type SLAConfig struct {
+	// Workflows maps a workflow name to the longest it may run, as a Go
+	// duration such as 90m or 2h30m; longer runs are flagged as SLA breaches
+	Workflows map[string]string `yaml:"workflows"`
+}
+
+// WorkflowSLAs returns the parsed workflow SLAs, skipping invalid durations;
+// Validate reports those.
+func (c *Config) WorkflowSLAs() map[string]time.Duration {
+	slas := make(map[string]time.Duration, len(c.SLA.Workflows))
+	for name, value := range c.SLA.Workflows {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			slas[name] = d
+		}
+	}
+	return slas
+}
+
+// parseWorkflowSLAs parses an SLA_WORKFLOWS value such as
+// "BRM_LOAD_JOB=90m,DAILY_ETL=2h". Items without a duration are kept with an
+// empty one so Validate reports them.
+func parseWorkflowSLAs(value string) map[string]string {
+	items := SplitList(value)
+	if len(items) == 0 {
+		return nil
+	}
+	slas := make(map[string]string, len(items))
+	for _, item := range items {
+		name, duration, _ := strings.Cut(item, "=")
+		slas[strings.TrimSpace(name)] = strings.TrimSpace(duration)
+	}
+	return slas
+}
+
+// DayBoundary returns the configured business day boundary, or midnight local
+// time when the settings are invalid; Validate reports those.
+func (c *Config) DayBoundary() businessday.Boundary {
+	b, err := businessday.Parse(c.BusinessDay.Start, c.BusinessDay.Timezone)
+	if err != nil {
+		return businessday.Boundary{}
+	}
+	return b
 }
 
 // GetNFSRoot returns the appropriate NFS root path based on mode
@@ -84,12 +278,13 @@ func (c *Config) GetNFSRoot() string {
 	return "/home/informaticaadmin/nfs_backup/monitoring"
 }
 
-// GetYarnURL returns the appropriate Yarn URL based on mode
-func (c *Config) GetYarnURL() string {
+// GetYarnURLs returns the Yarn ResourceManager URLs for the current mode. Several
+// comma-separated URLs may be configured for an HA pair.
+func (c *Config) GetYarnURLs() []string {
 	if c.Mode == "test" {
-		return c.Services.YarnRMURLTest
+		return SplitList(c.Services.YarnRMURLTest)
 	}
-	return c.Services.YarnRMURL
+	return SplitList(c.Services.YarnRMURL)
 }
 
 // IsProdMode returns true if running in production mode
@@ -106,7 +301,7 @@ func (c *Config) IsTestMode() bool {
 func LoadFromEnv() *Config {
 	// Parse port with default
 	port := 8080
-	if portStr := os.Getenv("PORT"); portStr != "" {
+	if portStr := lookupEnv("PORT"); portStr != "" {
 		if p, err := strconv.Atoi(portStr); err == nil {
 			port = p
 		}
@@ -114,7 +309,7 @@ func LoadFromEnv() *Config {
 
 	// Parse Informatica DB port
 	infDBPort := 1433
-	if portStr := os.Getenv("INFORMATICA_DB_PORT"); portStr != "" {
+	if portStr := lookupEnv("INFORMATICA_DB_PORT"); portStr != "" {
 		if p, err := strconv.Atoi(portStr); err == nil {
 			infDBPort = p
 		}
@@ -122,21 +317,227 @@ func LoadFromEnv() *Config {
 
 	// Parse Informatica time offset
 	timeOffset := 3
-	if offsetStr := os.Getenv("INFORMATICA_TIME_OFFSET"); offsetStr != "" {
+	if offsetStr := lookupEnv("INFORMATICA_TIME_OFFSET"); offsetStr != "" {
 		if o, err := strconv.Atoi(offsetStr); err == nil {
 			timeOffset = o
 		}
 	}
 
+	// Parse Informatica connection pool limits
+	infMaxOpenConns := 10
+	if maxOpenStr := lookupEnv("INFORMATICA_DB_MAX_OPEN_CONNS"); maxOpenStr != "" {
+		if m, err := strconv.Atoi(maxOpenStr); err == nil {
+			infMaxOpenConns = m
+		}
+	}
+	infMaxIdleConns := 5
+	if maxIdleStr := lookupEnv("INFORMATICA_DB_MAX_IDLE_CONNS"); maxIdleStr != "" {
+		if m, err := strconv.Atoi(maxIdleStr); err == nil {
+			infMaxIdleConns = m
+		}
+	}
+	infConnMaxLifetime := 300
+	if lifetimeStr := lookupEnv("INFORMATICA_DB_CONN_MAX_LIFETIME"); lifetimeStr != "" {
+		if l, err := strconv.Atoi(lifetimeStr); err == nil {
+			infConnMaxLifetime = l
+		}
+	}
+
+	// Parse Yarn retry policy
+	yarnRetryAttempts := 3
+	if attemptsStr := lookupEnv("YARN_RETRY_ATTEMPTS"); attemptsStr != "" {
+		if a, err := strconv.Atoi(attemptsStr); err == nil {
+			yarnRetryAttempts = a
+		}
+	}
+	yarnRetryDelay := 500
+	if delayStr := lookupEnv("YARN_RETRY_DELAY_MS"); delayStr != "" {
+		if d, err := strconv.Atoi(delayStr); err == nil {
+			yarnRetryDelay = d
+		}
+	}
+	yarnTimeout := 30
+	if timeoutStr := lookupEnv("YARN_TIMEOUT"); timeoutStr != "" {
+		if t, err := strconv.Atoi(timeoutStr); err == nil {
+			yarnTimeout = t
+		}
+	}
+
+	// Parse NFS scan concurrency (0 lets the scanner pick one worker per CPU)
+	scanWorkers := 0
+	if workersStr := lookupEnv("NFS_SCAN_WORKERS"); workersStr != "" {
+		if w, err := strconv.Atoi(workersStr); err == nil {
+			scanWorkers = w
+		}
+	}
+
+	// Parse the NFS open file limit (0 lets the scanner use its default)
+	maxOpenFiles := 0
+	if filesStr := lookupEnv("NFS_MAX_OPEN_FILES"); filesStr != "" {
+		if f, err := strconv.Atoi(filesStr); err == nil {
+			maxOpenFiles = f
+		}
+	}
+
+	// Parse NFS scan cache lifetimes
+	cacheTTL := 30
+	if ttlStr := lookupEnv("NFS_CACHE_TTL"); ttlStr != "" {
+		if t, err := strconv.Atoi(ttlStr); err == nil {
+			cacheTTL = t
+		}
+	}
+	pastCacheTTL := 600
+	if ttlStr := lookupEnv("NFS_PAST_CACHE_TTL"); ttlStr != "" {
+		if t, err := strconv.Atoi(ttlStr); err == nil {
+			pastCacheTTL = t
+		}
+	}
+
+	// Parse background NFS scan interval (0 scans on demand only)
+	nfsScanInterval := 0
+	if intervalStr := lookupEnv("NFS_SCAN_INTERVAL"); intervalStr != "" {
+		if i, err := strconv.Atoi(intervalStr); err == nil {
+			nfsScanInterval = i
+		}
+	}
+
+	// Parse dashboard stream interval
+	streamInterval := 10
+	if intervalStr := lookupEnv("STREAM_INTERVAL"); intervalStr != "" {
+		if i, err := strconv.Atoi(intervalStr); err == nil {
+			streamInterval = i
+		}
+	}
+
+	// Parse Yarn WebSocket push interval
+	yarnPushInterval := 5
+	if intervalStr := lookupEnv("YARN_PUSH_INTERVAL"); intervalStr != "" {
+		if i, err := strconv.Atoi(intervalStr); err == nil {
+			yarnPushInterval = i
+		}
+	}
+
+	// Parse static asset cache lifetime
+	staticMaxAge := 3600
+	if maxAgeStr := lookupEnv("STATIC_MAX_AGE"); maxAgeStr != "" {
+		if m, err := strconv.Atoi(maxAgeStr); err == nil {
+			staticMaxAge = m
+		}
+	}
+
+	// Parse per-request timeout
+	requestTimeout := 60
+	if timeoutStr := lookupEnv("REQUEST_TIMEOUT"); timeoutStr != "" {
+		if t, err := strconv.Atoi(timeoutStr); err == nil {
+			requestTimeout = t
+		}
+	}
+
+	// Parse browser auto-refresh interval
+	refreshInterval := DefaultRefreshInterval
+	if intervalStr := lookupEnv("REFRESH_INTERVAL"); intervalStr != "" {
+		if i, err := strconv.Atoi(intervalStr); err == nil {
+			refreshInterval = i
+		}
+	}
+
+	// Parse dependency wait timeout
+	dependencyTimeout := 300
+	if timeoutStr := lookupEnv("DEPENDENCY_TIMEOUT"); timeoutStr != "" {
+		if t, err := strconv.Atoi(timeoutStr); err == nil {
+			dependencyTimeout = t
+		}
+	}
+
+	// Parse kill rate limit
+	killRate := 10
+	if rateStr := lookupEnv("KILL_RATE_PER_MINUTE"); rateStr != "" {
+		if r, err := strconv.Atoi(rateStr); err == nil {
+			killRate = r
+		}
+	}
+	killBurst := 5
+	if burstStr := lookupEnv("KILL_BURST"); burstStr != "" {
+		if b, err := strconv.Atoi(burstStr); err == nil {
+			killBurst = b
+		}
+	}
+
+	// Parse history record interval
+	recordInterval := 300
+	if intervalStr := lookupEnv("HISTORY_RECORD_INTERVAL"); intervalStr != "" {
+		if i, err := strconv.Atoi(intervalStr); err == nil {
+			recordInterval = i
+		}
+	}
+
+	// Parse alert poll interval
+	alertInterval := 60
+	if intervalStr := lookupEnv("ALERT_POLL_INTERVAL"); intervalStr != "" {
+		if i, err := strconv.Atoi(intervalStr); err == nil {
+			alertInterval = i
+		}
+	}
+
+	staleThreshold := 240
+	if thresholdStr := lookupEnv("ALERT_STALE_APP_THRESHOLD"); thresholdStr != "" {
+		if t, err := strconv.Atoi(thresholdStr); err == nil {
+			staleThreshold = t
+		}
+	}
+	staleInterval := 300
+	if intervalStr := lookupEnv("ALERT_STALE_APP_INTERVAL"); intervalStr != "" {
+		if i, err := strconv.Atoi(intervalStr); err == nil {
+			staleInterval = i
+		}
+	}
+	smtpPort := 25
+	if portStr := lookupEnv("SMTP_PORT"); portStr != "" {
+		if p, err := strconv.Atoi(portStr); err == nil {
+			smtpPort = p
+		}
+	}
+
 	// Parse boolean values
+	waitForDeps := GetEnvWithDefault("WAIT_FOR_DEPENDENCIES", "false") == "true"
+	tlsEnabled := GetEnvWithDefault("TLS_ENABLED", "false") == "true"
+	yarnKrbEnabled := GetEnvWithDefault("YARN_KRB_ENABLED", "false") == "true"
+	enableGzip := GetEnvWithDefault("ENABLE_GZIP", "false") == "true"
+	followSymlinks := GetEnvWithDefault("NFS_FOLLOW_SYMLINKS", "false") == "true"
+	ignoreCase := GetEnvWithDefault("NFS_ERROR_PATTERNS_IGNORE_CASE", "false") == "true"
+	devMode := GetEnvWithDefault("DEV_MODE", "false") == "true"
 	fileLog := GetEnvWithDefault("LOG_FILE_ENABLED", "true") == "true"
 	jsonLog := GetEnvWithDefault("LOG_JSON_ENABLED", "false") == "true"
 
-	return &Config{
+	config := &Config{
 		Mode: GetEnvWithDefault("ENV", "test"),
 		Server: ServerConfig{
-			Port: port,
-			Host: GetEnvWithDefault("HOST", "0.0.0.0"),
+			Port:                port,
+			Host:                GetEnvWithDefault("HOST", "0.0.0.0"),
+			WaitForDependencies: waitForDeps,
+			DependencyTimeout:   dependencyTimeout,
+			StreamInterval:      streamInterval,
+			YarnPushInterval:    yarnPushInterval,
+			EnableGzip:          enableGzip,
+			MetricsPrefix:       GetEnvWithDefault("METRICS_PREFIX", "salam"),
+			DevMode:             devMode,
+			TemplateDir:         GetEnvWithDefault("TEMPLATE_DIR", "cmd/templates-deploy"),
+			BrandName:           GetEnvWithDefault("BRAND_NAME", DefaultBrandName),
+			KillRatePerMinute:   killRate,
+			KillBurst:           killBurst,
+			StaticMaxAge:        staticMaxAge,
+			RequestTimeout:      requestTimeout,
+			RefreshInterval:     refreshInterval,
+			TLS: TLSConfig{
+				Enabled:  tlsEnabled,
+				CertFile: GetEnvWithDefault("TLS_CERT_FILE", ""),
+				KeyFile:  GetEnvWithDefault("TLS_KEY_FILE", ""),
+			},
+			AuthUser:         GetEnvWithDefault("AUTH_USER", ""),
+			AuthPassword:     GetEnvWithDefault("AUTH_PASSWORD", ""),
+			AuthPasswordHash: GetEnvWithDefault("AUTH_PASSWORD_HASH", ""),
+
+			CORSAllowedOrigins: SplitList(lookupEnv("CORS_ALLOWED_ORIGINS")),
 		},
 		Paths: PathsConfig{
 			NFSRoot:     GetEnvWithDefault("NFS_ROOT", ""),
@@ -148,24 +549,86 @@ func LoadFromEnv() *Config {
 			YarnRMURL:     GetEnvWithDefault("YARN_RM_URL", "http://rm-host:8088"),
 			YarnRMURLTest: GetEnvWithDefault("YARN_RM_URL_TEST", "./mock/yarn/apps.json"),
 			InformaticaDB: InformaticaConfig{
+				DBType:     GetEnvWithDefault("INFORMATICA_DB_TYPE", "sqlserver"),
 				Host:       GetEnvWithDefault("INFORMATICA_DB_HOST", "localhost"),
 				Port:       infDBPort,
 				Database:   GetEnvWithDefault("INFORMATICA_DB_NAME", "INFORMATICA"),
 				Username:   GetEnvWithDefault("INFORMATICA_DB_USER", "repo_read"),
 				Password:   GetEnvWithDefault("INFORMATICA_DB_PASS", "password"),
 				TimeOffset: timeOffset,
+				Timezone:   GetEnvWithDefault("INFORMATICA_TIMEZONE", ""),
+
+				MaxOpenConns:    infMaxOpenConns,
+				MaxIdleConns:    infMaxIdleConns,
+				ConnMaxLifetime: infConnMaxLifetime,
+			},
+			YarnRetryAttempts: yarnRetryAttempts,
+			YarnRetryDelayMs:  yarnRetryDelay,
+			YarnTimeout:       yarnTimeout,
+			YarnKerberos: KerberosConfig{
+				Enabled:   yarnKrbEnabled,
+				Keytab:    GetEnvWithDefault("YARN_KRB_KEYTAB", ""),
+				Principal: GetEnvWithDefault("YARN_KRB_PRINCIPAL", ""),
+				Realm:     GetEnvWithDefault("YARN_KRB_REALM", ""),
+				SPN:       GetEnvWithDefault("YARN_KRB_SPN", ""),
+				Krb5Conf:  GetEnvWithDefault("YARN_KRB_CONF", ""),
 			},
 		},
+		NFS: NFSConfig{
+			ErrorPatterns: SplitList(lookupEnv("NFS_ERROR_PATTERNS")),
+			ScanWorkers:   scanWorkers,
+			MaxOpenFiles:  maxOpenFiles,
+
+			FollowSymlinks: followSymlinks,
+			CacheTTL:       cacheTTL,
+			PastCacheTTL:   pastCacheTTL,
+			ScanInterval:   nfsScanInterval,
+			LogFiles:       SplitList(lookupEnv("NFS_LOG_FILES")),
+
+			TimestampLayout: GetEnvWithDefault("NFS_TIMESTAMP_LAYOUT", ""),
+			CompletionLog:   GetEnvWithDefault("NFS_COMPLETION_LOG", ""),
+			ErrorLog:        GetEnvWithDefault("NFS_ERROR_LOG", ""),
+
+			ErrorPatternsIgnoreCase: ignoreCase,
+		},
 		Logging: LoggingConfig{
 			Level:    GetEnvWithDefault("LOG_LEVEL", "info"),
 			FilePath: GetEnvWithDefault("LOG_FILE_PATH", "./logs"),
 			FileLog:  fileLog,
 			JSONLog:  jsonLog,
+
+			AuditFile: GetEnvWithDefault("AUDIT_LOG_PATH", "./logs/audit.log"),
 		},
 		Database: DatabaseConfig{
-			SQLitePath: GetEnvWithDefault("SQLITE_PATH", "data/history.db"),
+			SQLitePath:     GetEnvWithDefault("SQLITE_PATH", "data/history.db"),
+			RecordInterval: recordInterval,
+		},
+		Alerts: AlertsConfig{
+			WebhookURL:   GetEnvWithDefault("ALERT_WEBHOOK_URL", ""),
+			PollInterval: alertInterval,
+			Workflows:    SplitList(lookupEnv("ALERT_WORKFLOWS")),
+
+			StaleAppThreshold: staleThreshold,
+			StaleAppInterval:  staleInterval,
+			SMTP: SMTPConfig{
+				Host:     GetEnvWithDefault("SMTP_HOST", ""),
+				Port:     smtpPort,
+				Username: GetEnvWithDefault("SMTP_USER", ""),
+				Password: GetEnvWithDefault("SMTP_PASSWORD", ""),
+				From:     GetEnvWithDefault("SMTP_FROM", ""),
+				To:       SplitList(lookupEnv("SMTP_TO")),
+			},
+		},
+		BusinessDay: BusinessDayConfig{
+			Start:    GetEnvWithDefault("BUSINESS_DAY_START", ""),
+			Timezone: GetEnvWithDefault("BUSINESS_DAY_TIMEZONE", ""),
+		},
+		SLA: SLAConfig{
+			Workflows: parseWorkflowSLAs(lookupEnv("SLA_WORKFLOWS")),
 		},
 	}
+	config.envErrors = takeEnvFileErrors()
+	return config
 }
 
 // LoadConfig loads configuration from file with environment variable overrides
@@ -186,8 +649,19 @@ func LoadConfig(configPath string) (*Config, error) {
 	config := &Config{
 		Mode: GetEnvWithDefault("ENV", "test"),
 		Server: ServerConfig{
-			Port: 8080,
-			Host: "0.0.0.0",
+			Port:              8080,
+			Host:              "0.0.0.0",
+			DependencyTimeout: 300,
+			StreamInterval:    10,
+			YarnPushInterval:  5,
+			MetricsPrefix:     "salam",
+			TemplateDir:       "cmd/templates-deploy",
+			BrandName:         DefaultBrandName,
+			KillRatePerMinute: 10,
+			KillBurst:         5,
+			StaticMaxAge:      3600,
+			RequestTimeout:    60,
+			RefreshInterval:   DefaultRefreshInterval,
 		},
 		Paths: PathsConfig{
 			NFSRoot:     "./nfs_backup/monitoring",
@@ -199,22 +673,46 @@ func LoadConfig(configPath string) (*Config, error) {
 			YarnRMURL:     "http://rm-host:8088",
 			YarnRMURLTest: "./mock/yarn/apps.json",
 			InformaticaDB: InformaticaConfig{
+				DBType:     "sqlserver",
 				Host:       "172.16.1.100",
 				Port:       1433,
 				Database:   "INFORMATICA_PROD",
 				Username:   "repo_read",
 				Password:   "password",
 				TimeOffset: 3,
+
+				MaxOpenConns:    10,
+				MaxIdleConns:    5,
+				ConnMaxLifetime: 300,
 			},
+			YarnRetryAttempts: 3,
+			YarnRetryDelayMs:  500,
+			YarnTimeout:       30,
+		},
+		NFS: NFSConfig{
+			CacheTTL:     30,
+			PastCacheTTL: 600,
 		},
 		Logging: LoggingConfig{
 			Level:    "info",
 			FilePath: "./logs",
 			FileLog:  true,
 			JSONLog:  false,
+
+			AuditFile: "./logs/audit.log",
 		},
 		Database: DatabaseConfig{
-			SQLitePath: "data/history.db",
+			SQLitePath:     "data/history.db",
+			RecordInterval: 300,
+		},
+		Alerts: AlertsConfig{
+			PollInterval: 60,
+
+			StaleAppThreshold: 240,
+			StaleAppInterval:  300,
+			SMTP: SMTPConfig{
+				Port: 25,
+			},
 		},
 	}
 
@@ -254,24 +752,25 @@ func LoadConfig(configPath string) (*Config, error) {
 	}
 
 	if !configLoaded {
-		fmt.Printf("Warning: No config file found, using defaults\n")
+		fmt.Fprintf(os.Stderr, "Warning: No config file found, using defaults\n")
 	}
 
 	// Apply environment variable overrides
 	applyEnvOverrides(config)
+	config.envErrors = takeEnvFileErrors()
 
 	// Log final configuration (without sensitive data)
-	fmt.Printf("Final configuration:\n")
-	fmt.Printf("  Mode: %s\n", config.Mode)
-	fmt.Printf("  Yarn RM URL: %s\n", config.Services.YarnRMURL)
-	fmt.Printf("  NFS Root: %s\n", config.GetNFSRoot())
+	fmt.Fprintf(os.Stderr, "Final configuration:\n")
+	fmt.Fprintf(os.Stderr, "  Mode: %s\n", config.Mode)
+	fmt.Fprintf(os.Stderr, "  Yarn RM URL: %s\n", config.Services.YarnRMURL)
+	fmt.Fprintf(os.Stderr, "  NFS Root: %s\n", config.GetNFSRoot())
 
 	return config, nil
 }
 
 // loadConfigFile loads configuration from a specific file
 func loadConfigFile(config *Config, filename string) error {
-	fmt.Printf("Loading config from: %s\n", filename)
+	fmt.Fprintf(os.Stderr, "Loading config from: %s\n", filename)
 
 	data, err := os.ReadFile(filename)
 	if err != nil {
@@ -282,92 +781,388 @@ func loadConfigFile(config *Config, filename string) error {
 		return fmt.Errorf("failed to parse config file: %w", err)
 	}
 
-	fmt.Printf("Successfully loaded config from: %s\n", filename)
-	fmt.Printf("  Loaded Yarn URL: %s\n", config.Services.YarnRMURL)
+	fmt.Fprintf(os.Stderr, "Successfully loaded config from: %s\n", filename)
+	fmt.Fprintf(os.Stderr, "  Loaded Yarn URL: %s\n", config.Services.YarnRMURL)
 	return nil
 }
 
 // applyEnvOverrides applies environment variable overrides to configuration
 func applyEnvOverrides(config *Config) {
 	// Mode override
-	if env := os.Getenv("ENV"); env != "" {
+	if env := lookupEnv("ENV"); env != "" {
 		config.Mode = env
 	}
 
 	// Server overrides
-	if port := os.Getenv("SERVER_PORT"); port != "" {
+	if port := lookupEnv("PORT"); port != "" {
 		if p, err := strconv.Atoi(port); err == nil {
 			config.Server.Port = p
 		}
 	}
 
-	if host := os.Getenv("SERVER_HOST"); host != "" {
+	if host := lookupEnv("HOST"); host != "" {
 		config.Server.Host = host
 	}
 
+	if wait := lookupEnv("WAIT_FOR_DEPENDENCIES"); wait != "" {
+		config.Server.WaitForDependencies = wait == "true"
+	}
+
+	if timeout := lookupEnv("DEPENDENCY_TIMEOUT"); timeout != "" {
+		if t, err := strconv.Atoi(timeout); err == nil {
+			config.Server.DependencyTimeout = t
+		}
+	}
+
+	if interval := lookupEnv("STREAM_INTERVAL"); interval != "" {
+		if i, err := strconv.Atoi(interval); err == nil {
+			config.Server.StreamInterval = i
+		}
+	}
+
+	if interval := lookupEnv("YARN_PUSH_INTERVAL"); interval != "" {
+		if i, err := strconv.Atoi(interval); err == nil {
+			config.Server.YarnPushInterval = i
+		}
+	}
+
+	if maxAge := lookupEnv("STATIC_MAX_AGE"); maxAge != "" {
+		if m, err := strconv.Atoi(maxAge); err == nil {
+			config.Server.StaticMaxAge = m
+		}
+	}
+
+	if timeout := lookupEnv("REQUEST_TIMEOUT"); timeout != "" {
+		if t, err := strconv.Atoi(timeout); err == nil {
+			config.Server.RequestTimeout = t
+		}
+	}
+
+	if interval := lookupEnv("REFRESH_INTERVAL"); interval != "" {
+		if i, err := strconv.Atoi(interval); err == nil {
+			config.Server.RefreshInterval = i
+		}
+	}
+
+	if gzipEnabled := lookupEnv("ENABLE_GZIP"); gzipEnabled != "" {
+		config.Server.EnableGzip = gzipEnabled == "true"
+	}
+
+	if prefix := lookupEnv("METRICS_PREFIX"); prefix != "" {
+		config.Server.MetricsPrefix = prefix
+	}
+
+	if devMode := lookupEnv("DEV_MODE"); devMode != "" {
+		config.Server.DevMode = devMode == "true"
+	}
+
+	if templateDir := lookupEnv("TEMPLATE_DIR"); templateDir != "" {
+		config.Server.TemplateDir = templateDir
+	}
+
+	if brand := lookupEnv("BRAND_NAME"); brand != "" {
+		config.Server.BrandName = brand
+	}
+
+	if rate := lookupEnv("KILL_RATE_PER_MINUTE"); rate != "" {
+		if r, err := strconv.Atoi(rate); err == nil {
+			config.Server.KillRatePerMinute = r
+		}
+	}
+
+	if burst := lookupEnv("KILL_BURST"); burst != "" {
+		if b, err := strconv.Atoi(burst); err == nil {
+			config.Server.KillBurst = b
+		}
+	}
+
+	if tlsEnabled := lookupEnv("TLS_ENABLED"); tlsEnabled != "" {
+		config.Server.TLS.Enabled = tlsEnabled == "true"
+	}
+
+	if certFile := lookupEnv("TLS_CERT_FILE"); certFile != "" {
+		config.Server.TLS.CertFile = certFile
+	}
+
+	if keyFile := lookupEnv("TLS_KEY_FILE"); keyFile != "" {
+		config.Server.TLS.KeyFile = keyFile
+	}
+
+	if user := lookupEnv("AUTH_USER"); user != "" {
+		config.Server.AuthUser = user
+	}
+
+	if password := lookupEnv("AUTH_PASSWORD"); password != "" {
+		config.Server.AuthPassword = password
+	}
+
+	if hash := lookupEnv("AUTH_PASSWORD_HASH"); hash != "" {
+		config.Server.AuthPasswordHash = hash
+	}
+
+	if origins := lookupEnv("CORS_ALLOWED_ORIGINS"); origins != "" {
+		config.Server.CORSAllowedOrigins = SplitList(origins)
+	}
+
 	// Path overrides
-	if nfsTest := os.Getenv("NFS_ROOT_TEST"); nfsTest != "" {
+	if nfsTest := lookupEnv("NFS_ROOT_TEST"); nfsTest != "" {
 		config.Paths.NFSRootTest = nfsTest
 	}
 
-	if nfsProd := os.Getenv("NFS_ROOT_PROD"); nfsProd != "" {
+	if nfsProd := lookupEnv("NFS_ROOT_PROD"); nfsProd != "" {
 		config.Paths.NFSRootProd = nfsProd
 	}
 
-	if logDir := os.Getenv("LOG_DIR"); logDir != "" {
+	if logDir := lookupEnv("LOG_DIR"); logDir != "" {
 		config.Paths.LogDir = logDir
 	}
 
 	// Service overrides
-	if yarnURL := os.Getenv("YARN_RM_URL"); yarnURL != "" {
+	if yarnURL := lookupEnv("YARN_RM_URL"); yarnURL != "" {
 		config.Services.YarnRMURL = yarnURL
 	}
 
-	if yarnTestURL := os.Getenv("YARN_RM_URL_TEST"); yarnTestURL != "" {
+	if yarnTestURL := lookupEnv("YARN_RM_URL_TEST"); yarnTestURL != "" {
 		config.Services.YarnRMURLTest = yarnTestURL
 	}
 
+	if attempts := lookupEnv("YARN_RETRY_ATTEMPTS"); attempts != "" {
+		if a, err := strconv.Atoi(attempts); err == nil {
+			config.Services.YarnRetryAttempts = a
+		}
+	}
+
+	if delay := lookupEnv("YARN_RETRY_DELAY_MS"); delay != "" {
+		if d, err := strconv.Atoi(delay); err == nil {
+			config.Services.YarnRetryDelayMs = d
+		}
+	}
+
+	if timeout := lookupEnv("YARN_TIMEOUT"); timeout != "" {
+		if t, err := strconv.Atoi(timeout); err == nil {
+			config.Services.YarnTimeout = t
+		}
+	}
+
+	// Yarn Kerberos overrides
+	if krbEnabled := lookupEnv("YARN_KRB_ENABLED"); krbEnabled != "" {
+		config.Services.YarnKerberos.Enabled = krbEnabled == "true"
+	}
+	if keytab := lookupEnv("YARN_KRB_KEYTAB"); keytab != "" {
+		config.Services.YarnKerberos.Keytab = keytab
+	}
+	if principal := lookupEnv("YARN_KRB_PRINCIPAL"); principal != "" {
+		config.Services.YarnKerberos.Principal = principal
+	}
+	if realm := lookupEnv("YARN_KRB_REALM"); realm != "" {
+		config.Services.YarnKerberos.Realm = realm
+	}
+	if spn := lookupEnv("YARN_KRB_SPN"); spn != "" {
+		config.Services.YarnKerberos.SPN = spn
+	}
+	if krb5Conf := lookupEnv("YARN_KRB_CONF"); krb5Conf != "" {
+		config.Services.YarnKerberos.Krb5Conf = krb5Conf
+	}
+
 	// Informatica DB overrides
-	if dbHost := os.Getenv("INF_DB_HOST"); dbHost != "" {
+	if dbType := lookupEnv("INFORMATICA_DB_TYPE"); dbType != "" {
+		config.Services.InformaticaDB.DBType = dbType
+	}
+
+	if dbHost := lookupEnv("INFORMATICA_DB_HOST"); dbHost != "" {
 		config.Services.InformaticaDB.Host = dbHost
 	}
 
-	if dbPort := os.Getenv("INF_DB_PORT"); dbPort != "" {
+	if dbPort := lookupEnv("INFORMATICA_DB_PORT"); dbPort != "" {
 		if p, err := strconv.Atoi(dbPort); err == nil {
 			config.Services.InformaticaDB.Port = p
 		}
 	}
 
-	if dbService := os.Getenv("INFORMATICA_DB_NAME"); dbService != "" {
+	if dbService := lookupEnv("INFORMATICA_DB_NAME"); dbService != "" {
 		config.Services.InformaticaDB.Database = dbService
 	}
 
-	if dbUser := os.Getenv("INF_DB_USER"); dbUser != "" {
+	if dbUser := lookupEnv("INFORMATICA_DB_USER"); dbUser != "" {
 		config.Services.InformaticaDB.Username = dbUser
 	}
 
-	if dbPass := os.Getenv("INF_DB_PASSWORD"); dbPass != "" {
+	if dbPass := lookupEnv("INFORMATICA_DB_PASS"); dbPass != "" {
 		config.Services.InformaticaDB.Password = dbPass
 	}
 
-	if offset := os.Getenv("TIME_OFFSET_HOURS"); offset != "" {
+	if timezone := lookupEnv("INFORMATICA_TIMEZONE"); timezone != "" {
+		config.Services.InformaticaDB.Timezone = timezone
+	}
+
+	if offset := lookupEnv("INFORMATICA_TIME_OFFSET"); offset != "" {
 		if o, err := strconv.Atoi(offset); err == nil {
 			config.Services.InformaticaDB.TimeOffset = o
 		}
 	}
 
+	if maxOpen := lookupEnv("INFORMATICA_DB_MAX_OPEN_CONNS"); maxOpen != "" {
+		if m, err := strconv.Atoi(maxOpen); err == nil {
+			config.Services.InformaticaDB.MaxOpenConns = m
+		}
+	}
+
+	if maxIdle := lookupEnv("INFORMATICA_DB_MAX_IDLE_CONNS"); maxIdle != "" {
+		if m, err := strconv.Atoi(maxIdle); err == nil {
+			config.Services.InformaticaDB.MaxIdleConns = m
+		}
+	}
+
+	if lifetime := lookupEnv("INFORMATICA_DB_CONN_MAX_LIFETIME"); lifetime != "" {
+		if l, err := strconv.Atoi(lifetime); err == nil {
+			config.Services.InformaticaDB.ConnMaxLifetime = l
+		}
+	}
+
+	// NFS overrides
+	if patterns := lookupEnv("NFS_ERROR_PATTERNS"); patterns != "" {
+		config.NFS.ErrorPatterns = SplitList(patterns)
+	}
+
+	if files := lookupEnv("NFS_LOG_FILES"); files != "" {
+		config.NFS.LogFiles = SplitList(files)
+	}
+
+	if completion := lookupEnv("NFS_COMPLETION_LOG"); completion != "" {
+		config.NFS.CompletionLog = completion
+	}
+
+	if errorLog := lookupEnv("NFS_ERROR_LOG"); errorLog != "" {
+		config.NFS.ErrorLog = errorLog
+	}
+
+	if layout := lookupEnv("NFS_TIMESTAMP_LAYOUT"); layout != "" {
+		config.NFS.TimestampLayout = layout
+	}
+
+	if workers := lookupEnv("NFS_SCAN_WORKERS"); workers != "" {
+		if w, err := strconv.Atoi(workers); err == nil {
+			config.NFS.ScanWorkers = w
+		}
+	}
+
+	if follow := lookupEnv("NFS_FOLLOW_SYMLINKS"); follow != "" {
+		config.NFS.FollowSymlinks = follow == "true"
+	}
+
+	if ignoreCase := lookupEnv("NFS_ERROR_PATTERNS_IGNORE_CASE"); ignoreCase != "" {
+		config.NFS.ErrorPatternsIgnoreCase = ignoreCase == "true"
+	}
+
+	if files := lookupEnv("NFS_MAX_OPEN_FILES"); files != "" {
+		if f, err := strconv.Atoi(files); err == nil {
+			config.NFS.MaxOpenFiles = f
+		}
+	}
+
+	if ttl := lookupEnv("NFS_CACHE_TTL"); ttl != "" {
+		if t, err := strconv.Atoi(ttl); err == nil {
+			config.NFS.CacheTTL = t
+		}
+	}
+
+	if ttl := lookupEnv("NFS_PAST_CACHE_TTL"); ttl != "" {
+		if t, err := strconv.Atoi(ttl); err == nil {
+			config.NFS.PastCacheTTL = t
+		}
+	}
+
+	if interval := lookupEnv("NFS_SCAN_INTERVAL"); interval != "" {
+		if i, err := strconv.Atoi(interval); err == nil {
+			config.NFS.ScanInterval = i
+		}
+	}
+
 	// Logging overrides
-	if level := os.Getenv("LOG_LEVEL"); level != "" {
+	if level := lookupEnv("LOG_LEVEL"); level != "" {
 		config.Logging.Level = level
 	}
 
-	if fileLog := os.Getenv("LOG_FILE"); fileLog != "" {
+	if fileLog := lookupEnv("LOG_FILE_ENABLED"); fileLog != "" {
 		config.Logging.FileLog = fileLog == "true"
 	}
 
-	if jsonLog := os.Getenv("LOG_JSON"); jsonLog != "" {
+	if jsonLog := lookupEnv("LOG_JSON_ENABLED"); jsonLog != "" {
 		config.Logging.JSONLog = jsonLog == "true"
 	}
+
+	if auditFile := lookupEnv("AUDIT_LOG_PATH"); auditFile != "" {
+		config.Logging.AuditFile = auditFile
+	}
+
+	if interval := lookupEnv("HISTORY_RECORD_INTERVAL"); interval != "" {
+		if i, err := strconv.Atoi(interval); err == nil {
+			config.Database.RecordInterval = i
+		}
+	}
+
+	// Alert overrides
+	if webhook := lookupEnv("ALERT_WEBHOOK_URL"); webhook != "" {
+		config.Alerts.WebhookURL = webhook
+	}
+
+	if interval := lookupEnv("ALERT_POLL_INTERVAL"); interval != "" {
+		if i, err := strconv.Atoi(interval); err == nil {
+			config.Alerts.PollInterval = i
+		}
+	}
+
+	if workflows := lookupEnv("ALERT_WORKFLOWS"); workflows != "" {
+		config.Alerts.Workflows = SplitList(workflows)
+	}
+
+	if threshold := lookupEnv("ALERT_STALE_APP_THRESHOLD"); threshold != "" {
+		if t, err := strconv.Atoi(threshold); err == nil {
+			config.Alerts.StaleAppThreshold = t
+		}
+	}
+
+	if interval := lookupEnv("ALERT_STALE_APP_INTERVAL"); interval != "" {
+		if i, err := strconv.Atoi(interval); err == nil {
+			config.Alerts.StaleAppInterval = i
+		}
+	}
+
+	// SMTP overrides
+	if host := lookupEnv("SMTP_HOST"); host != "" {
+		config.Alerts.SMTP.Host = host
+	}
+	if port := lookupEnv("SMTP_PORT"); port != "" {
+		if p, err := strconv.Atoi(port); err == nil {
+			config.Alerts.SMTP.Port = p
+		}
+	}
+	if user := lookupEnv("SMTP_USER"); user != "" {
+		config.Alerts.SMTP.Username = user
+	}
+	if password := lookupEnv("SMTP_PASSWORD"); password != "" {
+		config.Alerts.SMTP.Password = password
+	}
+	if from := lookupEnv("SMTP_FROM"); from != "" {
+		config.Alerts.SMTP.From = from
+	}
+	if to := lookupEnv("SMTP_TO"); to != "" {
+		config.Alerts.SMTP.To = SplitList(to)
+	}
+
+	// Business day overrides
+	if start := lookupEnv("BUSINESS_DAY_START"); start != "" {
+		config.BusinessDay.Start = start
+	}
+
+	if tz := lookupEnv("BUSINESS_DAY_TIMEZONE"); tz != "" {
+		config.BusinessDay.Timezone = tz
+	}
+
+	// SLA overrides
+	if slas := lookupEnv("SLA_WORKFLOWS"); slas != "" {
+		config.SLA.Workflows = parseWorkflowSLAs(slas)
+	}
 }
 
 // fileExists checks if a file exists
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Diff lists the settings that differ between two configurations, one entry per
+// setting in the form "services.yarn_timeout: 30 -> 60", using the YAML key
+// paths. Passwords, hashes and webhook URLs are reported as changed without their values.
+func Diff(old, new *Config) []string {
+	var changes []string
+	diffValues("", reflect.ValueOf(*old), reflect.ValueOf(*new), &changes)
+	return changes
+}
+
+func diffValues(path string, old, new reflect.Value, changes *[]string) {
+	if old.Kind() == reflect.Struct {
+		for i := 0; i < old.NumField(); i++ {
+			field := old.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+			if key == "" {
+				key = strings.ToLower(field.Name)
+			}
+			if path != "" {
+				key = path + "." + key
+			}
+			diffValues(key, old.Field(i), new.Field(i), changes)
+		}
+		return
+	}
+
+	if reflect.DeepEqual(old.Interface(), new.Interface()) {
+		return
+	}
+	if isSecretKey(path) {
+		*changes = append(*changes, path+": (changed)")
+		return
+	}
+	*changes = append(*changes, fmt.Sprintf("%s: %v -> %v", path, old.Interface(), new.Interface()))
+}
@@ -2,11 +2,41 @@ package config
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"strings"
+	"sync"
 )
 
-// LoadEnvFile loads environment variables from a .env file
+// envAliases maps each canonical variable name to the deprecated names that
+// are still accepted for it. Older .env files and deploy packages used these.
+var envAliases = map[string][]string{
+	"PORT":                    {"SERVER_PORT"},
+	"HOST":                    {"SERVER_HOST"},
+	"INFORMATICA_DB_HOST":     {"INF_DB_HOST"},
+	"INFORMATICA_DB_PORT":     {"INF_DB_PORT"},
+	"INFORMATICA_DB_NAME":     {"INF_DB_SERVICE"},
+	"INFORMATICA_DB_USER":     {"INF_DB_USER"},
+	"INFORMATICA_DB_PASS":     {"INF_DB_PASSWORD"},
+	"INFORMATICA_TIME_OFFSET": {"TIME_OFFSET_HOURS"},
+	"LOG_FILE_ENABLED":        {"LOG_FILE"},
+	"LOG_JSON_ENABLED":        {"LOG_JSON"},
+}
+
+// warnedAliases records the deprecated names already reported, so each is
+// only warned about once even though both load paths read the environment
+var warnedAliases sync.Map
+
+// fileEnvKeys records, per .env file, the variables LoadEnvFile set from it, so
+// loading the file again (on a config reload) refreshes them instead of
+// treating them as values from the process environment
+var (
+	fileEnvMu   sync.Mutex
+	fileEnvKeys = map[string]map[string]bool{}
+)
+
+// LoadEnvFile loads environment variables from a .env file. Variables already in
+// the process environment take precedence over the file.
 func LoadEnvFile(filename string) error {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -18,45 +48,166 @@ func LoadEnvFile(filename string) error {
 	}
 	defer file.Close()
 
+	fileEnvMu.Lock()
+	defer fileEnvMu.Unlock()
+	previous := fileEnvKeys[filename]
+	loaded := make(map[string]bool)
+
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		// Skip empty lines and comments
-		if line == "" || strings.HasPrefix(line, "#") {
+		key, value, ok := parseEnvLine(scanner.Text())
+		if !ok {
 			continue
 		}
 
-		// Split on first '=' character
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) != 2 {
-			continue
+		// Set environment variable if not already set, or set by an earlier load of this file
+		if os.Getenv(key) == "" || previous[key] {
+			os.Setenv(key, value)
+			loaded[key] = true
 		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
 
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
+	// Drop variables that were removed from the file since the last load
+	for key := range previous {
+		if !loaded[key] {
+			os.Unsetenv(key)
+		}
+	}
+	fileEnvKeys[filename] = loaded
+	return nil
+}
 
-		// Remove quotes if present
-		if len(value) >= 2 {
-			if (strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"")) ||
-				(strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'")) {
-				value = value[1 : len(value)-1]
+// envFileErrors collects secret files that could not be read while a
+// configuration was loading; see takeEnvFileErrors
+var (
+	envFileMu     sync.Mutex
+	envFileErrors []error
+)
+
+// lookupEnv returns the value of a variable. When <key>_FILE is set the value is
+// read from that file instead, Docker/Kubernetes secrets style, with trailing
+// newlines removed. Otherwise the variable itself is used, falling back to its
+// deprecated aliases.
+func lookupEnv(key string) string {
+	if path := os.Getenv(key + "_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return strings.TrimRight(string(data), "\r\n")
+		}
+		envFileMu.Lock()
+		envFileErrors = append(envFileErrors, fmt.Errorf("%s_FILE: %w", key, err))
+		envFileMu.Unlock()
+	}
+
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	for _, alias := range envAliases[key] {
+		if value := os.Getenv(alias); value != "" {
+			if _, warned := warnedAliases.LoadOrStore(alias, true); !warned {
+				fmt.Fprintf(os.Stderr, "Warning: %s is deprecated, use %s instead\n", alias, key)
 			}
+			return value
 		}
+	}
+	return ""
+}
 
-		// Set environment variable if not already set
-		if os.Getenv(key) == "" {
-			os.Setenv(key, value)
+// parseEnvLine parses one .env line of the form [export ]KEY=VALUE. Blank lines
+// and comments report ok=false. Values may be single-quoted (taken literally) or
+// double-quoted (with \n, \t, \", \\ and \$ escapes); unquoted values end at a
+// '#' that follows whitespace.
+func parseEnvLine(line string) (key, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+	if rest, found := strings.CutPrefix(line, "export"); found && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+		line = strings.TrimSpace(rest)
+	}
+
+	key, value, found := strings.Cut(line, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" {
+		return "", "", false
+	}
+	value = strings.TrimSpace(value)
+
+	switch {
+	case strings.HasPrefix(value, "'"):
+		if end := strings.IndexByte(value[1:], '\''); end >= 0 {
+			return key, value[1 : end+1], true
+		}
+	case strings.HasPrefix(value, "\""):
+		if unquoted, closed := unquoteEnvValue(value[1:]); closed {
+			return key, unquoted, true
+		}
+	default:
+		for i := 1; i < len(value); i++ {
+			if value[i] == '#' && (value[i-1] == ' ' || value[i-1] == '\t') {
+				return key, strings.TrimSpace(value[:i]), true
+			}
 		}
 	}
+	// Unquoted, or an unterminated quote kept as written
+	return key, value, true
+}
+
+// unquoteEnvValue reads a double-quoted value up to its closing quote, resolving
+// escapes; closed is false if the quote is never closed
+func unquoteEnvValue(s string) (value string, closed bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"':
+			return b.String(), true
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$':
+				b.WriteByte(s[i])
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", false
+}
 
-	return scanner.Err()
+// takeEnvFileErrors returns and clears the errors recorded by lookupEnv
+func takeEnvFileErrors() []error {
+	envFileMu.Lock()
+	defer envFileMu.Unlock()
+	errs := envFileErrors
+	envFileErrors = nil
+	return errs
 }
 
 // GetEnvWithDefault gets environment variable with default value
 func GetEnvWithDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := lookupEnv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+// SplitList splits a comma-separated environment value, dropping empty items
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
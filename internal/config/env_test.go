@@ -0,0 +1,180 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// loaders are the two ways a configuration is built from the environment
+var loaders = []struct {
+	name string
+	load func(t *testing.T) *Config
+}{
+	{"LoadFromEnv", func(t *testing.T) *Config { return LoadFromEnv() }},
+	{"LoadConfig", func(t *testing.T) *Config {
+		// A missing YAML file leaves the defaults to applyEnvOverrides
+		cfg, err := LoadConfig(filepath.Join(t.TempDir(), "config.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cfg
+	}},
+}
+
+// aliasedSettings are settings with deprecated aliases, and how to read them back
+var aliasedSettings = []struct {
+	canonical, alias, value string
+	get                     func(*Config) string
+}{
+	{"PORT", "SERVER_PORT", "9191", func(c *Config) string { return strconv.Itoa(c.Server.Port) }},
+	{"INFORMATICA_DB_HOST", "INF_DB_HOST", "repo-db", func(c *Config) string { return c.Services.InformaticaDB.Host }},
+	{"INFORMATICA_DB_PASS", "INF_DB_PASSWORD", "s3cret", func(c *Config) string { return c.Services.InformaticaDB.Password }},
+	{"INFORMATICA_TIME_OFFSET", "TIME_OFFSET_HOURS", "5", func(c *Config) string { return strconv.Itoa(c.Services.InformaticaDB.TimeOffset) }},
+	{"LOG_JSON_ENABLED", "LOG_JSON", "true", func(c *Config) string { return strconv.FormatBool(c.Logging.JSONLog) }},
+}
+
+// writeSecret writes a secret file the way Docker and Kubernetes mount them,
+// with a trailing newline
+func writeSecret(t *testing.T, value string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(value+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestEnvNames(t *testing.T) {
+	variants := []struct {
+		name string
+		set  func(t *testing.T, canonical, alias, value string)
+	}{
+		{"canonical", func(t *testing.T, canonical, alias, value string) {
+			t.Setenv(canonical, value)
+		}},
+		{"deprecated alias", func(t *testing.T, canonical, alias, value string) {
+			t.Setenv(alias, value)
+		}},
+		{"canonical wins over alias", func(t *testing.T, canonical, alias, value string) {
+			t.Setenv(canonical, value)
+			t.Setenv(alias, "ignored")
+		}},
+		{"_FILE", func(t *testing.T, canonical, alias, value string) {
+			t.Setenv(canonical+"_FILE", writeSecret(t, value))
+		}},
+		{"_FILE wins over canonical", func(t *testing.T, canonical, alias, value string) {
+			t.Setenv(canonical+"_FILE", writeSecret(t, value))
+			t.Setenv(canonical, "ignored")
+		}},
+	}
+
+	for _, loader := range loaders {
+		for _, variant := range variants {
+			for _, setting := range aliasedSettings {
+				t.Run(loader.name+"/"+variant.name+"/"+setting.canonical, func(t *testing.T) {
+					variant.set(t, setting.canonical, setting.alias, setting.value)
+					cfg := loader.load(t)
+					if got := setting.get(cfg); got != setting.value {
+						t.Errorf("got %q, want %q", got, setting.value)
+					}
+					if len(cfg.envErrors) != 0 {
+						t.Errorf("unexpected env errors: %v", cfg.envErrors)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestEnvFileUnreadable(t *testing.T) {
+	for _, loader := range loaders {
+		t.Run(loader.name, func(t *testing.T) {
+			t.Setenv("INFORMATICA_DB_PASS_FILE", filepath.Join(t.TempDir(), "missing"))
+			t.Setenv("INFORMATICA_DB_PASS", "fallback")
+
+			cfg := loader.load(t)
+			// The variable itself is still used, but Validate reports the file
+			if got := cfg.Services.InformaticaDB.Password; got != "fallback" {
+				t.Errorf("got password %q, want the variable's value", got)
+			}
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), "INFORMATICA_DB_PASS_FILE") {
+				t.Errorf("Validate() = %v, want an error naming INFORMATICA_DB_PASS_FILE", err)
+			}
+		})
+	}
+}
+
+func TestParseEnvLine(t *testing.T) {
+	tests := []struct {
+		name, line string
+		key, value string
+		ok         bool
+	}{
+		{"plain", "PORT=8080", "PORT", "8080", true},
+		{"spaces around", "  PORT = 8080  ", "PORT", "8080", true},
+		{"empty value", "PORT=", "PORT", "", true},
+		{"blank line", "   ", "", "", false},
+		{"comment line", "# PORT=8080", "", "", false},
+		{"no equals", "PORT", "", "", false},
+		{"empty key", "=8080", "", "", false},
+		{"export prefix", "export PORT=8080", "PORT", "8080", true},
+		{"export with tab", "export\tPORT=8080", "PORT", "8080", true},
+		{"key starting with export", "EXPORT_DIR=/out", "EXPORT_DIR", "/out", true},
+		{"inline comment", "PORT=8080 # dev port", "PORT", "8080", true},
+		{"inline comment after tab", "PORT=8080\t# dev port", "PORT", "8080", true},
+		{"hash without space", "COLOR=#ff0000", "COLOR", "#ff0000", true},
+		{"hash inside value", "TAG=build#42", "TAG", "build#42", true},
+		{"value with equals", "DSN=user=app password=x", "DSN", "user=app password=x", true},
+		{"single quoted", "PASS='a b # c'", "PASS", "a b # c", true},
+		{"single quoted is literal", `PASS='a\nb $HOME'`, "PASS", `a\nb $HOME`, true},
+		{"single quoted then comment", "PASS='secret' # note", "PASS", "secret", true},
+		{"double quoted", `PASS="a b # c"`, "PASS", "a b # c", true},
+		{"double quoted then comment", `PASS="secret" # note`, "PASS", "secret", true},
+		{"double quoted with equals", `DSN="host=db port=1521"`, "DSN", "host=db port=1521", true},
+		{"escapes", `MSG="line1\nline2\tend"`, "MSG", "line1\nline2\tend", true},
+		{"escaped quote and backslash", `PASS="a\"b\\c"`, "PASS", `a"b\c`, true},
+		{"escaped dollar", `PASS="\$HOME"`, "PASS", "$HOME", true},
+		{"unknown escape kept", `PATH_RE="\d+"`, "PATH_RE", `\d+`, true},
+		{"unterminated double quote", `PASS="secret`, "PASS", `"secret`, true},
+		{"unterminated single quote", "PASS='secret", "PASS", "'secret", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, value, ok := parseEnvLine(tt.line)
+			if key != tt.key || value != tt.value || ok != tt.ok {
+				t.Errorf("parseEnvLine(%q) = %q, %q, %v; want %q, %q, %v",
+					tt.line, key, value, ok, tt.key, tt.value, tt.ok)
+			}
+		})
+	}
+}
+
+func TestUnquoteEnvValue(t *testing.T) {
+	tests := []struct {
+		in, value string
+		closed    bool
+	}{
+		{`abc"`, "abc", true},
+		{`abc" trailing`, "abc", true},
+		{`"`, "", true},
+		{`a\"b"`, `a"b`, true},
+		{`a\\"`, `a\`, true},
+		{`a\n\t\$"`, "a\n\t$", true},
+		{`\x"`, `\x`, true},
+		{`abc`, "", false},
+		{`abc\"`, "", false},
+		{`abc\`, "", false},
+	}
+
+	for _, tt := range tests {
+		value, closed := unquoteEnvValue(tt.in)
+		if value != tt.value || closed != tt.closed {
+			t.Errorf("unquoteEnvValue(%q) = %q, %v; want %q, %v", tt.in, value, closed, tt.value, tt.closed)
+		}
+	}
+}
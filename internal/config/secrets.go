@@ -0,0 +1,42 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// maskedValue replaces secrets in MaskSecrets output
+const maskedValue = "***"
+
+// MaskSecrets returns a copy of cfg with passwords, password hashes and webhook
+// URLs replaced by "***" so it can be printed or logged. Empty secrets stay empty, which shows
+// that none is configured.
+func MaskSecrets(cfg *Config) *Config {
+	masked := *cfg
+	maskStrings(reflect.ValueOf(&masked).Elem())
+	return &masked
+}
+
+func maskStrings(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		switch value := v.Field(i); value.Kind() {
+		case reflect.Struct:
+			maskStrings(value)
+		case reflect.String:
+			if value.String() != "" && isSecretKey(field.Tag.Get("yaml")) {
+				value.SetString(maskedValue)
+			}
+		}
+	}
+}
+
+// isSecretKey reports whether a setting, given by its YAML key or key path,
+// holds a credential that must not be printed. Webhook URLs embed their token.
+func isSecretKey(path string) bool {
+	key := path[strings.LastIndex(path, ".")+1:]
+	return strings.Contains(key, "password") || strings.Contains(key, "webhook_url")
+}
@@ -0,0 +1,215 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"salam-monitoring/internal/businessday"
+)
+
+// Validate checks the configuration for missing or malformed settings and
+// returns every problem found, joined into one error. Only settings used in the
+// current mode are checked; reachability of NFS, Yarn and the database is left
+// to the health checks.
+func (c *Config) Validate() error {
+	errs := append([]error(nil), c.envErrors...)
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.Mode != "test" && c.Mode != "prod" {
+		add("mode must be test or prod, got %q", c.Mode)
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		add("server port %d is out of range", c.Server.Port)
+	}
+	if c.Server.TLS.Enabled && (c.Server.TLS.CertFile == "" || c.Server.TLS.KeyFile == "") {
+		add("tls is enabled but cert_file or key_file is empty")
+	}
+	if c.Server.KillRatePerMinute < 0 || c.Server.KillBurst < 0 {
+		add("kill_rate_per_minute and kill_burst must not be negative")
+	}
+	if c.Server.YarnPushInterval <= 0 {
+		add("yarn_push_interval must be positive, got %d", c.Server.YarnPushInterval)
+	}
+	if c.Server.StaticMaxAge < 0 {
+		add("static_max_age must not be negative, got %d", c.Server.StaticMaxAge)
+	}
+	if c.Server.RequestTimeout < 0 {
+		add("request_timeout must not be negative, got %d", c.Server.RequestTimeout)
+	}
+	if c.Server.RefreshInterval < 0 || (c.Server.RefreshInterval > 0 && c.Server.RefreshInterval < MinRefreshInterval) {
+		add("refresh_interval must be 0 (disabled) or at least %d seconds, got %d", MinRefreshInterval, c.Server.RefreshInterval)
+	}
+	if c.Server.AuthUser != "" {
+		// An unset or unreadable secret must not leave the server open
+		hasPassword, hasHash := c.Server.AuthPassword != "", c.Server.AuthPasswordHash != ""
+		if hasPassword == hasHash {
+			add("auth_user is set, so exactly one of auth_password or auth_password_hash is required")
+		}
+	}
+	if strings.TrimSpace(c.Server.BrandName) == "" {
+		add("brand_name must not be empty")
+	}
+	for _, origin := range c.Server.CORSAllowedOrigins {
+		if origin == "*" {
+			// Any site could otherwise read authenticated responses, kill tokens included
+			if c.Server.AuthUser != "" {
+				add("cors origin * cannot be used with basic authentication; list the allowed origins")
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.Trim(u.Path, "/") != "" {
+			add("invalid cors origin %q: expected scheme://host[:port] or *", origin)
+		}
+	}
+	if c.Server.DevMode {
+		if info, err := os.Stat(c.Server.TemplateDir); err != nil || !info.IsDir() {
+			add("dev mode template_dir %q is not a directory", c.Server.TemplateDir)
+		}
+	}
+
+	if c.GetNFSRoot() == "" {
+		add("nfs root is empty")
+	}
+	if c.NFS.MaxOpenFiles < 0 {
+		add("nfs max_open_files must not be negative, got %d", c.NFS.MaxOpenFiles)
+	}
+	if c.NFS.ScanInterval < 0 {
+		add("nfs scan_interval must not be negative, got %d", c.NFS.ScanInterval)
+	}
+	if layout := c.NFS.TimestampLayout; layout != "" {
+		// A usable layout parses its own output and contains at least one time element
+		sample := time.Date(2024, 11, 21, 10, 30, 0, 0, time.UTC).Format(layout)
+		if _, err := time.Parse(layout, sample); err != nil || sample == layout {
+			add("invalid nfs timestamp_layout %q: expected a Go time layout such as 2006-01-02 15:04:05", layout)
+		}
+	}
+	for _, pattern := range c.NFS.ErrorPatterns {
+		if expr, ok := strings.CutPrefix(pattern, "re:"); ok {
+			if _, err := regexp.Compile(expr); err != nil {
+				add("invalid nfs error pattern %q: %v", pattern, err)
+			}
+		}
+	}
+
+	if c.Database.SQLitePath != "" && c.Database.RecordInterval < 1 {
+		add("database record_interval must be at least 1 second, got %d", c.Database.RecordInterval)
+	}
+
+	if c.Alerts.WebhookURL != "" {
+		u, err := url.Parse(c.Alerts.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("invalid alert webhook_url: expected an http(s) URL")
+		}
+		if c.Alerts.PollInterval < 1 {
+			add("alert poll_interval must be at least 1 second, got %d", c.Alerts.PollInterval)
+		}
+	}
+
+	if smtp := c.Alerts.SMTP; smtp.Host != "" {
+		if smtp.Port < 1 || smtp.Port > 65535 {
+			add("smtp port %d is out of range", smtp.Port)
+		}
+		if smtp.From == "" || len(smtp.To) == 0 {
+			add("smtp is configured but from or to is empty")
+		}
+		if c.Alerts.StaleAppThreshold < 1 || c.Alerts.StaleAppInterval < 1 {
+			add("alert stale_app_threshold and stale_app_interval must be at least 1")
+		}
+	}
+
+	if _, err := businessday.Parse(c.BusinessDay.Start, c.BusinessDay.Timezone); err != nil {
+		errs = append(errs, err)
+	}
+
+	for name, value := range c.SLA.Workflows {
+		if name == "" {
+			add("sla workflow name must not be empty")
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			add("invalid sla for workflow %s: %q is not a positive duration such as 90m", name, value)
+		}
+	}
+
+	errs = append(errs, c.validateYarn()...)
+	if c.IsProdMode() {
+		errs = append(errs, c.validateInformatica()...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateYarn checks the RM URLs for the current mode and the client settings
+func (c *Config) validateYarn() []error {
+	var errs []error
+
+	urls := c.GetYarnURLs()
+	if len(urls) == 0 {
+		errs = append(errs, errors.New("no Yarn RM URL configured"))
+	}
+	for _, rmURL := range urls {
+		// Test mode may point at a local fixture directory instead of an RM
+		if c.IsTestMode() && !strings.HasPrefix(rmURL, "http://") && !strings.HasPrefix(rmURL, "https://") {
+			continue
+		}
+		u, err := url.Parse(rmURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid Yarn RM URL %q: expected http(s)://host:port", rmURL))
+		}
+	}
+
+	if c.Services.YarnTimeout < 0 {
+		errs = append(errs, fmt.Errorf("yarn_timeout must not be negative, got %d", c.Services.YarnTimeout))
+	}
+
+	krb := c.Services.YarnKerberos
+	if krb.Enabled && (krb.Keytab == "" || krb.Principal == "" || krb.Realm == "") {
+		errs = append(errs, errors.New("yarn kerberos is enabled but keytab, principal or realm is empty"))
+	}
+	return errs
+}
+
+// validateInformatica checks the repository database settings
+func (c *Config) validateInformatica() []error {
+	var errs []error
+	db := c.Services.InformaticaDB
+
+	switch strings.ToLower(db.DBType) {
+	case "", "sqlserver", "oracle":
+	default:
+		errs = append(errs, fmt.Errorf("informatica db_type must be sqlserver or oracle, got %q", db.DBType))
+	}
+
+	var missing []string
+	if db.Host == "" {
+		missing = append(missing, "host")
+	}
+	if db.Database == "" {
+		missing = append(missing, "database")
+	}
+	if db.Username == "" {
+		missing = append(missing, "username")
+	}
+	if len(missing) > 0 {
+		errs = append(errs, fmt.Errorf("informatica database settings missing: %s", strings.Join(missing, ", ")))
+	}
+	if db.Port < 1 || db.Port > 65535 {
+		errs = append(errs, fmt.Errorf("informatica database port %d is out of range", db.Port))
+	}
+
+	if db.Timezone != "" {
+		if _, err := time.LoadLocation(db.Timezone); err != nil {
+			errs = append(errs, fmt.Errorf("invalid informatica timezone %q: %v", db.Timezone, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateCORSWildcard(t *testing.T) {
+	tests := []struct {
+		name     string
+		authUser string
+		origins  []string
+		wantErr  bool
+	}{
+		{"wildcard without auth", "", []string{"*"}, false},
+		{"wildcard with auth", "admin", []string{"*"}, true},
+		{"wildcard beside a listed origin with auth", "admin", []string{"https://portal.example.com", "*"}, true},
+		{"listed origin with auth", "admin", []string{"https://portal.example.com"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := LoadFromEnv()
+			cfg.Server.AuthUser, cfg.Server.AuthPassword = tt.authUser, "secret"
+			cfg.Server.CORSAllowedOrigins = tt.origins
+
+			err := cfg.Validate()
+			gotErr := err != nil && strings.Contains(err.Error(), "cors origin *")
+			if gotErr != tt.wantErr {
+				t.Errorf("Validate() = %v, want a cors wildcard error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAuthCredentials(t *testing.T) {
+	tests := []struct {
+		name                 string
+		user, password, hash string
+		wantErr              bool
+	}{
+		{"auth disabled", "", "", "", false},
+		{"password", "admin", "secret", "", false},
+		{"hash", "admin", "", "$2a$10$abcdefghijklmnopqrstuv", false},
+		{"user without a credential", "admin", "", "", true},
+		{"both password and hash", "admin", "secret", "$2a$10$abcdefghijklmnopqrstuv", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := LoadFromEnv()
+			cfg.Server.AuthUser = tt.user
+			cfg.Server.AuthPassword, cfg.Server.AuthPasswordHash = tt.password, tt.hash
+
+			err := cfg.Validate()
+			gotErr := err != nil && strings.Contains(err.Error(), "auth_password")
+			if gotErr != tt.wantErr {
+				t.Errorf("Validate() = %v, want an auth error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateUnreadableAuthPasswordFile(t *testing.T) {
+	t.Setenv("AUTH_USER", "admin")
+	t.Setenv("AUTH_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	err := LoadFromEnv().Validate()
+	if err == nil || !strings.Contains(err.Error(), "auth_password") {
+		t.Errorf("Validate() = %v, want the missing credential reported", err)
+	}
+}
@@ -0,0 +1,241 @@
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"salam-monitoring/internal/informatica"
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/nfs"
+)
+
+// schema creates the history tables. Rows are keyed so repeated polls of the same
+// run update it in place rather than duplicating it.
+const schema = `
+CREATE TABLE IF NOT EXISTS informatica_runs (
+	stat_id       INTEGER PRIMARY KEY,
+	workflow_name TEXT NOT NULL,
+	status        TEXT NOT NULL,
+	started_at    TIMESTAMP NOT NULL,
+	finished_at   TIMESTAMP,
+	recorded_at   TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_informatica_runs_name ON informatica_runs (workflow_name, started_at);
+
+CREATE TABLE IF NOT EXISTS nfs_summaries (
+	source       TEXT NOT NULL,
+	date         TEXT NOT NULL,
+	workflow     TEXT NOT NULL,
+	status       TEXT NOT NULL,
+	has_errors   BOOLEAN NOT NULL,
+	has_warnings BOOLEAN NOT NULL,
+	log_count    INTEGER NOT NULL,
+	recorded_at  TIMESTAMP NOT NULL,
+	PRIMARY KEY (source, date, workflow)
+);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	ts         TIMESTAMP NOT NULL,
+	operation  TEXT NOT NULL,
+	app_id     TEXT NOT NULL,
+	app_name   TEXT NOT NULL,
+	pattern    TEXT NOT NULL,
+	requester  TEXT NOT NULL,
+	result     TEXT NOT NULL,
+	error      TEXT NOT NULL
+);
+`
+
+// Run is a recorded Informatica workflow run
+type Run struct {
+	StatID       int64      `json:"stat_id"`
+	WorkflowName string     `json:"workflow_name"`
+	Status       string     `json:"status"`
+	StartedAt    time.Time  `json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at"`
+}
+
+// AuditEntry records one destructive operation, such as killing a Yarn application
+type AuditEntry struct {
+	Time      time.Time `json:"ts"`
+	Operation string    `json:"operation"`
+	AppID     string    `json:"app_id"`
+	AppName   string    `json:"app_name,omitempty"`
+	Pattern   string    `json:"pattern,omitempty"`
+	Requester string    `json:"requester"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Store persists workflow history to SQLite. A Store opened with an empty path,
+// or a nil *Store, silently discards writes and returns no history.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if needed) the SQLite database at path
+func Open(path string) (*Store, error) {
+	if path == "" {
+		logger.Info("History persistence disabled (no SQLite path configured)")
+		return &Store{}, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database: %w", err)
+	}
+	// SQLite allows a single writer; serialise access instead of hitting SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create history tables: %w", err)
+	}
+
+	logger.Info("History database opened: %s", path)
+	return &Store{db: db}, nil
+}
+
+// Enabled reports whether the store persists anything
+func (s *Store) Enabled() bool {
+	return s != nil && s.db != nil
+}
+
+// Close closes the underlying database
+func (s *Store) Close() error {
+	if !s.Enabled() {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// RecordWorkflows upserts a batch of Informatica workflow runs
+func (s *Store) RecordWorkflows(workflows []informatica.WorkflowStat) error {
+	if !s.Enabled() || len(workflows) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin history transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO informatica_runs (stat_id, workflow_name, status, started_at, finished_at, recorded_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (stat_id) DO UPDATE SET
+			status = excluded.status,
+			finished_at = excluded.finished_at,
+			recorded_at = excluded.recorded_at`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare workflow insert: %w", err)
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, wf := range workflows {
+		if _, err := stmt.Exec(wf.StatID, wf.WorkflowName, wf.Status, wf.StartedAt, wf.FinishedAt, now); err != nil {
+			return fmt.Errorf("failed to record workflow %d: %w", wf.StatID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// RecordNFSSummaries upserts a batch of NFS workflow summaries
+func (s *Store) RecordNFSSummaries(summaries []*nfs.WorkflowSummary) error {
+	if !s.Enabled() || len(summaries) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin history transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO nfs_summaries (source, date, workflow, status, has_errors, has_warnings, log_count, recorded_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (source, date, workflow) DO UPDATE SET
+			status = excluded.status,
+			has_errors = excluded.has_errors,
+			has_warnings = excluded.has_warnings,
+			log_count = excluded.log_count,
+			recorded_at = excluded.recorded_at`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare NFS summary insert: %w", err)
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, summary := range summaries {
+		if _, err := stmt.Exec(summary.Source, summary.Date, summary.Workflow, summary.Status,
+			summary.HasErrors, summary.HasWarnings, len(summary.Logs), now); err != nil {
+			return fmt.Errorf("failed to record NFS summary %s/%s: %w", summary.Source, summary.Workflow, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// RecordAudit appends an audit entry; entries are never updated or removed
+func (s *Store) RecordAudit(entry AuditEntry) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO audit_log (ts, operation, app_id, app_name, pattern, requester, result, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.Time, entry.Operation, entry.AppID, entry.AppName, entry.Pattern, entry.Requester, entry.Result, entry.Error)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry for %s: %w", entry.AppID, err)
+	}
+	return nil
+}
+
+// GetWorkflowHistory returns runs of the named workflow started within the last days, newest first
+func (s *Store) GetWorkflowHistory(name string, days int) ([]Run, error) {
+	return s.runsSince(name, time.Now().AddDate(0, 0, -days))
+}
+
+// runsSince returns runs of the named workflow started at or after since, newest first
+func (s *Store) runsSince(name string, since time.Time) ([]Run, error) {
+	runs := []Run{}
+	if !s.Enabled() {
+		return runs, nil
+	}
+
+	rows, err := s.db.Query(`
+		SELECT stat_id, workflow_name, status, started_at, finished_at
+		FROM informatica_runs
+		WHERE workflow_name = ? AND started_at >= ?
+		ORDER BY started_at DESC`, name, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workflow history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var run Run
+		var finishedAt sql.NullTime
+		if err := rows.Scan(&run.StatID, &run.WorkflowName, &run.Status, &run.StartedAt, &finishedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan workflow history: %w", err)
+		}
+		if finishedAt.Valid {
+			run.FinishedAt = &finishedAt.Time
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
@@ -0,0 +1,64 @@
+package history
+
+import (
+	"time"
+)
+
+// DayStat summarises one workflow's runs on a single day
+type DayStat struct {
+	Date               string  `json:"date"`
+	Success            int     `json:"success"`
+	Failed             int     `json:"failed"`
+	Other              int     `json:"other"`
+	AvgDurationSeconds float64 `json:"avg_duration_seconds"`
+}
+
+// GetWorkflowTrend returns per-day run counts and average duration for the named
+// workflow over the last days, oldest first. Days without runs are included with zero counts.
+func (s *Store) GetWorkflowTrend(name string, days int) ([]DayStat, error) {
+	today := time.Now()
+	start := time.Date(today.Year(), today.Month(), today.Day()-days+1, 0, 0, 0, 0, today.Location())
+	runs, err := s.runsSince(name, start)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pre-fill every day in the window so charts get a continuous axis
+	stats := make([]DayStat, days)
+	index := make(map[string]int, days)
+	for i := 0; i < days; i++ {
+		date := today.AddDate(0, 0, i-days+1).Format("2006-01-02")
+		stats[i] = DayStat{Date: date}
+		index[date] = i
+	}
+
+	durations := make([]time.Duration, days)
+	finished := make([]int, days)
+	for _, run := range runs {
+		i, ok := index[run.StartedAt.Local().Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+
+		switch run.Status {
+		case "SUCCESS":
+			stats[i].Success++
+		case "FAILED":
+			stats[i].Failed++
+		default:
+			stats[i].Other++
+		}
+
+		if run.FinishedAt != nil {
+			durations[i] += run.FinishedAt.Sub(run.StartedAt)
+			finished[i]++
+		}
+	}
+
+	for i := range stats {
+		if finished[i] > 0 {
+			stats[i].AvgDurationSeconds = (durations[i] / time.Duration(finished[i])).Seconds()
+		}
+	}
+	return stats, nil
+}
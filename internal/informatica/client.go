@@ -3,13 +3,18 @@ package informatica
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"salam-monitoring/internal/businessday"
 	"salam-monitoring/internal/logger"
 
 	_ "github.com/denisenkom/go-mssqldb" // SQL Server driver
+	_ "github.com/sijms/go-ora/v2"       // Oracle driver
 )
 
 // WorkflowStat represents a workflow from PO_WORKFLOWSTAT
@@ -22,6 +27,10 @@ type WorkflowStat struct {
 	CreatedAt    time.Time   `json:"created_at"`
 	UpdatedAt    time.Time   `json:"updated_at"`
 	Elapsed      ElapsedTime `json:"elapsed"`
+
+	// Set by AnnotateSLA for workflows with a configured SLA
+	SLABreached bool         `json:"sla_breached"`
+	SLATarget   *ElapsedTime `json:"sla_target,omitempty"`
 }
 
 // TaskStat represents a task from PO_TASKSTAT
@@ -43,6 +52,19 @@ type ElapsedTime struct {
 	Sec int `json:"sec"`
 }
 
+// Duration returns the elapsed time as a time.Duration
+func (e ElapsedTime) Duration() time.Duration {
+	return time.Duration(e.Hrs)*time.Hour + time.Duration(e.Min)*time.Minute + time.Duration(e.Sec)*time.Second
+}
+
+// String formats the elapsed time as e.g. "2h 05m 09s"
+func (e ElapsedTime) String() string {
+	return fmt.Sprintf("%dh %02dm %02ds", e.Hrs, e.Min, e.Sec)
+}
+
+// ErrWorkflowNotFound is returned when no workflow run has the requested stat ID
+var ErrWorkflowNotFound = errors.New("workflow not found")
+
 // WorkflowWithTasks represents a workflow with its child tasks
 type WorkflowWithTasks struct {
 	Workflow WorkflowStat `json:"workflow"`
@@ -51,91 +73,271 @@ type WorkflowWithTasks struct {
 
 // DatabaseConfig holds database connection configuration
 type DatabaseConfig struct {
+	DBType     string // DBTypeSQLServer (default) or DBTypeOracle
 	Host       string
 	Port       int
-	Database   string
+	Database   string // database name, or the service name on Oracle
 	Username   string
 	Password   string
-	TimeOffset int // hours offset for timezone conversion
+	TimeOffset int    // hours offset for timezone conversion, used when Timezone is empty
+	Timezone   string // IANA zone of the repository timestamps, e.g. Asia/Riyadh
+
+	// DayBoundary sets when "today" starts for the today queries; the zero
+	// Boundary keeps the database server's midnight
+	DayBoundary businessday.Boundary
+
+	// WorkflowSLAs maps workflow names to the longest they may run; see AnnotateSLA
+	WorkflowSLAs map[string]time.Duration
+
+	// Connection pool limits; zero values fall back to the defaults below
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
 }
 
-// Client represents an Informatica SQL Server database client
+// Default connection pool limits. Connections are recycled after
+// DefaultConnMaxLifetime because a load balancer in front of SQL Server drops
+// idle flows silently, and the pool would otherwise hand out dead connections.
+const (
+	DefaultMaxOpenConns    = 10
+	DefaultMaxIdleConns    = 5
+	DefaultConnMaxLifetime = 5 * time.Minute
+)
+
+// Client represents an Informatica repository database client
 type Client struct {
 	config     DatabaseConfig
+	dialect    dialect
+	mu         sync.RWMutex // guards db and mockMode
 	db         *sql.DB
 	timeOffset int
-	mockMode   bool // For development when SQL Server is not available
+	location   *time.Location // nil means times are shifted by timeOffset instead
+	mockMode   bool           // For development when SQL Server is not available
+
+	done      chan struct{} // closed by Close to stop reconnectLoop
+	closeOnce sync.Once
 }
 
-// NewClient creates a new Informatica SQL Server client
+// NewClient creates a new Informatica repository client for config.DBType
 func NewClient(config DatabaseConfig) (*Client, error) {
-	logger.Info("Creating Informatica SQL Server client")
+	d, err := dialectFor(config.DBType)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Creating Informatica %s client", d.driverName())
+
+	var location *time.Location
+	if config.Timezone != "" {
+		location, err = time.LoadLocation(config.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Informatica timezone %q: %w", config.Timezone, err)
+		}
+	}
 
 	client := &Client{
 		config:     config,
+		dialect:    d,
 		timeOffset: config.TimeOffset,
+		location:   location,
 		mockMode:   false, // Try real connection first
+		done:       make(chan struct{}),
 	}
 
-	// Construct SQL Server connection string
-	dsn := fmt.Sprintf("server=%s;port=%d;database=%s;user id=%s;password=%s;encrypt=disable",
-		config.Host, config.Port, config.Database, config.Username, config.Password)
-
-	db, err := sql.Open("sqlserver", dsn)
+	db, err := client.openDatabase()
 	if err != nil {
-		logger.LogError("Failed to connect to SQL Server, falling back to mock mode", err)
+		logger.LogError("Failed to connect to Informatica database, falling back to mock mode", err)
 		client.mockMode = true
+		go client.reconnectLoop()
 		return client, nil
 	}
 
+	client.db = db
+	logger.Info("Successfully connected to Informatica %s database", d.driverName())
+	return client, nil
+}
+
+// openDatabase opens and pings a connection for the client's configuration
+func (c *Client) openDatabase() (*sql.DB, error) {
+	db, err := sql.Open(c.dialect.driverName(), c.dialect.dsn(c.config))
+	if err != nil {
+		return nil, err
+	}
+	applyPoolLimits(db, c.config)
+
 	// Test the connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := db.PingContext(ctx); err != nil {
-		logger.LogError("Failed to ping SQL Server, falling back to mock mode", err)
 		db.Close()
-		client.mockMode = true
-		return client, nil
+		return nil, fmt.Errorf("failed to ping Informatica database: %w", err)
 	}
+	return db, nil
+}
 
-	client.db = db
-	logger.Info("Successfully connected to Informatica SQL Server database")
-	return client, nil
+// applyPoolLimits sizes the connection pool, using the defaults for unset limits
+func applyPoolLimits(db *sql.DB, config DatabaseConfig) {
+	maxOpen := config.MaxOpenConns
+	if maxOpen <= 0 {
+		maxOpen = DefaultMaxOpenConns
+	}
+	maxIdle := config.MaxIdleConns
+	if maxIdle <= 0 {
+		maxIdle = DefaultMaxIdleConns
+	}
+	lifetime := config.ConnMaxLifetime
+	if lifetime <= 0 {
+		lifetime = DefaultConnMaxLifetime
+	}
+
+	db.SetMaxOpenConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
+	db.SetConnMaxLifetime(lifetime)
 }
 
-// Close closes the database connection
+// database returns the live connection, or nil while the client is in mock mode
+func (c *Client) database() *sql.DB {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.mockMode {
+		return nil
+	}
+	return c.db
+}
+
+// IsMockMode reports whether the client is serving mock data instead of querying the database
+func (c *Client) IsMockMode() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.mockMode
+}
+
+// Ping checks that the real database is reachable, reconnecting once if the
+// connection has dropped. A client that fell back to mock mode at startup tries
+// to connect again and leaves mock mode once the database answers.
+func (c *Client) Ping(ctx context.Context) error {
+	if !c.IsMockMode() {
+		_, err := c.ensureConnection(ctx)
+		return err
+	}
+
+	db, err := c.openDatabase()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	if !c.mockMode {
+		// reconnectLoop got there first
+		c.mu.Unlock()
+		db.Close()
+		return nil
+	}
+	c.db = db
+	c.mockMode = false
+	c.mu.Unlock()
+
+	logger.Info("Connected to Informatica database, leaving mock mode")
+	return nil
+}
+
+// Close stops any background reconnect and closes the database connection
 func (c *Client) Close() error {
+	c.closeOnce.Do(func() { close(c.done) })
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.db != nil {
 		return c.db.Close()
 	}
 	return nil
 }
 
-// convertEpochMillisToTime converts Informatica epoch milliseconds to time with offset
+// convertEpochMillisToTime converts Informatica epoch milliseconds to time in the
+// configured timezone. Without a timezone the fixed hour offset is added and the
+// result keeps a UTC label, which drifts by an hour across DST changes.
 func (c *Client) convertEpochMillisToTime(epochMs int64) time.Time {
 	if epochMs == 0 {
 		return time.Time{}
 	}
 
-	// Convert milliseconds to seconds and apply offset
+	// Convert milliseconds to seconds
 	epochSeconds := epochMs / 1000
-	timeOffset := time.Duration(c.timeOffset) * time.Hour
+	if c.location != nil {
+		return time.Unix(epochSeconds, 0).In(c.location)
+	}
 
+	timeOffset := time.Duration(c.timeOffset) * time.Hour
 	return time.Unix(epochSeconds, 0).UTC().Add(timeOffset)
 }
 
+// convertTimeToEpochMillis is the inverse of convertEpochMillisToTime, turning a
+// time from Location back into Informatica epoch milliseconds
+func (c *Client) convertTimeToEpochMillis(t time.Time) int64 {
+	if c.location != nil {
+		return t.Unix() * 1000
+	}
+
+	timeOffset := time.Duration(c.timeOffset) * time.Hour
+	return t.Add(-timeOffset).Unix() * 1000
+}
+
+// now returns the current time in the frame of convertEpochMillisToTime, so it
+// can be compared with workflow times. With only a TimeOffset that is the
+// shifted wall clock labelled UTC, not the real instant.
+func (c *Client) now() time.Time {
+	if c.location != nil {
+		return time.Now().In(c.location)
+	}
+	return time.Now().UTC().Add(time.Duration(c.timeOffset) * time.Hour)
+}
+
+// todayStartMillis returns a SQL expression for the start of today in epoch
+// milliseconds: the database's midnight, or with a DayBoundary configured the
+// boundary's start of the current business day as a literal
+func (c *Client) todayStartMillis() string {
+	if c.config.DayBoundary.IsZero() {
+		return c.dialect.startOfTodayMillis()
+	}
+	// Repository epochs are real instants, whichever of Timezone or TimeOffset
+	// is used to display them
+	return strconv.FormatInt(c.config.DayBoundary.Start(time.Now()).UnixMilli(), 10)
+}
+
+// Today returns the date the today queries cover, as 2006-01-02
+func (c *Client) Today() string {
+	if c.config.DayBoundary.IsZero() {
+		return c.now().Format("2006-01-02")
+	}
+	return c.config.DayBoundary.Today()
+}
+
+// Location returns the zone workflow times are reported in, and in which
+// wall-clock times passed to GetWorkflowsBetween should be built. With only a
+// TimeOffset configured this is UTC, holding the offset-adjusted wall clock.
+func (c *Client) Location() *time.Location {
+	if c.location != nil {
+		return c.location
+	}
+	return time.UTC
+}
+
 // calculateElapsed calculates elapsed time between start and end
 func (c *Client) calculateElapsed(startTime, endTime time.Time) ElapsedTime {
 	var duration time.Duration
 
 	if endTime.IsZero() {
-		// Still running - calculate from now
-		duration = time.Since(startTime)
+		// Still running - calculate from now, in the same frame as startTime
+		duration = c.now().Sub(startTime)
 	} else {
 		duration = endTime.Sub(startTime)
 	}
 
+	return elapsedTimeOf(duration)
+}
+
+// elapsedTimeOf breaks a duration down into hours, minutes and seconds
+func elapsedTimeOf(duration time.Duration) ElapsedTime {
 	hours := int(duration.Hours())
 	minutes := int(duration.Minutes()) % 60
 	seconds := int(duration.Seconds()) % 60
@@ -173,28 +375,36 @@ func mapTaskState(potState int) string {
 	}
 }
 
-// GetWorkflowsToday retrieves all workflows that started today
-func (c *Client) GetWorkflowsToday() ([]WorkflowStat, error) {
-	if c.mockMode {
-		return c.getMockWorkflowsToday(), nil
-	}
-
-	// SQL Server query for workflows that started today
-	query := `
-SELECT
-POW_STATID,
+// workflowColumns is the PO_WORKFLOWSTAT column list of every workflow query,
+// in the order scanWorkflowRow reads it. Add or change columns here and in
+// scanWorkflowRow together.
+const workflowColumns = `POW_STATID,
 POW_WORKFLOWDEFINITIONNAM,
 POW_STATE,
 POW_STARTTIME,
 POW_ENDTIME,
 POW_CREATEDTIME,
-POW_LASTUPDATETIME
+POW_LASTUPDATETIME`
+
+// workflowSelect starts every workflow query; callers append the WHERE and
+// ORDER BY clauses
+const workflowSelect = `
+SELECT
+` + workflowColumns + `
 FROM PO_WORKFLOWSTAT
-WHERE POW_STARTTIME >= DATEDIFF(SECOND, '1970-01-01', CAST(GETDATE() AS DATE)) * 1000
+`
+
+// GetWorkflowsToday retrieves all workflows that started today
+func (c *Client) GetWorkflowsToday(ctx context.Context) ([]WorkflowStat, error) {
+	if c.IsMockMode() {
+		return c.getMockWorkflowsToday(), nil
+	}
+
+	query := workflowSelect + `WHERE POW_STARTTIME >= ` + c.todayStartMillis() + `
 ORDER BY POW_STARTTIME DESC
 `
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	workflows, err := c.queryWorkflows(ctx, query)
@@ -202,65 +412,149 @@ ORDER BY POW_STARTTIME DESC
 		return nil, err
 	}
 
-	logger.Info("Retrieved %d workflows for today", len(workflows))
+	logger.InfoCtx(ctx, "Retrieved %d workflows for today", len(workflows))
 	return workflows, nil
 }
 
-// GetWorkflowWithTasks retrieves a specific workflow and its tasks
-func (c *Client) GetWorkflowWithTasks(statID int64) (*WorkflowWithTasks, error) {
-	if c.mockMode {
-		return c.getMockWorkflowWithTasks(statID), nil
+// GetStatusCounts counts today's workflows by status with a single GROUP BY.
+// RUNNING, SUCCESS and FAILED are always present, zero when no workflow is in
+// that state; other states appear under their UNKNOWN_n name.
+func (c *Client) GetStatusCounts(ctx context.Context) (map[string]int, error) {
+	counts := map[string]int{"RUNNING": 0, "SUCCESS": 0, "FAILED": 0}
+
+	if c.IsMockMode() {
+		for _, wf := range c.getMockWorkflowsToday() {
+			counts[wf.Status]++
+		}
+		return counts, nil
 	}
 
-	logger.Info("Getting workflow with tasks for stat_id: %d", statID)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
 
-	// Get the workflow first
-	workflowQuery := `
-		SELECT 
-			POW_STATID,
-			POW_WORKFLOWDEFINITIONNAM,
-			POW_STATE,
-			POW_STARTTIME,
-			POW_ENDTIME,
-			POW_CREATEDTIME,
-			POW_LASTUPDATETIME
-		FROM PO_WORKFLOWSTAT
-		WHERE POW_STATID = ?
-	`
+	db, err := c.ensureConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	query := `
+SELECT POW_STATE, COUNT(*)
+FROM PO_WORKFLOWSTAT
+WHERE POW_STARTTIME >= ` + c.todayStartMillis() + `
+GROUP BY POW_STATE
+`
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count workflows by status: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var powState, count int
+		if err := rows.Scan(&powState, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan status count row: %w", err)
+		}
+		counts[mapWorkflowState(powState)] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating status count rows: %w", err)
+	}
+	return counts, nil
+}
+
+// GetWorkflowsTodayPaged retrieves one page of today's workflows, newest first,
+// along with the total number of workflows that started today
+func (c *Client) GetWorkflowsTodayPaged(ctx context.Context, offset, limit int) ([]WorkflowStat, int, error) {
+	if c.IsMockMode() {
+		workflows := c.getMockWorkflowsToday()
+		total := len(workflows)
+		start := min(max(offset, 0), total)
+		end := min(start+limit, total)
+		return workflows[start:end], total, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	var wf WorkflowStat
-	var powState int
-	var startTimeMs, createdTimeMs, updatedTimeMs int64
-	var endTimePtr *int64
+	db, err := c.ensureConnection(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	err := c.db.QueryRowContext(ctx, workflowQuery, statID).Scan(
-		&wf.StatID,
-		&wf.WorkflowName,
-		&powState,
-		&startTimeMs,
-		&endTimePtr,
-		&createdTimeMs,
-		&updatedTimeMs,
-	)
+	var total int
+	countQuery := `SELECT COUNT(*) FROM PO_WORKFLOWSTAT WHERE POW_STARTTIME >= ` + c.todayStartMillis()
+	if err := db.QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count workflows: %w", err)
+	}
+
+	// OFFSET ... FETCH NEXT is supported by both SQL Server 2012+ and Oracle 12c+
+	query := workflowSelect + `WHERE POW_STARTTIME >= ` + c.todayStartMillis() + `
+ORDER BY POW_STARTTIME DESC, POW_STATID DESC
+OFFSET ` + c.dialect.placeholder(1) + ` ROWS FETCH NEXT ` + c.dialect.placeholder(2) + ` ROWS ONLY
+`
+
+	workflows, err := c.queryWorkflows(ctx, query, offset, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get workflow: %w", err)
+		return nil, 0, err
 	}
 
-	// Convert workflow data
-	wf.Status = mapWorkflowState(powState)
-	wf.StartedAt = c.convertEpochMillisToTime(startTimeMs)
-	wf.CreatedAt = c.convertEpochMillisToTime(createdTimeMs)
-	wf.UpdatedAt = c.convertEpochMillisToTime(updatedTimeMs)
+	logger.InfoCtx(ctx, "Retrieved %d of %d workflows for today from offset %d", len(workflows), total, offset)
+	return workflows, total, nil
+}
 
-	if endTimePtr != nil {
-		endTime := c.convertEpochMillisToTime(*endTimePtr)
-		wf.FinishedAt = &endTime
-		wf.Elapsed = c.calculateElapsed(wf.StartedAt, endTime)
-	} else {
-		wf.Elapsed = c.calculateElapsed(wf.StartedAt, time.Time{})
+// GetWorkflowsBetween retrieves all workflows that started between start and end
+// inclusive. Bounds are compared as instants; see Location for building them.
+func (c *Client) GetWorkflowsBetween(ctx context.Context, start, end time.Time) ([]WorkflowStat, error) {
+	if c.IsMockMode() {
+		return c.getMockWorkflowsBetween(start, end), nil
+	}
+
+	query := workflowSelect + `WHERE POW_STARTTIME BETWEEN ` + c.dialect.placeholder(1) + ` AND ` + c.dialect.placeholder(2) + `
+ORDER BY POW_STARTTIME DESC
+`
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	workflows, err := c.queryWorkflows(ctx, query, c.convertTimeToEpochMillis(start), c.convertTimeToEpochMillis(end))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfoCtx(ctx, "Retrieved %d workflows between %s and %s", len(workflows),
+		start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05"))
+	return workflows, nil
+}
+
+// GetWorkflowWithTasks retrieves a specific workflow and its tasks. It returns
+// ErrWorkflowNotFound when no workflow run has the stat ID.
+func (c *Client) GetWorkflowWithTasks(ctx context.Context, statID int64) (*WorkflowWithTasks, error) {
+	if c.IsMockMode() {
+		if wt := c.getMockWorkflowWithTasks(statID); wt != nil {
+			return wt, nil
+		}
+		return nil, fmt.Errorf("%w: stat_id %d", ErrWorkflowNotFound, statID)
+	}
+
+	db, err := c.ensureConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfoCtx(ctx, "Getting workflow with tasks for stat_id: %d", statID)
+
+	// Get the workflow first
+	workflowQuery := workflowSelect + `WHERE POW_STATID = ` + c.dialect.placeholder(1)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	wf, err := c.scanWorkflowRow(db.QueryRowContext(ctx, workflowQuery, statID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: stat_id %d", ErrWorkflowNotFound, statID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
 	}
 
 	// Get tasks for this workflow
@@ -274,11 +568,11 @@ func (c *Client) GetWorkflowWithTasks(statID int64) (*WorkflowWithTasks, error)
 			POT_STARTTIME,
 			POT_ENDTIME
 		FROM PO_TASKSTAT
-		WHERE POT_PARENTSTATID = ?
+		WHERE POT_PARENTSTATID = ` + c.dialect.placeholder(1) + `
 		ORDER BY POT_STARTTIME
 	`
 
-	rows, err := c.db.QueryContext(ctx, tasksQuery, statID)
+	rows, err := db.QueryContext(ctx, tasksQuery, statID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tasks: %w", err)
 	}
@@ -323,32 +617,36 @@ func (c *Client) GetWorkflowWithTasks(statID int64) (*WorkflowWithTasks, error)
 		return nil, fmt.Errorf("error iterating task rows: %w", err)
 	}
 
-	logger.Info("Retrieved workflow %s with %d tasks", wf.WorkflowName, len(tasks))
+	logger.InfoCtx(ctx, "Retrieved workflow %s with %d tasks", wf.WorkflowName, len(tasks))
 	return &WorkflowWithTasks{
 		Workflow: wf,
 		Tasks:    tasks,
 	}, nil
 }
 
-// IsHealthy checks if the Informatica database connection is healthy
+// IsHealthy checks if the Informatica database connection is healthy, reconnecting
+// once if it has dropped. A client in mock mode is serving data and reports healthy;
+// callers that need to tell real from mock data check IsMockMode.
 func (c *Client) IsHealthy() bool {
-	if c.mockMode {
-		return true
-	}
+	return c.IsHealthyContext(context.Background())
+}
 
-	if c.db == nil {
-		return false
+// IsHealthyContext is IsHealthy, giving up when ctx is done
+func (c *Client) IsHealthyContext(ctx context.Context) bool {
+	if c.IsMockMode() {
+		return true
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	return c.db.PingContext(ctx) == nil
+	_, err := c.ensureConnection(ctx)
+	return err == nil
 }
 
 // Mock data for development/testing
 func (c *Client) getMockWorkflowsToday() []WorkflowStat {
-	now := time.Now()
+	now := c.now()
 	startTime1 := now.Add(-2 * time.Hour)
 	startTime2 := now.Add(-1 * time.Hour)
 	endTime1 := now.Add(-30 * time.Minute)
@@ -389,6 +687,16 @@ func (c *Client) getMockWorkflowsToday() []WorkflowStat {
 	return workflows
 }
 
+func (c *Client) getMockWorkflowsBetween(start, end time.Time) []WorkflowStat {
+	var workflows []WorkflowStat
+	for _, wf := range c.getMockWorkflowsToday() {
+		if !wf.StartedAt.Before(start) && !wf.StartedAt.After(end) {
+			workflows = append(workflows, wf)
+		}
+	}
+	return workflows
+}
+
 func (c *Client) getMockWorkflowWithTasks(statID int64) *WorkflowWithTasks {
 	workflows := c.getMockWorkflowsToday()
 
@@ -404,7 +712,7 @@ func (c *Client) getMockWorkflowWithTasks(statID int64) *WorkflowWithTasks {
 	}
 
 	if !found {
-		return &WorkflowWithTasks{}
+		return nil
 	}
 
 	// Generate mock tasks for the workflow
@@ -442,47 +750,27 @@ func (c *Client) getMockWorkflowWithTasks(statID int64) *WorkflowWithTasks {
 }
 
 // GetRunningWorkflows returns only running top-level workflows (excludes child workflows when possible)
-func (c *Client) GetRunningWorkflows() ([]WorkflowStat, error) {
-	if c.mockMode {
+func (c *Client) GetRunningWorkflows(ctx context.Context) ([]WorkflowStat, error) {
+	if c.IsMockMode() {
 		return c.getMockRunningWorkflows(), nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	runningQueryWithParent := `
-SELECT
-POW_STATID,
-POW_WORKFLOWDEFINITIONNAM,
-POW_STATE,
-POW_STARTTIME,
-POW_ENDTIME,
-POW_CREATEDTIME,
-POW_LASTUPDATETIME
-FROM PO_WORKFLOWSTAT
-WHERE POW_STATE = 0
+	runningQueryWithParent := workflowSelect + `WHERE POW_STATE = 0
 AND (POW_PARENTSTATID IS NULL OR POW_PARENTSTATID = 0)
 ORDER BY POW_STARTTIME DESC
 `
 
-	runningQueryWithoutParent := `
-SELECT
-POW_STATID,
-POW_WORKFLOWDEFINITIONNAM,
-POW_STATE,
-POW_STARTTIME,
-POW_ENDTIME,
-POW_CREATEDTIME,
-POW_LASTUPDATETIME
-FROM PO_WORKFLOWSTAT
-WHERE POW_STATE = 0
+	runningQueryWithoutParent := workflowSelect + `WHERE POW_STATE = 0
 ORDER BY POW_STARTTIME DESC
 `
 
 	workflows, err := c.queryWorkflows(ctx, runningQueryWithParent)
 	if err != nil {
 		if strings.Contains(strings.ToUpper(err.Error()), "POW_PARENTSTATID") {
-			logger.Info("POW_PARENTSTATID column unavailable, retrying running workflows without child filter")
+			logger.InfoCtx(ctx, "POW_PARENTSTATID column unavailable, retrying running workflows without child filter")
 			return c.queryWorkflows(ctx, runningQueryWithoutParent)
 		}
 		return nil, err
@@ -493,9 +781,14 @@ ORDER BY POW_STARTTIME DESC
 
 // queryWorkflows executes a workflow-level query and converts the results
 func (c *Client) queryWorkflows(ctx context.Context, query string, args ...any) ([]WorkflowStat, error) {
-	logger.Info("Executing workflow query: %s", query)
+	logger.InfoCtx(ctx, "Executing workflow query: %s", query)
+
+	db, err := c.ensureConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := c.db.QueryContext(ctx, query, args...)
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute workflow query: %w", err)
 	}
@@ -503,37 +796,10 @@ func (c *Client) queryWorkflows(ctx context.Context, query string, args ...any)
 
 	var workflows []WorkflowStat
 	for rows.Next() {
-		var wf WorkflowStat
-		var powState int
-		var startTimeMs, createdTimeMs, updatedTimeMs int64
-		var endTimePtr *int64
-
-		err := rows.Scan(
-			&wf.StatID,
-			&wf.WorkflowName,
-			&powState,
-			&startTimeMs,
-			&endTimePtr,
-			&createdTimeMs,
-			&updatedTimeMs,
-		)
+		wf, err := c.scanWorkflowRow(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan workflow row: %w", err)
 		}
-
-		wf.Status = mapWorkflowState(powState)
-		wf.StartedAt = c.convertEpochMillisToTime(startTimeMs)
-		wf.CreatedAt = c.convertEpochMillisToTime(createdTimeMs)
-		wf.UpdatedAt = c.convertEpochMillisToTime(updatedTimeMs)
-
-		if endTimePtr != nil {
-			endTime := c.convertEpochMillisToTime(*endTimePtr)
-			wf.FinishedAt = &endTime
-			wf.Elapsed = c.calculateElapsed(wf.StartedAt, endTime)
-		} else {
-			wf.Elapsed = c.calculateElapsed(wf.StartedAt, time.Time{})
-		}
-
 		workflows = append(workflows, wf)
 	}
 
@@ -544,6 +810,48 @@ func (c *Client) queryWorkflows(ctx context.Context, query string, args ...any)
 	return workflows, nil
 }
 
+// rowScanner is implemented by *sql.Rows and *sql.Row
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanWorkflowRow reads one row selected with workflowColumns into a
+// WorkflowStat, converting states and epoch-millisecond times. Scan errors,
+// including sql.ErrNoRows, are returned unwrapped.
+func (c *Client) scanWorkflowRow(row rowScanner) (WorkflowStat, error) {
+	var wf WorkflowStat
+	var powState int
+	var startTimeMs, createdTimeMs, updatedTimeMs int64
+	var endTimePtr *int64
+
+	err := row.Scan(
+		&wf.StatID,
+		&wf.WorkflowName,
+		&powState,
+		&startTimeMs,
+		&endTimePtr,
+		&createdTimeMs,
+		&updatedTimeMs,
+	)
+	if err != nil {
+		return WorkflowStat{}, err
+	}
+
+	wf.Status = mapWorkflowState(powState)
+	wf.StartedAt = c.convertEpochMillisToTime(startTimeMs)
+	wf.CreatedAt = c.convertEpochMillisToTime(createdTimeMs)
+	wf.UpdatedAt = c.convertEpochMillisToTime(updatedTimeMs)
+
+	if endTimePtr != nil {
+		endTime := c.convertEpochMillisToTime(*endTimePtr)
+		wf.FinishedAt = &endTime
+		wf.Elapsed = c.calculateElapsed(wf.StartedAt, endTime)
+	} else {
+		wf.Elapsed = c.calculateElapsed(wf.StartedAt, time.Time{})
+	}
+	return wf, nil
+}
+
 func (c *Client) getMockRunningWorkflows() []WorkflowStat {
 	all := c.getMockWorkflowsToday()
 	var running []WorkflowStat
@@ -554,3 +862,72 @@ func (c *Client) getMockRunningWorkflows() []WorkflowStat {
 	}
 	return running
 }
+
+// GetFailedWorkflowsToday returns workflows that started today and failed
+func (c *Client) GetFailedWorkflowsToday(ctx context.Context) ([]WorkflowStat, error) {
+	return c.SearchWorkflows(ctx, "", "FAILED")
+}
+
+// ErrUnknownStatus is returned by SearchWorkflows for a status it cannot filter on
+var ErrUnknownStatus = errors.New("unknown workflow status")
+
+// workflowStates maps the statuses reported by mapWorkflowState back to POW_STATE
+var workflowStates = map[string]int{
+	"RUNNING": 0,
+	"SUCCESS": 1,
+	"FAILED":  3,
+}
+
+// SearchWorkflows returns workflows that started today whose name contains
+// namePattern (case-insensitive) and whose status is status (RUNNING, SUCCESS
+// or FAILED). Empty arguments are not filtered on.
+func (c *Client) SearchWorkflows(ctx context.Context, namePattern, status string) ([]WorkflowStat, error) {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	powState, ok := workflowStates[status]
+	if status != "" && !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownStatus, status)
+	}
+
+	if c.IsMockMode() {
+		return c.getMockSearchWorkflows(namePattern, status), nil
+	}
+
+	query := workflowSelect + `WHERE POW_STARTTIME >= ` + c.todayStartMillis() + `
+`
+	var args []any
+	if namePattern != "" {
+		args = append(args, "%"+c.dialect.escapeLike(namePattern)+"%")
+		query += "AND UPPER(POW_WORKFLOWDEFINITIONNAM) LIKE UPPER(" + c.dialect.placeholder(len(args)) + ") ESCAPE '\\'\n"
+	}
+	if status != "" {
+		args = append(args, powState)
+		query += "AND POW_STATE = " + c.dialect.placeholder(len(args)) + "\n"
+	}
+	query += "ORDER BY POW_STARTTIME DESC\n"
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	workflows, err := c.queryWorkflows(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfoCtx(ctx, "Retrieved %d workflows for today matching name %q and status %q", len(workflows), namePattern, status)
+	return workflows, nil
+}
+
+func (c *Client) getMockSearchWorkflows(namePattern, status string) []WorkflowStat {
+	needle := strings.ToLower(namePattern)
+	var workflows []WorkflowStat
+	for _, wf := range c.getMockWorkflowsToday() {
+		if status != "" && wf.Status != status {
+			continue
+		}
+		if needle != "" && !strings.Contains(strings.ToLower(wf.WorkflowName), needle) {
+			continue
+		}
+		workflows = append(workflows, wf)
+	}
+	return workflows
+}
@@ -0,0 +1,127 @@
+package informatica
+
+import (
+	"testing"
+	"time"
+)
+
+// newTimeClients returns clients for the same US Eastern repository configured
+// with an IANA zone and with the winter hour offset
+func newTimeClients(t *testing.T) (zoned, offset *Client) {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	return &Client{location: loc}, &Client{timeOffset: -5}
+}
+
+// utcMillis returns the epoch milliseconds of a UTC time written 2006-01-02 15:04
+func utcMillis(t *testing.T, s string) int64 {
+	t.Helper()
+	ts, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts.UnixMilli()
+}
+
+func TestConvertEpochMillisToTime(t *testing.T) {
+	zoned, offset := newTimeClients(t)
+
+	// Clocks in New York sprang forward at 07:00 UTC on 2024-03-10
+	tests := []struct {
+		name       string
+		utc        string
+		zoned      string
+		offsetWall string
+	}{
+		{"winter", "2024-01-15 12:00", "2024-01-15 07:00 EST", "2024-01-15 07:00 UTC"},
+		{"before the change", "2024-03-10 06:30", "2024-03-10 01:30 EST", "2024-03-10 01:30 UTC"},
+		{"after the change", "2024-03-10 07:30", "2024-03-10 03:30 EDT", "2024-03-10 02:30 UTC"},
+		{"summer", "2024-07-01 12:00", "2024-07-01 08:00 EDT", "2024-07-01 07:00 UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := utcMillis(t, tt.utc)
+			const layout = "2006-01-02 15:04 MST"
+
+			if got := zoned.convertEpochMillisToTime(ms).Format(layout); got != tt.zoned {
+				t.Errorf("Timezone: got %s, want %s", got, tt.zoned)
+			}
+			// The fixed offset ignores DST, so it lags an hour in summer
+			if got := offset.convertEpochMillisToTime(ms).Format(layout); got != tt.offsetWall {
+				t.Errorf("TimeOffset: got %s, want %s", got, tt.offsetWall)
+			}
+
+			for name, c := range map[string]*Client{"Timezone": zoned, "TimeOffset": offset} {
+				if got := c.convertTimeToEpochMillis(c.convertEpochMillisToTime(ms)); got != ms {
+					t.Errorf("%s: round trip gave %d, want %d", name, got, ms)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertEpochMillisToTimeZero(t *testing.T) {
+	zoned, offset := newTimeClients(t)
+	for name, c := range map[string]*Client{"Timezone": zoned, "TimeOffset": offset} {
+		if got := c.convertEpochMillisToTime(0); !got.IsZero() {
+			t.Errorf("%s: got %v for a zero epoch, want the zero time", name, got)
+		}
+	}
+}
+
+func TestConvertTimeToEpochMillis(t *testing.T) {
+	zoned, offset := newTimeClients(t)
+
+	// Wall-clock times as GetWorkflowsBetween callers build them in Location
+	tests := []struct {
+		name      string
+		wall      string
+		zonedUTC  string
+		offsetUTC string
+	}{
+		{"winter", "2024-01-15 07:00", "2024-01-15 12:00", "2024-01-15 12:00"},
+		{"before the change", "2024-03-10 01:30", "2024-03-10 06:30", "2024-03-10 06:30"},
+		{"after the change", "2024-03-10 03:30", "2024-03-10 07:30", "2024-03-10 08:30"},
+		{"summer", "2024-07-01 08:00", "2024-07-01 12:00", "2024-07-01 13:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, c := range map[string]struct {
+				client *Client
+				want   string
+			}{
+				"Timezone":   {zoned, tt.zonedUTC},
+				"TimeOffset": {offset, tt.offsetUTC},
+			} {
+				wall, err := time.ParseInLocation("2006-01-02 15:04", tt.wall, c.client.Location())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := c.client.convertTimeToEpochMillis(wall); got != utcMillis(t, c.want) {
+					t.Errorf("%s: got %s UTC, want %s UTC", name, time.UnixMilli(got).UTC().Format("2006-01-02 15:04"), c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLocation(t *testing.T) {
+	zoned, offset := newTimeClients(t)
+	if got := zoned.Location().String(); got != "America/New_York" {
+		t.Errorf("Timezone client: got Location %s, want America/New_York", got)
+	}
+	if got := offset.Location(); got != time.UTC {
+		t.Errorf("TimeOffset client: got Location %s, want UTC", got)
+	}
+}
+
+func TestNewClientRejectsUnknownTimezone(t *testing.T) {
+	if _, err := NewClient(DatabaseConfig{Timezone: "Mars/Olympus_Mons"}); err == nil {
+		t.Error("expected an error for an unknown timezone")
+	}
+}
@@ -0,0 +1,94 @@
+package informatica
+
+import (
+	"fmt"
+	"strings"
+
+	go_ora "github.com/sijms/go-ora/v2"
+)
+
+// Supported repository database types for DatabaseConfig.DBType
+const (
+	DBTypeSQLServer = "sqlserver"
+	DBTypeOracle    = "oracle"
+)
+
+// dialect holds what differs between repository backends. The PO_* tables,
+// their epoch-millis columns and the state codes are the same on both.
+type dialect interface {
+	// driverName is the database/sql driver to open
+	driverName() string
+	// dsn builds the connection string for the configuration
+	dsn(config DatabaseConfig) string
+	// startOfTodayMillis is a SQL expression for midnight today in epoch milliseconds
+	startOfTodayMillis() string
+	// placeholder returns the bind parameter for the nth (1-based) query argument
+	placeholder(n int) string
+	// missingObject reports whether err means a table or column does not exist
+	missingObject(err error) bool
+	// escapeLike escapes s for a LIKE pattern with ESCAPE '\' so it matches literally
+	escapeLike(s string) string
+}
+
+// dialectFor returns the dialect for a DBType, defaulting to SQL Server
+func dialectFor(dbType string) (dialect, error) {
+	switch strings.ToLower(dbType) {
+	case "", DBTypeSQLServer:
+		return sqlServerDialect{}, nil
+	case DBTypeOracle:
+		return oracleDialect{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported Informatica database type %q", dbType)
+	}
+}
+
+type sqlServerDialect struct{}
+
+func (sqlServerDialect) driverName() string { return "sqlserver" }
+
+func (sqlServerDialect) dsn(config DatabaseConfig) string {
+	return fmt.Sprintf("server=%s;port=%d;database=%s;user id=%s;password=%s;encrypt=disable",
+		config.Host, config.Port, config.Database, config.Username, config.Password)
+}
+
+func (sqlServerDialect) startOfTodayMillis() string {
+	return "DATEDIFF(SECOND, '1970-01-01', CAST(GETDATE() AS DATE)) * 1000"
+}
+
+func (sqlServerDialect) placeholder(n int) string { return fmt.Sprintf("@p%d", n) }
+
+func (sqlServerDialect) missingObject(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "Invalid object name") || strings.Contains(msg, "Invalid column name")
+}
+
+// escapeLike also escapes [, which opens a character class in T-SQL LIKE
+func (sqlServerDialect) escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`, `[`, `\[`).Replace(s)
+}
+
+// oracleDialect connects with go-ora; Database is the Oracle service name
+type oracleDialect struct{}
+
+func (oracleDialect) driverName() string { return "oracle" }
+
+func (oracleDialect) dsn(config DatabaseConfig) string {
+	return go_ora.BuildUrl(config.Host, config.Port, config.Database, config.Username, config.Password, nil)
+}
+
+func (oracleDialect) startOfTodayMillis() string {
+	return "(TRUNC(SYSDATE) - DATE '1970-01-01') * 86400000"
+}
+
+func (oracleDialect) placeholder(n int) string { return fmt.Sprintf(":%d", n) }
+
+func (oracleDialect) missingObject(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "ORA-00942") || strings.Contains(msg, "ORA-00904")
+}
+
+// escapeLike leaves [ alone: Oracle only allows the escape character before
+// %, _ or itself and fails anything else with ORA-01424
+func (oracleDialect) escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,32 @@
+package informatica
+
+import "testing"
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect dialect
+		in      string
+		want    string
+	}{
+		{"sqlserver plain", sqlServerDialect{}, "BRM_LOAD", `BRM\_LOAD`},
+		{"sqlserver percent", sqlServerDialect{}, "100%", `100\%`},
+		{"sqlserver escape char", sqlServerDialect{}, `a\b`, `a\\b`},
+		{"sqlserver bracket", sqlServerDialect{}, "[wf]", `\[wf]`},
+		{"sqlserver empty", sqlServerDialect{}, "", ""},
+		{"oracle plain", oracleDialect{}, "BRM_LOAD", `BRM\_LOAD`},
+		{"oracle percent", oracleDialect{}, "100%", `100\%`},
+		{"oracle escape char", oracleDialect{}, `a\b`, `a\\b`},
+		// Escaping [ would fail the query with ORA-01424
+		{"oracle bracket", oracleDialect{}, "[wf]", "[wf]"},
+		{"oracle empty", oracleDialect{}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dialect.escapeLike(tt.in); got != tt.want {
+				t.Errorf("escapeLike(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package informatica
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// reconnectInterval is how often a client in mock mode retries the real database
+const reconnectInterval = 30 * time.Second
+
+// pingTimeout bounds the liveness check ensureConnection makes before a query
+const pingTimeout = 2 * time.Second
+
+// ensureConnection returns a live connection, pinging the current one first.
+// If the ping fails the connection is reopened once; a second failure is returned
+// to the caller rather than retried.
+func (c *Client) ensureConnection(ctx context.Context) (*sql.DB, error) {
+	db := c.database()
+	if db == nil {
+		return nil, fmt.Errorf("informatica database not connected")
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	err := db.PingContext(pingCtx)
+	cancel()
+	if err == nil {
+		return db, nil
+	}
+
+	logger.LogErrorCtx(ctx, "Informatica database ping failed, reconnecting", err)
+	fresh, err := c.openDatabase()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconnect to Informatica database: %w", err)
+	}
+
+	c.mu.Lock()
+	if c.db != db {
+		// Another caller reconnected while we were dialing; keep its connection
+		current := c.db
+		c.mu.Unlock()
+		fresh.Close()
+		return current, nil
+	}
+	c.db = fresh
+	c.mu.Unlock()
+
+	db.Close()
+	logger.InfoCtx(ctx, "Reconnected to Informatica database")
+	return fresh, nil
+}
+
+// reconnectLoop retries the real database every reconnectInterval while the
+// client is in mock mode and stops once Ping succeeds or the client is closed.
+func (c *Client) reconnectLoop() {
+	ticker := time.NewTicker(reconnectInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			err := c.Ping(ctx)
+			cancel()
+			if err == nil {
+				return
+			}
+			logger.LogError("Informatica database still unreachable, staying in mock mode", err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package informatica
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// maxSessionLogRows bounds how many error rows GetTaskSessionLog returns
+const maxSessionLogRows = 200
+
+// ErrLogUnavailable is returned when a task has no session log rows, or the
+// repository does not expose the session tables at all
+var ErrLogUnavailable = errors.New("log unavailable")
+
+// GetTaskSessionLog returns the session error summary recorded for a task of a
+// workflow run, one line per row, oldest first
+func (c *Client) GetTaskSessionLog(ctx context.Context, parentStatID int64, taskName string) (string, error) {
+	if c.IsMockMode() {
+		return c.getMockTaskSessionLog(parentStatID, taskName)
+	}
+
+	db, err := c.ensureConnection(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	query := `
+SELECT
+POS_ERRORTIME,
+POS_ERRORCODE,
+POS_ERRORMSG
+FROM PO_SESSIONERRORSTAT
+WHERE POS_PARENTSTATID = ` + c.dialect.placeholder(1) + `
+AND POS_TASKNAME = ` + c.dialect.placeholder(2) + `
+ORDER BY POS_ERRORTIME
+`
+	rows, err := db.QueryContext(ctx, query, parentStatID, taskName)
+	if err != nil {
+		if c.dialect.missingObject(err) {
+			logger.InfoCtx(ctx, "Session log tables not available in this repository: %v", err)
+			return "", ErrLogUnavailable
+		}
+		return "", fmt.Errorf("failed to get session log: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []string
+	for rows.Next() && len(lines) < maxSessionLogRows {
+		var errorTimeMs int64
+		var code, message string
+		if err := rows.Scan(&errorTimeMs, &code, &message); err != nil {
+			return "", fmt.Errorf("failed to scan session log row: %w", err)
+		}
+		lines = append(lines, fmt.Sprintf("%s [%s] %s",
+			c.convertEpochMillisToTime(errorTimeMs).Format("2006-01-02 15:04:05"), code, message))
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("error iterating session log rows: %w", err)
+	}
+
+	if len(lines) == 0 {
+		return "", ErrLogUnavailable
+	}
+	logger.InfoCtx(ctx, "Retrieved %d session log lines for task %s of stat_id %d", len(lines), taskName, parentStatID)
+	return strings.Join(lines, "\n"), nil
+}
+
+func (c *Client) getMockTaskSessionLog(parentStatID int64, taskName string) (string, error) {
+	wt := c.getMockWorkflowWithTasks(parentStatID)
+	if wt == nil {
+		return "", ErrLogUnavailable
+	}
+	for _, task := range wt.Tasks {
+		if task.TaskName != taskName {
+			continue
+		}
+		ts := task.StartedAt.Format("2006-01-02 15:04:05")
+		return fmt.Sprintf("%s [TM_6014] Initializing session [%s]\n"+
+			"%s [WRT_8229] Database errors occurred: ORA-00001: unique constraint violated\n"+
+			"%s [TM_6020] Session [%s] completed with errors", ts, taskName, ts, ts, taskName), nil
+	}
+	return "", ErrLogUnavailable
+}
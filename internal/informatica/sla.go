@@ -0,0 +1,20 @@
+package informatica
+
+// AnnotateSLA sets SLATarget on each workflow with a configured SLA and flags
+// SLABreached when its elapsed time exceeds the target. Running workflows are
+// measured up to now, so an overrun is flagged before the run finishes.
+func (c *Client) AnnotateSLA(workflows []WorkflowStat) {
+	if len(c.config.WorkflowSLAs) == 0 {
+		return
+	}
+	for i := range workflows {
+		wf := &workflows[i]
+		target, ok := c.config.WorkflowSLAs[wf.WorkflowName]
+		if !ok {
+			continue
+		}
+		elapsed := elapsedTimeOf(target)
+		wf.SLATarget = &elapsed
+		wf.SLABreached = wf.Elapsed.Duration() > target
+	}
+}
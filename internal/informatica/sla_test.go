@@ -0,0 +1,54 @@
+package informatica
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnnotateSLA(t *testing.T) {
+	zoned, offset := newTimeClients(t)
+	offset.timeOffset = 3 // the INFORMATICA_TIME_OFFSET default
+
+	for name, c := range map[string]*Client{"Timezone": zoned, "TimeOffset": offset} {
+		t.Run(name, func(t *testing.T) {
+			c.config.WorkflowSLAs = map[string]time.Duration{"LOAD": 90 * time.Minute}
+
+			// workflow builds a stat the way rows are read from the repository
+			workflow := func(started time.Duration, finished *time.Duration) WorkflowStat {
+				wf := WorkflowStat{
+					WorkflowName: "LOAD",
+					StartedAt:    c.convertEpochMillisToTime(time.Now().Add(-started).UnixMilli()),
+				}
+				end := time.Time{}
+				if finished != nil {
+					end = c.convertEpochMillisToTime(time.Now().Add(-*finished).UnixMilli())
+					wf.FinishedAt = &end
+				}
+				wf.Elapsed = c.calculateElapsed(wf.StartedAt, end)
+				return wf
+			}
+			tenMinutesAgo := 10 * time.Minute
+
+			workflows := []WorkflowStat{
+				workflow(time.Hour, nil),                    // running within its SLA
+				workflow(2*time.Hour, nil),                  // running past its SLA
+				workflow(3*time.Hour, &tenMinutesAgo),       // finished late
+				{WorkflowName: "OTHER", StartedAt: c.now()}, // no SLA configured
+			}
+			c.AnnotateSLA(workflows)
+
+			for i, want := range []bool{false, true, true, false} {
+				if got := workflows[i].SLABreached; got != want {
+					t.Errorf("workflow %d: got SLABreached=%v (elapsed %v), want %v",
+						i, got, workflows[i].Elapsed.Duration(), want)
+				}
+			}
+			if workflows[0].SLATarget == nil || workflows[0].SLATarget.Duration() != 90*time.Minute {
+				t.Errorf("got SLATarget %v, want 90m", workflows[0].SLATarget)
+			}
+			if workflows[3].SLATarget != nil {
+				t.Error("workflow without an SLA got a target")
+			}
+		})
+	}
+}
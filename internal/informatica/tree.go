@@ -0,0 +1,134 @@
+package informatica
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// maxTreeDepth bounds how many levels of child workflows GetWorkflowTree follows
+const maxTreeDepth = 10
+
+// WorkflowNode is a workflow run with its tasks and the child workflows
+// (worklets, nested workflows) that reference it through POW_PARENTSTATID
+type WorkflowNode struct {
+	Workflow WorkflowStat    `json:"workflow"`
+	Tasks    []TaskStat      `json:"tasks"`
+	Children []*WorkflowNode `json:"children"`
+
+	// Truncated is set when the node has children that were not loaded
+	// because maxTreeDepth was reached
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// GetWorkflowTree loads a workflow run, its tasks and, recursively, its child
+// workflows. A child already present in the tree is skipped so a cyclic parent
+// reference cannot recurse forever.
+func (c *Client) GetWorkflowTree(ctx context.Context, statID int64) (*WorkflowNode, error) {
+	visited := make(map[int64]bool)
+	root, err := c.loadWorkflowNode(ctx, statID, 0, visited)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfoCtx(ctx, "Built workflow tree for stat_id %d with %d workflows", statID, len(visited))
+	return root, nil
+}
+
+// loadWorkflowNode loads one node of the tree and descends into its children
+func (c *Client) loadWorkflowNode(ctx context.Context, statID int64, depth int, visited map[int64]bool) (*WorkflowNode, error) {
+	visited[statID] = true
+
+	wt, err := c.GetWorkflowWithTasks(ctx, statID)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &WorkflowNode{
+		Workflow: wt.Workflow,
+		Tasks:    wt.Tasks,
+		Children: []*WorkflowNode{},
+	}
+	if node.Tasks == nil {
+		node.Tasks = []TaskStat{}
+	}
+
+	childIDs, err := c.childWorkflowIDs(ctx, statID)
+	if err != nil {
+		return nil, err
+	}
+	if len(childIDs) > 0 && depth >= maxTreeDepth {
+		logger.InfoCtx(ctx, "Workflow tree truncated at stat_id %d after %d levels", statID, maxTreeDepth)
+		node.Truncated = true
+		return node, nil
+	}
+
+	for _, childID := range childIDs {
+		if visited[childID] {
+			logger.InfoCtx(ctx, "Skipping workflow stat_id %d already in the tree under %d", childID, statID)
+			continue
+		}
+		child, err := c.loadWorkflowNode(ctx, childID, depth+1, visited)
+		if errors.Is(err, ErrWorkflowNotFound) {
+			// The child row was purged between the two queries
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, child)
+	}
+	return node, nil
+}
+
+// mockChildWorkflows links the mock workflows into a small hierarchy
+var mockChildWorkflows = map[int64][]int64{
+	1001: {1003},
+}
+
+// childWorkflowIDs returns the stat IDs of the workflows whose parent is statID.
+// Repositories without POW_PARENTSTATID have no hierarchy, so none are returned.
+func (c *Client) childWorkflowIDs(ctx context.Context, statID int64) ([]int64, error) {
+	if c.IsMockMode() {
+		return mockChildWorkflows[statID], nil
+	}
+
+	db, err := c.ensureConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, `
+SELECT POW_STATID
+FROM PO_WORKFLOWSTAT
+WHERE POW_PARENTSTATID = `+c.dialect.placeholder(1)+`
+ORDER BY POW_STARTTIME
+`, statID)
+	if err != nil {
+		if strings.Contains(strings.ToUpper(err.Error()), "POW_PARENTSTATID") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get child workflows: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan child workflow row: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating child workflow rows: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// requestIDKey is the context key for the current request ID
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the given request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID stored in ctx, or "" if there is none
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// InfoCtx logs an info message tagged with the request ID from ctx
+func InfoCtx(ctx context.Context, format string, args ...interface{}) {
+	emitCtx(ctx, "info", fmt.Sprintf(format, args...), nil)
+}
+
+// ErrorCtx logs an error message tagged with the request ID from ctx
+func ErrorCtx(ctx context.Context, format string, args ...interface{}) {
+	emitCtx(ctx, "error", fmt.Sprintf(format, args...), nil)
+}
+
+// LogErrorCtx logs an error with context, tagged with the request ID from ctx
+func LogErrorCtx(ctx context.Context, context string, err error) {
+	ErrorCtx(ctx, "%s: %v", context, err)
+}
+
+// emitCtx prefixes text lines with the request ID and adds it as a field for JSON output.
+// It calls the sink directly, like emit, so text output reports the right caller.
+func emitCtx(ctx context.Context, level, msg string, fields Fields) {
+	if !enabled(level) {
+		return
+	}
+	if id := RequestID(ctx); id != "" {
+		if _, isJSON := output.(*jsonSink); isJSON {
+			tagged := Fields{"request_id": id}
+			for key, value := range fields {
+				tagged[key] = value
+			}
+			fields = tagged
+		} else {
+			msg = fmt.Sprintf("[req=%s] %s", id, msg)
+		}
+	}
+
+	if output == nil {
+		log.Printf("[%s] %s%s", strings.ToUpper(level), msg, formatFields(fields))
+		return
+	}
+	output.write(level, msg, fields)
+}
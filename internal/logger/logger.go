@@ -1,80 +1,151 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"time"
+
+	"salam-monitoring/internal/config"
 )
 
+// Fields are structured key/value pairs attached to a log line
+type Fields map[string]interface{}
+
 var (
 	InfoLogger  *log.Logger
 	ErrorLogger *log.Logger
 	logFile     *os.File
+	output      sink
+
+	// infoDisabled drops info lines when the level is warn or error
+	infoDisabled atomic.Bool
 )
 
-// InitLogger sets up the logging system
-func InitLogger() error {
-	today := time.Now().Format("2006-01-02")
-	logDir := filepath.Join(os.Getenv("HOME"), "nfs_backup", "monitoring", "monitoring_util", today)
-	
+// SetLevel applies a configured log level. Only info and error lines exist, so
+// "warn" and "error" suppress info lines and anything else enables them.
+func SetLevel(level string) {
+	switch strings.ToLower(level) {
+	case "warn", "warning", "error":
+		infoDisabled.Store(true)
+	default:
+		infoDisabled.Store(false)
+	}
+}
+
+// enabled reports whether lines of a level are currently written
+func enabled(level string) bool {
+	return level != "info" || !infoDisabled.Load()
+}
+
+// InitLogger sets up the logging system. Log lines always go to stdout and, when
+// cfg.FileLog is set, to <cfg.FilePath>/<date>/info.log as well. If the log file
+// cannot be created the logger falls back to console-only output. cfg.JSONLog
+// switches every line to a JSON object.
+func InitLogger(cfg config.LoggingConfig) {
+	writer, logPath, err := openOutput(cfg)
+
+	InfoLogger = log.New(writer, "[INFO] ", log.LstdFlags|log.Lshortfile)
+	ErrorLogger = log.New(writer, "[ERROR] ", log.LstdFlags|log.Lshortfile)
+	if cfg.JSONLog {
+		output = newJSONSink(writer)
+	} else {
+		output = textSink{}
+	}
+	SetLevel(cfg.Level)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v - logging to console only\n", err)
+	}
+	switch {
+	case logPath != "":
+		Info("Logger initialized - log file: %s", logPath)
+	case !cfg.FileLog:
+		Info("Logger initialized - file logging disabled")
+	}
+}
+
+// openOutput returns the writer for log lines and the log file path, if any
+func openOutput(cfg config.LoggingConfig) (io.Writer, string, error) {
+	if !cfg.FileLog {
+		return os.Stdout, "", nil
+	}
+
+	dir := cfg.FilePath
+	if dir == "" {
+		dir = "./logs"
+	}
+	logDir := filepath.Join(dir, time.Now().Format("2006-01-02"))
+
 	// Create log directory if it doesn't exist
 	if err := os.MkdirAll(logDir, 0755); err != nil {
-		return fmt.Errorf("failed to create log directory %s: %v", logDir, err)
+		return os.Stdout, "", fmt.Errorf("failed to create log directory %s: %w", logDir, err)
 	}
-	
+
 	logPath := filepath.Join(logDir, "info.log")
-	
+
 	// Open log file in append mode
-	var err error
-	logFile, err = os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	file, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err != nil {
-		return fmt.Errorf("failed to open log file %s: %v", logPath, err)
+		return os.Stdout, "", fmt.Errorf("failed to open log file %s: %w", logPath, err)
 	}
-	
-	// Create multi-writer for both file and console
-	multiWriter := io.MultiWriter(os.Stdout, logFile)
-	
-	// Create loggers with timestamps
-	InfoLogger = log.New(multiWriter, "[INFO] ", log.LstdFlags|log.Lshortfile)
-	ErrorLogger = log.New(multiWriter, "[ERROR] ", log.LstdFlags|log.Lshortfile)
-	
-	InfoLogger.Printf("Logger initialized - log file: %s", logPath)
-	return nil
+	logFile = file
+
+	// Write to both file and console
+	return io.MultiWriter(os.Stdout, logFile), logPath, nil
 }
 
 // CloseLogger closes the log file
 func CloseLogger() {
 	if logFile != nil {
-		InfoLogger.Println("Closing logger")
+		Info("Closing logger")
 		logFile.Close()
 	}
 }
 
 // Info logs an info message
 func Info(format string, args ...interface{}) {
-	if InfoLogger != nil {
-		InfoLogger.Printf(format, args...)
-	} else {
-		log.Printf("[INFO] "+format, args...)
-	}
+	emit("info", fmt.Sprintf(format, args...), nil)
 }
 
 // Error logs an error message
 func Error(format string, args ...interface{}) {
-	if ErrorLogger != nil {
-		ErrorLogger.Printf(format, args...)
-	} else {
-		log.Printf("[ERROR] "+format, args...)
+	emit("error", fmt.Sprintf(format, args...), nil)
+}
+
+// InfoFields logs an info message with structured fields
+func InfoFields(msg string, fields Fields) {
+	emit("info", msg, fields)
+}
+
+// emit routes a log line to the configured sink, or the standard logger before InitLogger runs
+func emit(level, msg string, fields Fields) {
+	if !enabled(level) {
+		return
 	}
+	if output == nil {
+		log.Printf("[%s] %s%s", strings.ToUpper(level), msg, formatFields(fields))
+		return
+	}
+	output.write(level, msg, fields)
 }
 
 // LogRequest logs HTTP request details
-func LogRequest(method, path, remoteAddr string, status int, duration time.Duration) {
-	Info("HTTP %s %s from %s - Status: %d, Duration: %v", method, path, remoteAddr, status, duration)
-	}
+func LogRequest(ctx context.Context, method, path, remoteAddr string, status int, bytes int64, duration time.Duration) {
+	emitCtx(ctx, "info", fmt.Sprintf("HTTP %s %s", method, path), Fields{
+		"method":      method,
+		"path":        path,
+		"remote_addr": remoteAddr,
+		"status":      status,
+		"bytes":       bytes,
+		"duration_ms": float64(duration.Microseconds()) / 1000,
+	})
+}
 
 // LogError logs an error with context
 func LogError(context string, err error) {
@@ -84,4 +155,4 @@ func LogError(context string, err error) {
 // LogPanic logs a panic with context
 func LogPanic(context string, recovered interface{}) {
 	Error("PANIC in %s: %v", context, recovered)
-}
\ No newline at end of file
+}
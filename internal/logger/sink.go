@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sink formats and writes a single log line
+type sink interface {
+	write(level, msg string, fields Fields)
+}
+
+// textSink writes human-readable lines through InfoLogger and ErrorLogger,
+// appending any fields as key=value pairs
+type textSink struct{}
+
+func (textSink) write(level, msg string, fields Fields) {
+	logger := InfoLogger
+	if level == "error" {
+		logger = ErrorLogger
+	}
+	// Skip emit and the exported helper so Lshortfile points at the caller's package
+	logger.Output(4, msg+formatFields(fields))
+}
+
+// jsonSink writes one JSON object per line for log shippers such as Filebeat
+type jsonSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// jsonLine is the shape of a JSON log line
+type jsonLine struct {
+	TS     string `json:"ts"`
+	Level  string `json:"level"`
+	Msg    string `json:"msg"`
+	Fields Fields `json:"fields,omitempty"`
+}
+
+func newJSONSink(w io.Writer) *jsonSink {
+	return &jsonSink{w: w}
+}
+
+func (s *jsonSink) write(level, msg string, fields Fields) {
+	line, err := json.Marshal(jsonLine{
+		TS:     time.Now().Format(time.RFC3339Nano),
+		Level:  level,
+		Msg:    msg,
+		Fields: fields,
+	})
+	if err != nil {
+		line, _ = json.Marshal(jsonLine{TS: time.Now().Format(time.RFC3339Nano), Level: "error", Msg: fmt.Sprintf("unencodable log line %q: %v", msg, err)})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.w.Write(append(line, '\n'))
+}
+
+// formatFields renders fields as sorted " key=value" pairs for text output
+func formatFields(fields Fields) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%v", key, fields[key])
+	}
+	return b.String()
+}
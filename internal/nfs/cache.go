@@ -0,0 +1,106 @@
+package nfs
+
+import (
+	"sync"
+	"time"
+)
+
+// Default cache lifetimes: today's logs change constantly, past dates rarely do
+const (
+	DefaultTodayCacheTTL = 30 * time.Second
+	DefaultPastCacheTTL  = 10 * time.Minute
+)
+
+// maxCacheEntries bounds how many dates are cached at once; storing another
+// evicts the least recently scanned
+const maxCacheEntries = 32
+
+// scanCache holds scan results keyed by YYYY-MM-DD date
+type scanCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// cacheEntry is a cached scan result and the time it was produced
+type cacheEntry struct {
+	summaries []*WorkflowSummary
+	scannedAt time.Time
+}
+
+// WithCacheTTL sets how long scan results are reused for today and for past dates.
+// A TTL of zero or less disables caching for that kind of date.
+func WithCacheTTL(today, past time.Duration) ScannerOption {
+	return func(s *Scanner) {
+		s.todayCacheTTL = today
+		s.pastCacheTTL = past
+	}
+}
+
+// cacheTTL returns the TTL that applies to a date
+func (s *Scanner) cacheTTL(date string) time.Duration {
+	if date == s.Today() {
+		return s.todayCacheTTL
+	}
+	return s.pastCacheTTL
+}
+
+// cachedScan returns cached summaries for a date if they are still fresh
+func (s *Scanner) cachedScan(date string) ([]*WorkflowSummary, bool) {
+	ttl := s.cacheTTL(date)
+	if ttl <= 0 {
+		return nil, false
+	}
+
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+
+	entry, ok := s.cache.entries[date]
+	if !ok || time.Since(entry.scannedAt) > ttl {
+		return nil, false
+	}
+	return entry.summaries, true
+}
+
+// storeScan caches the summaries for a date
+func (s *Scanner) storeScan(date string, summaries []*WorkflowSummary) {
+	if s.cacheTTL(date) <= 0 {
+		return
+	}
+
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+
+	if s.cache.entries == nil {
+		s.cache.entries = make(map[string]cacheEntry)
+	}
+	s.evictLocked(date)
+	s.cache.entries[date] = cacheEntry{summaries: summaries, scannedAt: time.Now()}
+}
+
+// evictLocked drops expired entries, then the oldest ones until there is room
+// to store date. The caller must hold s.cache.mu.
+func (s *Scanner) evictLocked(date string) {
+	for cached, entry := range s.cache.entries {
+		if time.Since(entry.scannedAt) > s.cacheTTL(cached) {
+			delete(s.cache.entries, cached)
+		}
+	}
+
+	delete(s.cache.entries, date)
+	for len(s.cache.entries) >= maxCacheEntries {
+		var oldest string
+		for cached, entry := range s.cache.entries {
+			if oldest == "" || entry.scannedAt.Before(s.cache.entries[oldest].scannedAt) {
+				oldest = cached
+			}
+		}
+		delete(s.cache.entries, oldest)
+	}
+}
+
+// InvalidateCache drops any cached scan for the given date so the next scan hits the disk
+func (s *Scanner) InvalidateCache(date string) {
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+	delete(s.cache.entries, date)
+}
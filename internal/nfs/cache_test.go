@@ -0,0 +1,160 @@
+package nfs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testDate is a past date, so scans of it use the past-date cache TTL
+const testDate = "2024-11-21"
+
+// writeLog creates root/source/date/workflow/name with the given content
+func writeLog(t testing.TB, root, source, date, workflow, name, content string) {
+	t.Helper()
+	dir := filepath.Join(root, source, date, workflow)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newCacheTestScanner returns a scanner over a small tree with two workflows
+func newCacheTestScanner(t *testing.T, ttl time.Duration) *Scanner {
+	t.Helper()
+	root := t.TempDir()
+	writeLog(t, root, "src1", testDate, "wf1", "info.log", "2024-11-21 10:30:00 INFO: Starting workflow\n")
+	writeLog(t, root, "src1", testDate, "wf2", "run.log", "2024-11-21 10:31:00 ERROR: Load failed\n")
+	return NewScanner(root, WithCacheTTL(ttl, ttl))
+}
+
+// scanStats scans testDate and returns the scan's stats
+func scanStats(t *testing.T, s *Scanner) ScanStats {
+	t.Helper()
+	summaries, stats, err := s.ScanLogsForDateWithStats(context.Background(), testDate)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("got %d workflows, want 2", len(summaries))
+	}
+	return stats
+}
+
+// assertFromDisk fails unless stats come from a scan that read the log files
+func assertFromDisk(t *testing.T, stats ScanStats, when string) {
+	t.Helper()
+	if stats.Cached || stats.FilesOpened != 2 {
+		t.Errorf("%s: got Cached=%v FilesOpened=%d, want a disk scan opening 2 files", when, stats.Cached, stats.FilesOpened)
+	}
+}
+
+func TestScanCacheServesRepeatScans(t *testing.T) {
+	s := newCacheTestScanner(t, time.Minute)
+
+	assertFromDisk(t, scanStats(t, s), "first scan")
+
+	second := scanStats(t, s)
+	if !second.Cached || second.FilesOpened != 0 || second.BytesRead != 0 {
+		t.Errorf("second scan: got Cached=%v FilesOpened=%d BytesRead=%d, want a cached result without I/O",
+			second.Cached, second.FilesOpened, second.BytesRead)
+	}
+}
+
+func TestScanCacheExpires(t *testing.T) {
+	s := newCacheTestScanner(t, 50*time.Millisecond)
+
+	assertFromDisk(t, scanStats(t, s), "first scan")
+	time.Sleep(100 * time.Millisecond)
+	assertFromDisk(t, scanStats(t, s), "scan after TTL")
+}
+
+func TestScanCacheInvalidate(t *testing.T) {
+	s := newCacheTestScanner(t, time.Minute)
+
+	assertFromDisk(t, scanStats(t, s), "first scan")
+	s.InvalidateCache(testDate)
+	assertFromDisk(t, scanStats(t, s), "scan after InvalidateCache")
+}
+
+func TestScanCacheDisabled(t *testing.T) {
+	s := newCacheTestScanner(t, 0)
+
+	assertFromDisk(t, scanStats(t, s), "first scan")
+	assertFromDisk(t, scanStats(t, s), "second scan")
+}
+
+func TestNormalizeDate(t *testing.T) {
+	today := time.Date(2024, 11, 21, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"2024-11-21", "2024-11-21", true},
+		{"2024-1-5", "2024-01-05", true},
+		{" 2024-11-20 ", "2024-11-20", true},
+		{"2024-11-22", "2024-11-22", true}, // a day ahead allows for timezone skew
+		{"2024-11-23", "", false},
+		{"2014-11-20", "", false},
+		{"2024-02-30", "", false},
+		{"21/11/2024", "", false},
+		{"2024-11-21/../x", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeDate(tt.in, today)
+		if tt.ok && (err != nil || got != tt.want) {
+			t.Errorf("NormalizeDate(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+		if !tt.ok && !errors.Is(err, ErrInvalidDate) {
+			t.Errorf("NormalizeDate(%q) = %q, %v; want ErrInvalidDate", tt.in, got, err)
+		}
+	}
+}
+
+func TestScanCacheKeyIsNormalized(t *testing.T) {
+	s := newCacheTestScanner(t, time.Minute)
+
+	assertFromDisk(t, scanStats(t, s), "first scan")
+	_, stats, err := s.ScanLogsForDateWithStats(context.Background(), " 2024-11-21")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stats.Cached {
+		t.Error("an unnormalized spelling of a cached date missed the cache")
+	}
+}
+
+func TestScanCacheEvictsOldest(t *testing.T) {
+	s := newCacheTestScanner(t, time.Minute)
+
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i <= maxCacheEntries; i++ {
+		s.storeScan(first.AddDate(0, 0, i).Format(dateLayout), nil)
+	}
+
+	if len(s.cache.entries) != maxCacheEntries {
+		t.Errorf("got %d cache entries, want %d", len(s.cache.entries), maxCacheEntries)
+	}
+	if _, ok := s.cache.entries[first.Format(dateLayout)]; ok {
+		t.Error("the oldest entry was not evicted")
+	}
+}
+
+func TestScanCacheEvictsExpired(t *testing.T) {
+	s := newCacheTestScanner(t, 50*time.Millisecond)
+
+	s.storeScan("2024-01-01", nil)
+	time.Sleep(100 * time.Millisecond)
+	s.storeScan("2024-01-02", nil)
+
+	if _, ok := s.cache.entries["2024-01-01"]; ok {
+		t.Error("an expired entry survived storing another date")
+	}
+}
@@ -0,0 +1,92 @@
+package nfs
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ErrOutsideRoot is returned for log paths that do not lie under the NFS root
+var ErrOutsideRoot = errors.New("path is outside the NFS root")
+
+// Line severities reported by LineSeverity
+const (
+	SeverityError = "error"
+	SeverityWarn  = "warn"
+	SeverityInfo  = "info"
+)
+
+// infoPattern matches INFO severity markers
+var infoPattern = regexp.MustCompile(`\bINFO\b`)
+
+// LineSeverity classifies a log line the way the scanner does: the configured
+// error patterns first, then WARN/WARNING, then INFO. Lines with none of these
+// give "".
+func (s *Scanner) LineSeverity(line string) string {
+	switch {
+	case s.matchesError(line):
+		return SeverityError
+	case warningPattern.MatchString(line):
+		return SeverityWarn
+	case infoPattern.MatchString(line):
+		return SeverityInfo
+	default:
+		return ""
+	}
+}
+
+// OpenLog opens a log file under the NFS root for reading, decompressing .gz
+// files. Paths that resolve outside the root give ErrOutsideRoot.
+func (s *Scanner) OpenLog(filePath string) (io.ReadCloser, error) {
+	path, err := s.ResolveLogPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return openLogFile(path)
+}
+
+// ResolveWorkflowDir returns the resolved directory holding a workflow's logs
+// for a date, as ResolveLogPath does. Source and workflow names that are not
+// single directory names give ErrInvalidSource.
+func (s *Scanner) ResolveWorkflowDir(source, date, workflow string) (string, error) {
+	if !isDirName(source) || !isDirName(date) || !isDirName(workflow) {
+		return "", ErrInvalidSource
+	}
+	return s.ResolveLogPath(filepath.Join(s.nfsRoot, source, date, workflow))
+}
+
+// ResolveLogPath returns the absolute, symlink-resolved form of a path under the
+// NFS root, or ErrOutsideRoot if it lies elsewhere. When the scanner follows
+// symlinks, a path under the root that reaches its log through a symlinked
+// directory is accepted too.
+func (s *Scanner) ResolveLogPath(filePath string) (string, error) {
+	root, err := filepath.Abs(s.nfsRoot)
+	if err != nil {
+		return "", err
+	}
+	path, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", err
+	}
+	lexicallyInside := isWithin(root, path)
+	// Resolve symlinks where possible so a link can't point out of the root
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	if !isWithin(root, path) && !(s.followSymlinks && lexicallyInside) {
+		return "", ErrOutsideRoot
+	}
+	return path, nil
+}
+
+// isWithin reports whether path is root or lies under it; both must be absolute
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,92 @@
+package nfs
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSource is returned for source names that are not a single directory name
+var ErrInvalidSource = errors.New("invalid source name")
+
+// ErrInvalidDate is returned for dates that aren't a calendar date within the scannable range
+var ErrInvalidDate = errors.New("invalid date")
+
+// dateLayout is the name format of the date directories under each source
+const dateLayout = "2006-01-02"
+
+// maxDateAge bounds how far back a date may be scanned; older requests are
+// typos, and each distinct date would otherwise take its own cache entry
+const maxDateAge = 10 * 365 * 24 * time.Hour
+
+// NormalizeDate parses a date, accepting single-digit months and days, and returns
+// it as YYYY-MM-DD. Dates more than a day after today or older than maxDateAge
+// give ErrInvalidDate, as does anything that doesn't parse.
+func NormalizeDate(date string, today time.Time) (string, error) {
+	parsed, err := time.Parse("2006-1-2", strings.TrimSpace(date))
+	if err != nil {
+		return "", fmt.Errorf("%w %q: expected YYYY-MM-DD", ErrInvalidDate, date)
+	}
+	if parsed.After(today.AddDate(0, 0, 1)) || today.Sub(parsed) > maxDateAge {
+		return "", fmt.Errorf("%w %q: out of range", ErrInvalidDate, date)
+	}
+	return parsed.Format(dateLayout), nil
+}
+
+// normalizeDate is NormalizeDate relative to the scanner's today
+func (s *Scanner) normalizeDate(date string) (string, error) {
+	today, err := time.Parse(dateLayout, s.Today())
+	if err != nil {
+		return "", err
+	}
+	return NormalizeDate(date, today)
+}
+
+// GetAvailableDates lists the dates that have a log directory under source, or
+// under any source when source is empty, newest first. Directories that aren't
+// named YYYY-MM-DD are ignored. A missing source gives an error satisfying
+// errors.Is(err, fs.ErrNotExist).
+func (s *Scanner) GetAvailableDates(source string) ([]string, error) {
+	sources := []string{source}
+	if source == "" {
+		var err error
+		if sources, err = s.getSourceDirectories(); err != nil {
+			return nil, err
+		}
+	} else if !isDirName(source) {
+		return nil, ErrInvalidSource
+	}
+
+	seen := make(map[string]bool)
+	for _, src := range sources {
+		dirs, err := s.listDirectories(filepath.Join(s.nfsRoot, src))
+		if err != nil {
+			if source != "" {
+				return nil, err
+			}
+			// One unreadable source shouldn't hide the others' dates
+			continue
+		}
+		for _, dir := range dirs {
+			if _, err := time.Parse(dateLayout, dir); err == nil {
+				seen[dir] = true
+			}
+		}
+	}
+
+	dates := make([]string, 0, len(seen))
+	for date := range seen {
+		dates = append(dates, date)
+	}
+	// YYYY-MM-DD sorts chronologically as a string
+	sort.Sort(sort.Reverse(sort.StringSlice(dates)))
+	return dates, nil
+}
+
+// isDirName reports whether name is a single, non-empty path element
+func isDirName(name string) bool {
+	return name != "" && name != "." && name != ".." && name == filepath.Base(name)
+}
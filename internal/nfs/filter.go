@@ -0,0 +1,43 @@
+package nfs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LatestModTime returns the newest modification time across the workflow's logs,
+// or the zero time when it has none
+func (w *WorkflowSummary) LatestModTime() time.Time {
+	var latest time.Time
+	for _, log := range w.Logs {
+		if log.ModTime.After(latest) {
+			latest = log.ModTime
+		}
+	}
+	return latest
+}
+
+// ParseSince interprets a since threshold given either as an RFC3339 timestamp
+// or as a duration such as "2h" counted back from now
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid since value %q: expected RFC3339 time or duration like 2h", value)
+}
+
+// FilterModifiedSince keeps workflows whose newest log was modified at or after since
+func FilterModifiedSince(workflows []*WorkflowSummary, since time.Time) []*WorkflowSummary {
+	filtered := make([]*WorkflowSummary, 0, len(workflows))
+	for _, workflow := range workflows {
+		if !workflow.LatestModTime().Before(since) {
+			filtered = append(filtered, workflow)
+		}
+	}
+	return filtered
+}
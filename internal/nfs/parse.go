@@ -0,0 +1,166 @@
+package nfs
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+// DefaultTimestampLayout is the time layout that starts each log entry, as in
+// "2024-11-21 10:30:00 INFO: Starting workflow"
+const DefaultTimestampLayout = "2006-01-02 15:04:05"
+
+// levelRanks are the level markers recognised after an entry's timestamp,
+// ranked from least to most severe
+var levelRanks = map[string]int{
+	"TRACE": 0, "DEBUG": 1, "INFO": 2, "NOTICE": 2,
+	"WARN": 3, "WARNING": 3, "ERROR": 4, "SEVERE": 4, "FATAL": 5,
+}
+
+// ParsedLine is one log entry: a line starting with a timestamp plus any
+// following lines that don't, such as stack traces
+type ParsedLine struct {
+	Timestamp time.Time `json:"timestamp"`       // zero for lines before the first timestamped entry
+	Level     string    `json:"level,omitempty"` // upper-case level marker, empty if the entry has none
+	Message   string    `json:"message"`         // rest of the entry; continuation lines are joined with \n
+	Raw       string    `json:"raw"`             // the entry's lines as written
+}
+
+// LineCount returns how many log lines the entry spans
+func (p ParsedLine) LineCount() int {
+	return strings.Count(p.Raw, "\n") + 1
+}
+
+// LevelRank returns the severity rank of a level name such as warn or ERROR;
+// ok is false for names that are not log levels
+func LevelRank(level string) (rank int, ok bool) {
+	rank, ok = levelRanks[strings.ToUpper(level)]
+	return rank, ok
+}
+
+// LevelSeverity returns the LineSeverity matching a level marker, or "" for
+// levels below INFO and unknown levels
+func LevelSeverity(level string) string {
+	rank, ok := LevelRank(level)
+	switch {
+	case !ok:
+		return ""
+	case rank >= levelRanks["ERROR"]:
+		return SeverityError
+	case rank >= levelRanks["WARN"]:
+		return SeverityWarn
+	case rank >= levelRanks["INFO"]:
+		return SeverityInfo
+	default:
+		return ""
+	}
+}
+
+// EntryLevel returns the entry's level marker or, for entries without one, the
+// level its text implies by LineSeverity: ERROR, WARN, INFO or "".
+func (s *Scanner) EntryLevel(entry ParsedLine) string {
+	if entry.Level != "" {
+		return entry.Level
+	}
+	switch s.LineSeverity(entry.Message) {
+	case SeverityError:
+		return "ERROR"
+	case SeverityWarn:
+		return "WARN"
+	case SeverityInfo:
+		return "INFO"
+	default:
+		return ""
+	}
+}
+
+// WithTimestampLayout sets the time layout that starts each log entry in
+// ParseLogEntries; an empty layout keeps DefaultTimestampLayout. The layout
+// must be fixed-width, since it is matched against the start of each line.
+func WithTimestampLayout(layout string) ScannerOption {
+	return func(s *Scanner) {
+		if layout != "" {
+			s.timestampLayout = layout
+		}
+	}
+}
+
+// ParseLogEntries reads up to max entries (0 for all) from a log file, splitting
+// it at lines that start with the scanner's timestamp layout. Lines that don't
+// are kept as continuations of the previous entry.
+func (s *Scanner) ParseLogEntries(filePath string, max int) ([]ParsedLine, error) {
+	file, err := openLogFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []ParsedLine
+	err = s.ScanLogEntries(file, func(entry ParsedLine) bool {
+		entries = append(entries, entry)
+		return max <= 0 || len(entries) < max
+	})
+	return entries, err
+}
+
+// ScanLogEntries parses entries from r like ParseLogEntries, calling fn with
+// each complete entry until fn returns false or the input ends. Entries are
+// not kept, so large logs can be filtered in constant memory.
+func (s *Scanner) ScanLogEntries(r io.Reader, fn func(ParsedLine) bool) error {
+	var (
+		current ParsedLine
+		lines   []string // current's lines, the first being its timestamped line
+	)
+	emit := func() bool {
+		if len(lines) == 0 {
+			return true
+		}
+		current.Raw = strings.Join(lines, "\n")
+		if len(lines) > 1 {
+			current.Message += "\n" + strings.Join(lines[1:], "\n")
+		}
+		return fn(current)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		entry, ok := s.parseLogLine(line)
+		if !ok && len(lines) > 0 {
+			lines = append(lines, line)
+			continue
+		}
+		if !emit() {
+			return nil
+		}
+		current, lines = entry, []string{line}
+	}
+	emit()
+	return scanner.Err()
+}
+
+// parseLogLine splits a line into timestamp, level and message. ok is false when
+// the line doesn't start with a timestamp; entry then holds the whole line as
+// its message.
+func (s *Scanner) parseLogLine(line string) (entry ParsedLine, ok bool) {
+	layout := s.timestampLayout
+	if len(line) < len(layout) {
+		return ParsedLine{Message: line}, false
+	}
+	timestamp, err := time.ParseInLocation(layout, line[:len(layout)], time.Local)
+	if err != nil {
+		return ParsedLine{Message: line}, false
+	}
+
+	entry = ParsedLine{Timestamp: timestamp, Message: strings.TrimSpace(line[len(layout):])}
+	// The level may be written INFO, INFO: or [INFO]
+	marker, rest, _ := strings.Cut(entry.Message, " ")
+	level := strings.ToUpper(strings.Trim(marker, "[]:"))
+	if _, known := levelRanks[level]; known {
+		entry.Level = level
+		entry.Message = strings.TrimSpace(rest)
+	}
+	return entry, true
+}
@@ -2,79 +2,353 @@ package nfs
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"salam-monitoring/internal/businessday"
 	"salam-monitoring/internal/logger"
 )
 
 // LogEntry represents a log entry from NFS monitoring
 type LogEntry struct {
-	Source    string    `json:"source"`
-	Date      string    `json:"date"`
-	Workflow  string    `json:"workflow"`
-	LogType   string    `json:"log_type"`
-	Content   string    `json:"content"`
-	HasErrors bool      `json:"has_errors"`
-	FilePath  string    `json:"file_path"`
-	Size      int64     `json:"size"`
-	ModTime   time.Time `json:"mod_time"`
+	Source      string    `json:"source"`
+	Date        string    `json:"date"`
+	Workflow    string    `json:"workflow"`
+	LogType     string    `json:"log_type"`
+	Content     string    `json:"content"`
+	HasErrors   bool      `json:"has_errors"`
+	HasWarnings bool      `json:"has_warnings"`
+	FilePath    string    `json:"file_path"`
+	Size        int64     `json:"size"`
+	ModTime     time.Time `json:"mod_time"`
+	Compressed  bool      `json:"compressed"`
 }
 
 // WorkflowSummary represents a summary of workflow logs
 type WorkflowSummary struct {
-	Source    string      `json:"source"`
-	Date      string      `json:"date"`
-	Workflow  string      `json:"workflow"`
-	Logs      []*LogEntry `json:"logs"`
-	HasErrors bool        `json:"has_errors"`
-	Status    string      `json:"status"`
+	Source      string      `json:"source"`
+	Date        string      `json:"date"`
+	Workflow    string      `json:"workflow"`
+	Logs        []*LogEntry `json:"logs"`
+	HasErrors   bool        `json:"has_errors"`
+	HasWarnings bool        `json:"has_warnings"`
+	Status      string      `json:"status"`
+}
+
+// DefaultErrorPatterns are the error indicators used when none are configured
+var DefaultErrorPatterns = []string{
+	"ERROR",
+	"FATAL",
+	"Exception",
+	"FAILED",
+	"failure",
+}
+
+// DefaultMaxOpenFiles bounds the log files read at once across all scans when
+// no limit is configured
+const DefaultMaxOpenFiles = 64
+
+// DefaultLogFiles are the log file names scanned in each workflow directory
+// for sources without their own configured list
+var DefaultLogFiles = []string{"info.log", "error.log", "run.log"}
+
+// LogRoles names the log files that decide a workflow's status. Both should be
+// among the log files scanned for the source.
+type LogRoles struct {
+	Completion string // present once the workflow has finished
+	Error      string // any content means the workflow failed
+}
+
+// DefaultLogRoles are the log roles for sources without their own
+var DefaultLogRoles = LogRoles{Completion: "run.log", Error: "error.log"}
+
+// or fills the empty fields of r from fallback
+func (r LogRoles) or(fallback LogRoles) LogRoles {
+	if r.Completion == "" {
+		r.Completion = fallback.Completion
+	}
+	if r.Error == "" {
+		r.Error = fallback.Error
+	}
+	return r
 }
 
 // Scanner handles NFS log scanning operations
 type Scanner struct {
 	nfsRoot string
+
+	// ErrorPatterns are matched against each log line, case-sensitively unless
+	// ignoreCase is set. Patterns prefixed with "re:" are treated as regular expressions.
+	ErrorPatterns []string
+	ignoreCase    bool
+	errorMatchers []*regexp.Regexp
+
+	// logFiles is the default log file set; sourceLogFiles overrides it per source
+	logFiles       []string
+	sourceLogFiles map[string][]string
+
+	// logRoles names the completion and error logs; sourceLogRoles overrides them per source
+	logRoles       LogRoles
+	sourceLogRoles map[string]LogRoles
+
+	// scanWorkers bounds how many source directories are scanned concurrently
+	scanWorkers int
+
+	// openFiles holds a slot for each log file being read for error detection,
+	// shared by all scans so concurrent scans can't exhaust file descriptors
+	openFiles chan struct{}
+
+	// followSymlinks includes symlinked source and workflow directories
+	followSymlinks bool
+
+	cache         scanCache
+	todayCacheTTL time.Duration
+	pastCacheTTL  time.Duration
+
+	// scanObserver, if set, is given the stats of each uncached scan
+	scanObserver func(ScanStats)
+
+	// timestampLayout starts each entry in ParseLogEntries
+	timestampLayout string
+
+	// dayBoundary decides which date directory holds today's logs
+	dayBoundary businessday.Boundary
+}
+
+// ScannerOption configures optional Scanner behaviour
+type ScannerOption func(*Scanner)
+
+// WithErrorPatterns overrides the default error patterns; an empty list keeps the defaults
+func WithErrorPatterns(patterns []string) ScannerOption {
+	return func(s *Scanner) {
+		if len(patterns) > 0 {
+			s.ErrorPatterns = patterns
+		}
+	}
+}
+
+// WithErrorPatternsIgnoreCase matches the error patterns regardless of case
+func WithErrorPatternsIgnoreCase(ignore bool) ScannerOption {
+	return func(s *Scanner) {
+		s.ignoreCase = ignore
+	}
+}
+
+// WithScanWorkers sets how many sources are scanned concurrently; values below 1 keep the default
+func WithScanWorkers(workers int) ScannerOption {
+	return func(s *Scanner) {
+		if workers > 0 {
+			s.scanWorkers = workers
+		}
+	}
+}
+
+// WithMaxOpenFiles sets how many log files scans may read at once; values below
+// 1 keep DefaultMaxOpenFiles
+func WithMaxOpenFiles(files int) ScannerOption {
+	return func(s *Scanner) {
+		if files > 0 {
+			s.openFiles = make(chan struct{}, files)
+		}
+	}
+}
+
+// WithLogFiles sets the log file names to scan. defaults applies to sources missing
+// from perSource; an empty defaults list keeps DefaultLogFiles.
+func WithLogFiles(defaults []string, perSource map[string][]string) ScannerOption {
+	return func(s *Scanner) {
+		if len(defaults) > 0 {
+			s.logFiles = defaults
+		}
+		s.sourceLogFiles = perSource
+	}
+}
+
+// WithLogRoles sets the completion and error log names. perSource overrides
+// defaults for individual sources; empty names fall back to defaults and then
+// to DefaultLogRoles.
+func WithLogRoles(defaults LogRoles, perSource map[string]LogRoles) ScannerOption {
+	return func(s *Scanner) {
+		s.logRoles = defaults.or(DefaultLogRoles)
+		s.sourceLogRoles = perSource
+	}
+}
+
+// WithScanObserver registers a callback invoked with the stats of every uncached scan
+func WithScanObserver(observe func(ScanStats)) ScannerOption {
+	return func(s *Scanner) {
+		s.scanObserver = observe
+	}
 }
 
 // NewScanner creates a new NFS log scanner
-func NewScanner(nfsRoot string) *Scanner {
+func NewScanner(nfsRoot string, opts ...ScannerOption) *Scanner {
 	logger.Info("Creating NFS scanner for root: %s", nfsRoot)
-	return &Scanner{
-		nfsRoot: nfsRoot,
+	s := &Scanner{
+		nfsRoot:       nfsRoot,
+		ErrorPatterns: DefaultErrorPatterns,
+		logFiles:      DefaultLogFiles,
+		logRoles:      DefaultLogRoles,
+		scanWorkers:   runtime.NumCPU(),
+		openFiles:     make(chan struct{}, DefaultMaxOpenFiles),
+		todayCacheTTL: DefaultTodayCacheTTL,
+		pastCacheTTL:  DefaultPastCacheTTL,
+
+		timestampLayout: DefaultTimestampLayout,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	s.errorMatchers = compilePatterns(s.ErrorPatterns, s.ignoreCase)
+	return s
+}
+
+// logFilesFor returns the log file names to scan for a source
+func (s *Scanner) logFilesFor(source string) []string {
+	if files := s.sourceLogFiles[source]; len(files) > 0 {
+		return files
+	}
+	return s.logFiles
+}
+
+// logRolesFor returns the completion and error log names for a source
+func (s *Scanner) logRolesFor(source string) LogRoles {
+	return s.sourceLogRoles[source].or(s.logRoles)
+}
+
+// compilePatterns turns configured patterns into matchers, case-insensitive when
+// ignoreCase is set. Invalid regular expressions are logged and skipped.
+func compilePatterns(patterns []string, ignoreCase bool) []*regexp.Regexp {
+	var matchers []*regexp.Regexp
+	for _, pattern := range patterns {
+		expr := regexp.QuoteMeta(pattern)
+		if strings.HasPrefix(pattern, "re:") {
+			expr = strings.TrimPrefix(pattern, "re:")
+		}
+		if ignoreCase {
+			expr = "(?i)" + expr
+		}
+
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Ignoring invalid error pattern %q", pattern), err)
+			continue
+		}
+		matchers = append(matchers, re)
+	}
+	return matchers
+}
+
+// matchesError reports whether a line matches any configured error pattern
+func (s *Scanner) matchesError(line string) bool {
+	for _, re := range s.errorMatchers {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithDayBoundary sets when today's date directory takes over from
+// yesterday's; the zero Boundary switches at midnight local time
+func WithDayBoundary(b businessday.Boundary) ScannerOption {
+	return func(s *Scanner) {
+		s.dayBoundary = b
+	}
+}
+
+// Today returns the current business date, the name of today's date directories
+func (s *Scanner) Today() string {
+	return s.dayBoundary.Today()
 }
 
 // ScanTodaysLogs scans today's logs from all sources
-func (s *Scanner) ScanTodaysLogs() ([]*WorkflowSummary, error) {
-	today := time.Now().Format("2006-01-02")
-	logger.Info("Scanning today's logs for date: %s", today)
-	return s.ScanLogsForDate(today)
+func (s *Scanner) ScanTodaysLogs(ctx context.Context) ([]*WorkflowSummary, error) {
+	today := s.Today()
+	logger.InfoCtx(ctx, "Scanning today's logs for date: %s", today)
+	return s.ScanLogsForDate(ctx, today)
 }
 
-// ScanLogsForDate scans logs for a specific date
-func (s *Scanner) ScanLogsForDate(date string) ([]*WorkflowSummary, error) {
-	logger.Info("Scanning logs for date: %s in NFS root: %s", date, s.nfsRoot)
+// ScanLogsForDate scans logs for a specific date, reusing cached results while fresh.
+// Callers must not modify the returned summaries.
+func (s *Scanner) ScanLogsForDate(ctx context.Context, date string) ([]*WorkflowSummary, error) {
+	summaries, _, err := s.ScanLogsForDateWithStats(ctx, date)
+	return summaries, err
+}
+
+// ScanLogsForDateWithStats is ScanLogsForDate, also returning what the scan
+// cost. A result served from the cache has Cached set and no I/O counts.
+func (s *Scanner) ScanLogsForDateWithStats(ctx context.Context, date string) ([]*WorkflowSummary, ScanStats, error) {
+	date, err := s.normalizeDate(date)
+	if err != nil {
+		return nil, ScanStats{}, err
+	}
+	if summaries, ok := s.cachedScan(date); ok {
+		logger.InfoCtx(ctx, "Using cached scan for date: %s (%d workflows)", date, len(summaries))
+		return summaries, ScanStats{Date: date, Cached: true, Workflows: len(summaries)}, nil
+	}
+	return s.refreshLogsForDate(ctx, date)
+}
+
+// RefreshLogsForDate scans a date from disk, ignoring any cached result, and
+// caches the fresh summaries for later ScanLogsForDate calls
+func (s *Scanner) RefreshLogsForDate(ctx context.Context, date string) ([]*WorkflowSummary, error) {
+	date, err := s.normalizeDate(date)
+	if err != nil {
+		return nil, err
+	}
+	summaries, _, err := s.refreshLogsForDate(ctx, date)
+	return summaries, err
+}
+
+// refreshLogsForDate scans a date from disk, logging and reporting the scan's
+// stats, and caches the summaries
+func (s *Scanner) refreshLogsForDate(ctx context.Context, date string) ([]*WorkflowSummary, ScanStats, error) {
+	summaries, stats, err := s.scanLogsForDate(ctx, date)
+	if err != nil {
+		return nil, stats, err
+	}
+	logger.InfoCtx(ctx, "NFS scan stats for %s: %s", date, stats)
+	if s.scanObserver != nil {
+		s.scanObserver(stats)
+	}
+	s.storeScan(date, summaries)
+	return summaries, stats, nil
+}
+
+// scanLogsForDate walks the NFS root for a date without consulting the cache
+func (s *Scanner) scanLogsForDate(ctx context.Context, date string) ([]*WorkflowSummary, ScanStats, error) {
+	logger.InfoCtx(ctx, "Scanning logs for date: %s in NFS root: %s", date, s.nfsRoot)
+	start := time.Now()
+	stats := ScanStats{Date: date}
 
 	// Scan all source directories
-	var summaries []*WorkflowSummary
 	sources, err := s.getSourceDirectories()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get source directories: %w", err)
+		return nil, stats, fmt.Errorf("failed to get source directories: %w", err)
 	}
 
-	for _, source := range sources {
-		sourceSummaries, err := s.scanSourceForDate(source, date)
-		if err != nil {
-			// Log error but continue with other sources
-			logger.LogError(fmt.Sprintf("Failed to scan source %s for date %s", source, date), err)
-			continue
-		}
-		summaries = append(summaries, sourceSummaries...)
+	var counters scanCounters
+	summaries, err := s.scanSourcesConcurrently(ctx, sources, date, &counters)
+	if err != nil {
+		return nil, stats, err
 	}
+	stats.Sources = len(sources)
+	stats.Workflows = len(summaries)
+	stats.FilesOpened = counters.filesOpened.Load()
+	stats.BytesRead = counters.bytesRead.Load()
+	stats.Duration = time.Since(start)
 
 	// Sort summaries by source and workflow name
 	sort.Slice(summaries, func(i, j int) bool {
@@ -84,28 +358,85 @@ func (s *Scanner) ScanLogsForDate(date string) ([]*WorkflowSummary, error) {
 		return summaries[i].Workflow < summaries[j].Workflow
 	})
 
-	logger.Info("Found %d workflow summaries for date %s", len(summaries), date)
-	return summaries, nil
+	logger.InfoCtx(ctx, "Found %d workflow summaries for date %s", len(summaries), date)
+	return summaries, stats, nil
 }
 
-// getSourceDirectories returns all source directories under NFS root
-func (s *Scanner) getSourceDirectories() ([]string, error) {
-	entries, err := os.ReadDir(s.nfsRoot)
-	if err != nil {
+// scanSourcesConcurrently scans sources with a bounded worker pool. A failing or
+// panicking source is logged and skipped so the rest of the scan still completes.
+// Once ctx is done no further sources are started and ctx's error is returned.
+func (s *Scanner) scanSourcesConcurrently(ctx context.Context, sources []string, date string, counters *scanCounters) ([]*WorkflowSummary, error) {
+	workers := s.scanWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(sources) {
+		workers = len(sources)
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		summaries []*WorkflowSummary
+	)
+
+	jobs := make(chan string)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for source := range jobs {
+				sourceSummaries := s.scanSourceSafely(source, date, counters)
+
+				mu.Lock()
+				summaries = append(summaries, sourceSummaries...)
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, source := range sources {
+		select {
+		case jobs <- source:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	return summaries, nil
+}
 
-	var sources []string
-	for _, entry := range entries {
-		if entry.IsDir() {
-			sources = append(sources, entry.Name())
+// scanSourceSafely scans one source, recovering from panics and logging errors
+func (s *Scanner) scanSourceSafely(source, date string, counters *scanCounters) (summaries []*WorkflowSummary) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			logger.LogPanic(fmt.Sprintf("scan of source %s for date %s", source, date), recovered)
+			summaries = nil
 		}
+	}()
+
+	summaries, err := s.scanSourceForDate(source, date, counters)
+	if err != nil {
+		// Log error but continue with other sources
+		logger.LogError(fmt.Sprintf("Failed to scan source %s for date %s", source, date), err)
+		return nil
 	}
-	return sources, nil
+	return summaries
+}
+
+// getSourceDirectories returns all source directories under NFS root
+func (s *Scanner) getSourceDirectories() ([]string, error) {
+	return s.listDirectories(s.nfsRoot)
 }
 
 // scanSourceForDate scans a specific source directory for a specific date
-func (s *Scanner) scanSourceForDate(source, date string) ([]*WorkflowSummary, error) {
+func (s *Scanner) scanSourceForDate(source, date string, counters *scanCounters) ([]*WorkflowSummary, error) {
 	datePath := filepath.Join(s.nfsRoot, source, date)
 	var summaries []*WorkflowSummary
 
@@ -121,7 +452,7 @@ func (s *Scanner) scanSourceForDate(source, date string) ([]*WorkflowSummary, er
 	}
 
 	for _, workflow := range workflows {
-		summary, err := s.scanWorkflow(source, date, workflow)
+		summary, err := s.scanWorkflow(source, date, workflow, counters)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Failed to scan workflow %s", workflow), err)
 			continue
@@ -133,22 +464,11 @@ func (s *Scanner) scanSourceForDate(source, date string) ([]*WorkflowSummary, er
 
 // getWorkflowDirectories returns all workflow directories under a date path
 func (s *Scanner) getWorkflowDirectories(datePath string) ([]string, error) {
-	entries, err := os.ReadDir(datePath)
-	if err != nil {
-		return nil, err
-	}
-
-	var workflows []string
-	for _, entry := range entries {
-		if entry.IsDir() {
-			workflows = append(workflows, entry.Name())
-		}
-	}
-	return workflows, nil
+	return s.listDirectories(datePath)
 }
 
 // scanWorkflow scans a specific workflow directory for logs
-func (s *Scanner) scanWorkflow(source, date, workflow string) (*WorkflowSummary, error) {
+func (s *Scanner) scanWorkflow(source, date, workflow string, counters *scanCounters) (*WorkflowSummary, error) {
 	workflowPath := filepath.Join(s.nfsRoot, source, date, workflow)
 
 	summary := &WorkflowSummary{
@@ -159,15 +479,15 @@ func (s *Scanner) scanWorkflow(source, date, workflow string) (*WorkflowSummary,
 		Status:   "Unknown",
 	}
 
-	// Scan for log files
-	logTypes := []string{"info.log", "error.log", "run.log"}
-	for _, logType := range logTypes {
-		logPath := filepath.Join(workflowPath, logType)
-		if _, err := os.Stat(logPath); os.IsNotExist(err) {
+	// Scan for log files, falling back to rotated .gz variants
+	roles := s.logRolesFor(source)
+	for _, logType := range s.logFilesFor(source) {
+		logPath := findLogFile(workflowPath, logType)
+		if logPath == "" {
 			continue // File doesn't exist, skip
 		}
 
-		logEntry, err := s.scanLogFile(source, date, workflow, logType, logPath)
+		logEntry, err := s.scanLogFile(source, date, workflow, logType, logPath, logType == roles.Error, counters)
 		if err != nil {
 			logger.LogError(fmt.Sprintf("Failed to scan log file %s", logPath), err)
 			continue
@@ -177,82 +497,143 @@ func (s *Scanner) scanWorkflow(source, date, workflow string) (*WorkflowSummary,
 		if logEntry.HasErrors {
 			summary.HasErrors = true
 		}
+		if logEntry.HasWarnings {
+			summary.HasWarnings = true
+		}
 	}
 
 	// Determine workflow status
-	summary.Status = s.determineWorkflowStatus(summary)
+	summary.Status = s.determineWorkflowStatus(summary, roles.Completion)
 	return summary, nil
 }
 
-// scanLogFile scans a specific log file
-func (s *Scanner) scanLogFile(source, date, workflow, logType, filePath string) (*LogEntry, error) {
+// findLogFile returns the path of a log file or its .gz variant, or "" if neither exists
+func findLogFile(workflowPath, logType string) string {
+	logPath := filepath.Join(workflowPath, logType)
+	if _, err := os.Stat(logPath); err == nil {
+		return logPath
+	}
+	if _, err := os.Stat(logPath + ".gz"); err == nil {
+		return logPath + ".gz"
+	}
+	return ""
+}
+
+// isCompressed reports whether a log file is gzip-compressed
+func isCompressed(filePath string) bool {
+	return strings.HasSuffix(filePath, ".gz")
+}
+
+// gzipReadCloser closes both the gzip stream and the underlying file
+type gzipReadCloser struct {
+	*gzip.Reader
+	file io.Closer
+}
+
+func (g *gzipReadCloser) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
+
+// openLogFile opens a log file for reading, transparently decompressing .gz files
+func openLogFile(filePath string) (io.ReadCloser, error) {
+	return openCountedLogFile(filePath, nil)
+}
+
+// openCountedLogFile is openLogFile, also counting the file and the bytes read
+// from disk in counters when it is non-nil
+func openCountedLogFile(filePath string, counters *scanCounters) (io.ReadCloser, error) {
+	opened, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var file io.ReadCloser = opened
+	if counters != nil {
+		counters.opened()
+		file = &countingReader{ReadCloser: opened, counters: counters}
+	}
+	if !isCompressed(filePath) {
+		return file, nil
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open gzip log %s: %w", filePath, err)
+	}
+	return &gzipReadCloser{Reader: gz, file: file}, nil
+}
+
+// scanLogFile scans a specific log file; errorLog marks the source's error log
+func (s *Scanner) scanLogFile(source, date, workflow, logType, filePath string, errorLog bool, counters *scanCounters) (*LogEntry, error) {
 	stat, err := os.Stat(filePath)
 	if err != nil {
 		return nil, err
 	}
 
 	// Read file content for error detection
-	hasErrors, err := s.detectErrors(filePath, logType)
+	hasErrors, hasWarnings, err := s.scanSeverity(filePath, errorLog, counters)
 	if err != nil {
 		return nil, err
 	}
 
 	entry := &LogEntry{
-		Source:    source,
-		Date:      date,
-		Workflow:  workflow,
-		LogType:   logType,
-		HasErrors: hasErrors,
-		FilePath:  filePath,
-		Size:      stat.Size(),
-		ModTime:   stat.ModTime(),
+		Source:      source,
+		Date:        date,
+		Workflow:    workflow,
+		LogType:     logType,
+		HasErrors:   hasErrors,
+		HasWarnings: hasWarnings,
+		FilePath:    filePath,
+		Size:        stat.Size(),
+		ModTime:     stat.ModTime(),
+		Compressed:  isCompressed(filePath),
 	}
 	return entry, nil
 }
 
-// detectErrors scans a log file for error indicators
-func (s *Scanner) detectErrors(filePath, logType string) (bool, error) {
-	file, err := os.Open(filePath)
+// warningPattern matches WARN/WARNING severity markers
+var warningPattern = regexp.MustCompile(`\bWARN(ING)?\b`)
+
+// scanSeverity scans a log file for error and warning indicators, waiting for
+// one of the scanner's open file slots first. Any content in an error log counts
+// as an error.
+func (s *Scanner) scanSeverity(filePath string, errorLog bool, counters *scanCounters) (hasErrors, hasWarnings bool, err error) {
+	s.openFiles <- struct{}{}
+	defer func() { <-s.openFiles }()
+
+	file, err := openCountedLogFile(filePath, counters)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
 	defer file.Close()
 
 	scanner := bufio.NewScanner(file)
 
-	errorPatterns := []string{
-		"ERROR",
-		"FATAL",
-		"Exception",
-		"Failed",
-		"failure",
-		"FAILED",
-		"error:",
-		"Error:",
-	}
-
-	// For error.log files, any content indicates errors
-	if logType == "error.log" {
+	// For error logs, any content indicates errors
+	if errorLog {
 		// Check if file has any content
 		scanner.Scan()
-		return len(strings.TrimSpace(scanner.Text())) > 0, scanner.Err()
+		return len(strings.TrimSpace(scanner.Text())) > 0, false, scanner.Err()
 	}
 
-	// For other logs, scan for error patterns
+	// For other logs, scan for error patterns; an error outranks any warnings
 	for scanner.Scan() {
 		line := scanner.Text()
-		for _, pattern := range errorPatterns {
-			if strings.Contains(line, pattern) {
-				return true, nil
-			}
+		if s.matchesError(line) {
+			return true, hasWarnings, nil
+		}
+		if !hasWarnings && warningPattern.MatchString(line) {
+			hasWarnings = true
 		}
 	}
 
-	return false, scanner.Err()
+	return false, hasWarnings, scanner.Err()
 }
 
-// determineWorkflowStatus determines the overall workflow status
-func (s *Scanner) determineWorkflowStatus(summary *WorkflowSummary) string {
+// determineWorkflowStatus determines the overall workflow status; a workflow with
+// its completion log present has finished
+func (s *Scanner) determineWorkflowStatus(summary *WorkflowSummary, completionLog string) string {
 	if summary.HasErrors {
 		return "Failed"
 	}
@@ -262,25 +643,38 @@ func (s *Scanner) determineWorkflowStatus(summary *WorkflowSummary) string {
 		return "No Logs"
 	}
 
-	// Check for run.log to determine if workflow completed
-	hasRunLog := false
+	// Check for the completion log to determine if workflow completed
+	completed := false
 	for _, log := range summary.Logs {
-		if log.LogType == "run.log" {
-			hasRunLog = true
+		if log.LogType == completionLog {
+			completed = true
 			break
 		}
 	}
 
-	if hasRunLog && !summary.HasErrors {
+	if completed && !summary.HasErrors {
+		if summary.HasWarnings {
+			return "Completed with Warnings"
+		}
 		return "Completed"
 	}
 
 	return "In Progress"
 }
 
+// IsHealthy checks that the NFS root exists and is a directory
+func (s *Scanner) IsHealthy() bool {
+	info, err := os.Stat(s.nfsRoot)
+	if err != nil {
+		logger.LogError("NFS root not accessible", err)
+		return false
+	}
+	return info.IsDir()
+}
+
 // GetLogContent reads the content of a specific log file
 func (s *Scanner) GetLogContent(filePath string, maxLines int) ([]string, error) {
-	file, err := os.Open(filePath)
+	file, err := openLogFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -298,49 +692,104 @@ func (s *Scanner) GetLogContent(filePath string, maxLines int) ([]string, error)
 	return lines, scanner.Err()
 }
 
-// GetLogTail reads the last N lines of a log file
+// tailChunkSize is how much GetLogTail reads per backward seek
+const tailChunkSize = 32 * 1024
+
+// GetLogTail reads the last N lines of a log file. Plain files are read backwards
+// in fixed-size chunks so only the tail is loaded; gzip files can't be seeked and
+// are streamed while keeping just the last N lines.
 func (s *Scanner) GetLogTail(filePath string, lines int) ([]string, error) {
-	// In production, you might want to use more efficient tail implementation
-	// For simplicity, we'll read the whole file and return the last N lines
-	allLines, err := s.GetLogContent(filePath, 0)
+	if lines <= 0 {
+		return []string{}, nil
+	}
+	if isCompressed(filePath) {
+		return tailStream(filePath, lines)
+	}
+
+	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
 
-	start := 0
-	if len(allLines) > lines {
-		start = len(allLines) - lines
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
 	}
 
-	return allLines[start:], nil
+	// Walk backwards until we've seen more newlines than requested lines,
+	// which guarantees N complete lines even with a trailing newline
+	var chunks [][]byte
+	offset := stat.Size()
+	newlines := 0
+	for offset > 0 && newlines <= lines {
+		size := int64(tailChunkSize)
+		if offset < size {
+			size = offset
+		}
+		offset -= size
+
+		chunk := make([]byte, size)
+		if _, err := file.ReadAt(chunk, offset); err != nil && err != io.EOF {
+			return nil, err
+		}
+		newlines += bytes.Count(chunk, []byte{'\n'})
+		chunks = append(chunks, chunk)
+	}
+
+	// Chunks were collected back to front
+	var buf bytes.Buffer
+	for i := len(chunks) - 1; i >= 0; i-- {
+		buf.Write(chunks[i])
+	}
+	if buf.Len() == 0 {
+		return []string{}, nil
+	}
+
+	result := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if offset > 0 {
+		// The first line is partial because we stopped mid-file
+		result = result[1:]
+	}
+	if len(result) > lines {
+		result = result[len(result)-lines:]
+	}
+	for i, line := range result {
+		result[i] = strings.TrimSuffix(line, "\r")
+	}
+	return result, nil
 }
 
-// SearchLogs searches for a keyword across all logs for today
-func (s *Scanner) SearchLogs(keyword string) ([]*LogEntry, error) {
-	summaries, err := s.ScanTodaysLogs()
+// tailStream returns the last N lines of a non-seekable log by streaming it through a ring buffer
+func tailStream(filePath string, lines int) ([]string, error) {
+	file, err := openLogFile(filePath)
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
 
-	var results []*LogEntry
-	for _, summary := range summaries {
-		for _, logEntry := range summary.Logs {
-			content, err := s.GetLogContent(logEntry.FilePath, 1000) // Search first 1000 lines
-			if err != nil {
-				continue
-			}
+	ring := make([]string, lines)
+	count := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		ring[count%lines] = scanner.Text()
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
-			for _, line := range content {
-				if strings.Contains(strings.ToLower(line), strings.ToLower(keyword)) {
-					// Clone the log entry with matching content
-					result := *logEntry
-					result.Content = line
-					results = append(results, &result)
-					break // Only add once per file
-				}
-			}
-		}
+	if count <= lines {
+		return ring[:count], nil
 	}
+	start := count % lines
+	return append(ring[start:], ring[:start]...), nil
+}
 
-	return results, nil
+// SearchLogs searches for a keyword across all logs for today
+func (s *Scanner) SearchLogs(ctx context.Context, keyword string) ([]*LogEntry, error) {
+	needle := strings.ToLower(keyword)
+	return s.searchTodaysLogs(ctx, func(line string) bool {
+		return strings.Contains(strings.ToLower(line), needle)
+	})
 }
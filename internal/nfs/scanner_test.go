@@ -0,0 +1,247 @@
+package nfs
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScanRespectsMaxOpenFiles(t *testing.T) {
+	const (
+		sources      = 20
+		workflows    = 100
+		maxOpenFiles = 4
+	)
+	// Enough lines that files stay open long enough for scans to overlap
+	content := strings.Repeat("2024-11-21 10:30:00 INFO: Loading batch\n", 25)
+	root := t.TempDir()
+	for i := 0; i < sources; i++ {
+		source := fmt.Sprintf("src%02d", i)
+		for j := 0; j < workflows; j++ {
+			workflow := fmt.Sprintf("wf%03d", j)
+			writeLog(t, root, source, testDate, workflow, "info.log", content)
+			writeLog(t, root, source, testDate, workflow, "run.log", content)
+		}
+	}
+
+	s := NewScanner(root, WithScanWorkers(sources), WithMaxOpenFiles(maxOpenFiles))
+	dirs, err := s.getSourceDirectories()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var counters scanCounters
+	summaries, err := s.scanSourcesConcurrently(context.Background(), dirs, testDate, &counters)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if len(summaries) != sources*workflows {
+		t.Errorf("got %d workflows, want %d", len(summaries), sources*workflows)
+	}
+	if opened := counters.filesOpened.Load(); opened != 2*sources*workflows {
+		t.Errorf("opened %d files, want %d", opened, 2*sources*workflows)
+	}
+	if peak := counters.peakOpenFiles.Load(); peak > maxOpenFiles {
+		t.Errorf("peak of %d files open at once, want at most %d", peak, maxOpenFiles)
+	}
+	if open := counters.openFiles.Load(); open != 0 {
+		t.Errorf("%d files still open after the scan", open)
+	}
+}
+
+// fullReadTail is the original GetLogTail, which read the whole file and kept
+// the last lines. It is the reference GetLogTail is checked against, and
+// BenchmarkGetLogTail compares the two.
+func fullReadTail(s *Scanner, filePath string, lines int) ([]string, error) {
+	allLines, err := s.GetLogContent(filePath, 0)
+	if err != nil {
+		return nil, err
+	}
+	start := 0
+	if len(allLines) > lines {
+		start = len(allLines) - lines
+	}
+	return allLines[start:], nil
+}
+
+func TestGetLogTailMatchesFullRead(t *testing.T) {
+	longLine := strings.Repeat("x", tailChunkSize+100)
+	var boundary strings.Builder
+	for i := 0; i < 200; i++ {
+		// 1000-byte lines, so several cross each chunk boundary
+		fmt.Fprintf(&boundary, "%04d %s\n", i, strings.Repeat("y", 994))
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		lines   int
+	}{
+		{"lf", "info.log", "a\nb\nc\n", 2},
+		{"crlf", "info.log", "a\r\nb\r\nc\r\n", 2},
+		{"no final newline", "info.log", "a\nb\nc", 2},
+		{"fewer lines than requested", "info.log", "a\nb\n", 10},
+		{"exactly the requested lines", "info.log", "a\nb\nc\n", 3},
+		{"blank lines", "info.log", "a\n\nb\n\n", 3},
+		{"lines across chunk boundaries", "info.log", boundary.String(), 150},
+		{"line longer than a chunk", "info.log", "first\n" + longLine + "\nlast\n", 2},
+		{"empty file", "info.log", "", 5},
+		{"gzip", "info.log.gz", "a\nb\r\nc\nd", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			data := []byte(tt.content)
+			if isCompressed(path) {
+				var buf bytes.Buffer
+				zw := gzip.NewWriter(&buf)
+				zw.Write(data)
+				zw.Close()
+				data = buf.Bytes()
+			}
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			s := NewScanner(filepath.Dir(path))
+
+			want, err := fullReadTail(s, path, tt.lines)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := s.GetLogTail(path, tt.lines)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("got %d lines, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("line %d: got %.40q, want %.40q", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+// benchmarkLogSize is the size of the log BenchmarkGetLogTail tails, large
+// enough that reading the whole file dominates as it does on production logs
+const benchmarkLogSize = 500 << 20
+
+func BenchmarkGetLogTail(b *testing.B) {
+	if testing.Short() {
+		b.Skip("writes a 500 MB log; skipped with -short")
+	}
+
+	// Written once per run, a line at a time, rather than built in memory
+	path := filepath.Join(b.TempDir(), "info.log")
+	file, err := os.Create(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	w := bufio.NewWriter(file)
+	line := "2024-11-21 10:30:00 INFO: Loaded batch of customer records into the staging area\n"
+	for written := 0; written < benchmarkLogSize; written += len(line) {
+		w.WriteString(line)
+	}
+	if err := w.Flush(); err != nil {
+		b.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		b.Fatal(err)
+	}
+	s := NewScanner(filepath.Dir(path))
+
+	for _, bm := range []struct {
+		name string
+		tail func(*Scanner, string, int) ([]string, error)
+	}{
+		{"full-read", fullReadTail},
+		{"chunked", (*Scanner).GetLogTail},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				got, err := bm.tail(s, path, 100)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(got) != 100 {
+					b.Fatalf("got %d lines, want 100", len(got))
+				}
+			}
+		})
+	}
+}
+
+func TestErrorPatternsCase(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []ScannerOption
+		line       string
+		wantErrors bool
+	}{
+		{"default marker", nil, "2024-11-21 10:30:00 ERROR: Load failed", true},
+		{"defaults are case-sensitive", nil, "2024-11-21 10:30:00 INFO: no error rows rejected", false},
+		{"opted in", []ScannerOption{WithErrorPatternsIgnoreCase(true)}, "2024-11-21 10:30:00 INFO: no error rows rejected", true},
+		{"custom pattern as written", []ScannerOption{WithErrorPatterns([]string{"Severity: ERROR"})}, "Severity: error", false},
+		{"custom pattern opted in", []ScannerOption{WithErrorPatterns([]string{"Severity: ERROR"}), WithErrorPatternsIgnoreCase(true)}, "Severity: error", true},
+		{"regex", []ScannerOption{WithErrorPatterns([]string{`re:ORA-\d{5}`})}, "ORA-01424 invalid escape", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewScanner(t.TempDir(), tt.opts...).matchesError(tt.line); got != tt.wantErrors {
+				t.Errorf("matchesError(%q) = %v, want %v", tt.line, got, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestWorkflowStatusWithSourceLogRoles(t *testing.T) {
+	root := t.TempDir()
+	const info = "2024-11-21 10:30:00 INFO: Loading batch\n"
+	// "legacy" keeps the default info.log, error.log and run.log
+	writeLog(t, root, "legacy", testDate, "done", "info.log", info)
+	writeLog(t, root, "legacy", testDate, "done", "run.log", info)
+	writeLog(t, root, "legacy", testDate, "failed", "error.log", "Connection refused\n")
+	// "informatica" writes session.log as it runs and wf.log when it finishes
+	writeLog(t, root, "informatica", testDate, "done", "session.log", info)
+	writeLog(t, root, "informatica", testDate, "done", "wf.log", info)
+	writeLog(t, root, "informatica", testDate, "running", "session.log", info)
+	writeLog(t, root, "informatica", testDate, "failed", "session.log", info)
+	writeLog(t, root, "informatica", testDate, "failed", "error.txt", "Connection refused\n")
+
+	s := NewScanner(root,
+		WithLogFiles(nil, map[string][]string{"informatica": {"session.log", "error.txt", "wf.log"}}),
+		WithLogRoles(LogRoles{}, map[string]LogRoles{"informatica": {Completion: "wf.log", Error: "error.txt"}}),
+	)
+	summaries, err := s.ScanLogsForDate(context.Background(), testDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"legacy/done":         "Completed",
+		"legacy/failed":       "Failed",
+		"informatica/done":    "Completed",
+		"informatica/running": "In Progress",
+		"informatica/failed":  "Failed",
+	}
+	if len(summaries) != len(want) {
+		t.Errorf("got %d workflows, want %d", len(summaries), len(want))
+	}
+	for _, summary := range summaries {
+		key := summary.Source + "/" + summary.Workflow
+		if summary.Status != want[key] {
+			t.Errorf("%s: got status %q, want %q", key, summary.Status, want[key])
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package nfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// searchLineLimit caps how many lines of each log file are searched
+const searchLineLimit = 1000
+
+// maxSearchPatternLength bounds regex size. Go's RE2 engine matches in linear time,
+// so there is no catastrophic backtracking, but huge patterns still cost memory and CPU.
+const maxSearchPatternLength = 256
+
+// ErrInvalidPattern is returned for search regexes that are too long or don't compile
+var ErrInvalidPattern = errors.New("invalid search pattern")
+
+// searchTodaysLogs returns one entry per log file whose first matching line satisfies match
+func (s *Scanner) searchTodaysLogs(ctx context.Context, match func(string) bool) ([]*LogEntry, error) {
+	return s.searchLogs(ctx, s.Today(), match)
+}
+
+// searchLogs is searchTodaysLogs for an arbitrary YYYY-MM-DD date
+func (s *Scanner) searchLogs(ctx context.Context, date string, match func(string) bool) ([]*LogEntry, error) {
+	summaries, err := s.ScanLogsForDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*LogEntry
+	for _, summary := range summaries {
+		for _, logEntry := range summary.Logs {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			content, err := s.GetLogContent(logEntry.FilePath, searchLineLimit)
+			if err != nil {
+				continue
+			}
+
+			for _, line := range content {
+				if match(line) {
+					// Clone the log entry with matching content
+					result := *logEntry
+					result.Content = line
+					results = append(results, &result)
+					break // Only add once per file
+				}
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// CompileSearchPattern validates and compiles a user-supplied search regex
+func CompileSearchPattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > maxSearchPatternLength {
+		return nil, fmt.Errorf("%w: too long (%d characters, max %d)", ErrInvalidPattern, len(pattern), maxSearchPatternLength)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPattern, err)
+	}
+	return re, nil
+}
+
+// SearchLogsRegex searches today's logs for lines matching a regular expression
+func (s *Scanner) SearchLogsRegex(ctx context.Context, pattern string) ([]*LogEntry, error) {
+	re, err := CompileSearchPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return s.searchTodaysLogs(ctx, re.MatchString)
+}
+
+// SearchLogsForDate searches the logs of a YYYY-MM-DD date for a keyword, ignoring case
+func (s *Scanner) SearchLogsForDate(ctx context.Context, date, keyword string) ([]*LogEntry, error) {
+	needle := strings.ToLower(keyword)
+	return s.searchLogs(ctx, date, func(line string) bool {
+		return strings.Contains(strings.ToLower(line), needle)
+	})
+}
+
+// SearchLogsRegexForDate searches the logs of a YYYY-MM-DD date for lines matching a regular expression
+func (s *Scanner) SearchLogsRegexForDate(ctx context.Context, date, pattern string) ([]*LogEntry, error) {
+	re, err := CompileSearchPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return s.searchLogs(ctx, date, re.MatchString)
+}
+
+// SearchMatch is a search hit together with the lines surrounding it
+type SearchMatch struct {
+	LogEntry
+	LineNumber   int      `json:"line_number"`   // 1-based line of the first match in this window
+	MatchLines   []int    `json:"match_lines"`   // every matching line merged into this window
+	ContextStart int      `json:"context_start"` // 1-based line number of Context[0]
+	Context      []string `json:"context"`
+}
+
+// SearchLogsWithContext searches today's logs for a keyword and returns each match with
+// up to before/after surrounding lines. Overlapping windows in the same file are merged.
+func (s *Scanner) SearchLogsWithContext(ctx context.Context, keyword string, before, after int) ([]*SearchMatch, error) {
+	if before < 0 {
+		before = 0
+	}
+	if after < 0 {
+		after = 0
+	}
+
+	summaries, err := s.ScanTodaysLogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	needle := strings.ToLower(keyword)
+	var results []*SearchMatch
+	for _, summary := range summaries {
+		for _, logEntry := range summary.Logs {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			content, err := s.GetLogContent(logEntry.FilePath, searchLineLimit)
+			if err != nil {
+				continue
+			}
+			results = append(results, contextMatches(logEntry, content, func(line string) bool {
+				return strings.Contains(strings.ToLower(line), needle)
+			}, before, after)...)
+		}
+	}
+
+	return results, nil
+}
+
+// contextMatches finds matching lines in content and groups them into context windows
+func contextMatches(entry *LogEntry, content []string, match func(string) bool, before, after int) []*SearchMatch {
+	var matches []*SearchMatch
+	var current *SearchMatch
+	currentEnd := -1 // 0-based index of the last line in the current window
+
+	for i, line := range content {
+		if !match(line) {
+			continue
+		}
+
+		start := i - before
+		if start < 0 {
+			start = 0
+		}
+		end := i + after
+		if end >= len(content) {
+			end = len(content) - 1
+		}
+
+		// Extend the previous window rather than repeating lines it already covers
+		if current != nil && start <= currentEnd+1 {
+			if end > currentEnd {
+				current.Context = append(current.Context, content[currentEnd+1:end+1]...)
+				currentEnd = end
+			}
+			current.MatchLines = append(current.MatchLines, i+1)
+			continue
+		}
+
+		current = &SearchMatch{
+			LogEntry:     *entry,
+			LineNumber:   i + 1,
+			MatchLines:   []int{i + 1},
+			ContextStart: start + 1,
+			Context:      append([]string(nil), content[start:end+1]...),
+		}
+		current.Content = line
+		currentEnd = end
+		matches = append(matches, current)
+	}
+
+	return matches
+}
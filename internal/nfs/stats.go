@@ -0,0 +1,67 @@
+package nfs
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// ScanStats describes the cost of one scan of the NFS root for a date
+type ScanStats struct {
+	Date        string        `json:"date"`
+	Cached      bool          `json:"cached"` // served from the scan cache; only Workflows is set
+	Sources     int           `json:"sources"`
+	Workflows   int           `json:"workflows"`
+	FilesOpened int64         `json:"files_opened"`
+	BytesRead   int64         `json:"bytes_read"` // read from disk, before decompression
+	Duration    time.Duration `json:"duration"`
+}
+
+// String formats the stats for the scan log
+func (st ScanStats) String() string {
+	if st.Cached {
+		return fmt.Sprintf("%d workflows (cached)", st.Workflows)
+	}
+	return fmt.Sprintf("%d sources, %d workflows, %d files opened, %d bytes read in %v",
+		st.Sources, st.Workflows, st.FilesOpened, st.BytesRead, st.Duration.Round(time.Millisecond))
+}
+
+// scanCounters accumulates file I/O across the concurrent workers of one scan
+type scanCounters struct {
+	filesOpened atomic.Int64
+	bytesRead   atomic.Int64
+
+	// openFiles is how many files are open now, peakOpenFiles the most at once
+	openFiles     atomic.Int64
+	peakOpenFiles atomic.Int64
+}
+
+// opened counts a newly opened file
+func (c *scanCounters) opened() {
+	c.filesOpened.Add(1)
+	open := c.openFiles.Add(1)
+	for {
+		peak := c.peakOpenFiles.Load()
+		if open <= peak || c.peakOpenFiles.CompareAndSwap(peak, open) {
+			return
+		}
+	}
+}
+
+// countingReader adds the bytes read through it to a scan's counters
+type countingReader struct {
+	io.ReadCloser
+	counters *scanCounters
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.counters.bytesRead.Add(int64(n))
+	return n, err
+}
+
+func (c *countingReader) Close() error {
+	c.counters.openFiles.Add(-1)
+	return c.ReadCloser.Close()
+}
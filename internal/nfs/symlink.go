@@ -0,0 +1,81 @@
+package nfs
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"salam-monitoring/internal/logger"
+)
+
+// WithFollowSymlinks makes scans treat symlinks to directories as source and
+// workflow directories. os.ReadDir reports a symlink as a non-directory, so
+// without this symlinked sources, such as other mounts, are skipped.
+func WithFollowSymlinks(follow bool) ScannerOption {
+	return func(s *Scanner) {
+		s.followSymlinks = follow
+	}
+}
+
+// listDirectories returns the names of the directories in dir. With
+// followSymlinks, symlinks resolving to directories are included too, unless
+// they lead back to dir, the NFS root or a directory already listed, which
+// guards against loops and scanning the same directory twice.
+func (s *Scanner) listDirectories(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	visited := make(map[dirID]bool)
+	if s.followSymlinks {
+		for _, path := range []string{s.nfsRoot, dir} {
+			if info, err := os.Stat(path); err == nil {
+				if id, ok := dirIDOf(path, info); ok {
+					visited[id] = true
+				}
+			}
+		}
+		// Real directories first, so a link to one of them counts as the duplicate
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			if info, err := entry.Info(); err == nil {
+				if id, ok := dirIDOf(filepath.Join(dir, entry.Name()), info); ok {
+					visited[id] = true
+				}
+			}
+		}
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, entry.Name())
+			continue
+		}
+		if !s.followSymlinks || entry.Type()&fs.ModeSymlink == 0 {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(path)
+		if err != nil {
+			logger.Error("Skipping unreadable symlink %s: %v", path, err)
+			continue
+		}
+		if !info.IsDir() {
+			continue
+		}
+		if id, ok := dirIDOf(path, info); ok {
+			if visited[id] {
+				logger.Info("Skipping symlink %s: its target is already scanned", path)
+				continue
+			}
+			visited[id] = true
+		}
+		dirs = append(dirs, entry.Name())
+	}
+	return dirs, nil
+}
@@ -0,0 +1,25 @@
+//go:build !unix
+
+package nfs
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// dirID identifies a directory independently of the path used to reach it
+type dirID string
+
+// dirIDOf returns the absolute path of the directory at path with every
+// symlink resolved, standing in for the inode on platforms without one
+func dirIDOf(path string, info os.FileInfo) (dirID, bool) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", false
+	}
+	resolved, err = filepath.Abs(resolved)
+	if err != nil {
+		return "", false
+	}
+	return dirID(resolved), true
+}
@@ -0,0 +1,22 @@
+//go:build unix
+
+package nfs
+
+import (
+	"os"
+	"syscall"
+)
+
+// dirID identifies a directory independently of the path used to reach it
+type dirID struct {
+	dev, ino uint64
+}
+
+// dirIDOf returns the device and inode number of the directory at path
+func dirIDOf(path string, info os.FileInfo) (dirID, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return dirID{}, false
+	}
+	return dirID{dev: uint64(stat.Dev), ino: uint64(stat.Ino)}, true
+}
@@ -0,0 +1,128 @@
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status is a point-in-time view of a background task's health
+type Status struct {
+	Name            string        `json:"name"`
+	Interval        time.Duration `json:"-"`
+	IntervalSeconds int64         `json:"interval_seconds"`
+	LastRun         time.Time     `json:"last_run"`
+	LastError       string        `json:"last_error,omitempty"`
+	Runs            int64         `json:"runs"`
+	Stale           bool          `json:"stale"`
+}
+
+// Summary renders the status as a short human-readable line, e.g. "ok, last run 12s ago"
+func (s Status) Summary() string {
+	state := "ok"
+	if s.Stale {
+		state = "stale"
+	} else if s.LastError != "" {
+		state = "error"
+	}
+
+	if s.LastRun.IsZero() {
+		return fmt.Sprintf("%s, never run", state)
+	}
+	return fmt.Sprintf("%s, last run %s ago", state, time.Since(s.LastRun).Truncate(time.Second))
+}
+
+// task holds the mutable state for one registered background task
+type task struct {
+	interval   time.Duration
+	registered time.Time
+	lastRun    time.Time
+	lastError  error
+	runs       int64
+}
+
+// Registry tracks the liveness of background goroutines such as pollers and watchers
+type Registry struct {
+	mu    sync.RWMutex
+	tasks map[string]*task
+}
+
+// NewRegistry creates an empty task registry
+func NewRegistry() *Registry {
+	return &Registry{tasks: make(map[string]*task)}
+}
+
+// Register adds a task that is expected to run every interval
+func (r *Registry) Register(name string, interval time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tasks[name] = &task{interval: interval, registered: time.Now()}
+}
+
+// Report records a completed run of a task and its error, if any
+func (r *Registry) Report(name string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tasks[name]
+	if !ok {
+		t = &task{registered: time.Now()}
+		r.tasks[name] = t
+	}
+	t.lastRun = time.Now()
+	t.lastError = err
+	t.runs++
+}
+
+// Snapshot returns the status of every registered task sorted by name.
+// A task is stale when it has not run for twice its expected interval.
+func (r *Registry) Snapshot() []Status {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	statuses := make([]Status, 0, len(r.tasks))
+	for name, t := range r.tasks {
+		status := Status{
+			Name:            name,
+			Interval:        t.interval,
+			IntervalSeconds: int64(t.interval / time.Second),
+			LastRun:         t.lastRun,
+			Runs:            t.runs,
+		}
+		if t.lastError != nil {
+			status.LastError = t.lastError.Error()
+		}
+
+		if t.interval > 0 {
+			reference := t.lastRun
+			if reference.IsZero() {
+				reference = t.registered
+			}
+			status.Stale = now.Sub(reference) > 2*t.interval
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// Healthy reports whether no registered task is stale
+func (r *Registry) Healthy() bool {
+	return Healthy(r.Snapshot())
+}
+
+// Healthy reports whether none of statuses is stale, so a caller that renders a
+// snapshot can judge health from the same snapshot
+func Healthy(statuses []Status) bool {
+	for _, status := range statuses {
+		if status.Stale {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"salam-monitoring/internal/alert"
+	"salam-monitoring/internal/informatica"
+	"salam-monitoring/internal/yarn"
+)
+
+// informaticaAlertTask is the task registry name of the failed-workflow poller
+const informaticaAlertTask = "informatica-alerts"
+
+// staleAppAlertTask is the task registry name of the stale Yarn application monitor
+const staleAppAlertTask = "yarn-stale-alerts"
+
+// startAlerts starts the failed-workflow poller when an alert webhook is configured
+// and the stale application monitor when an SMTP relay is
+func (s *Server) startAlerts() {
+	cfg := s.currentConfig().Alerts
+
+	if cfg.WebhookURL != "" {
+		interval := time.Duration(cfg.PollInterval) * time.Second
+		poller := alert.NewWorkflowFailurePoller(s.failedWorkflowsToday,
+			alert.NewWebhookNotifier(cfg.WebhookURL), interval, cfg.Workflows)
+		s.tasks.Register(informaticaAlertTask, interval)
+		go poller.Run(context.Background(), func(err error) {
+			s.tasks.Report(informaticaAlertTask, err)
+		})
+	}
+
+	if cfg.SMTP.Host != "" {
+		interval := time.Duration(cfg.StaleAppInterval) * time.Second
+		monitor := alert.NewStaleAppMonitor(s.staleApplications, alert.NewEmailNotifier(alert.EmailConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+			To:       cfg.SMTP.To,
+		}), interval, time.Duration(cfg.StaleAppThreshold)*time.Minute)
+		s.tasks.Register(staleAppAlertTask, interval)
+		go monitor.Run(context.Background(), func(err error) {
+			s.tasks.Report(staleAppAlertTask, err)
+		})
+	}
+}
+
+// failedWorkflowsToday queries the active Informatica client, which a config
+// reload may have replaced since the poller started
+func (s *Server) failedWorkflowsToday(ctx context.Context) ([]informatica.WorkflowStat, error) {
+	infClient := s.currentInfClient()
+	if infClient == nil {
+		return nil, errors.New("informatica client not initialized")
+	}
+	return infClient.GetFailedWorkflowsToday(ctx)
+}
+
+// staleApplications queries the active Yarn client
+func (s *Server) staleApplications(ctx context.Context, maxDuration time.Duration) ([]*yarn.Application, error) {
+	return s.currentYarnClient().GetStaleApplicationsContext(ctx, maxDuration)
+}
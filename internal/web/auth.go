@@ -0,0 +1,71 @@
+package web
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"salam-monitoring/internal/logger"
+)
+
+// publicPaths stay reachable without credentials so probes and static assets keep working
+var publicPaths = []string{"/health", "/healthz", "/readyz", "/static", "/favicon.ico"}
+
+// authEnabled reports whether basic authentication has been configured. A user
+// is enough: Validate requires a password or hash alongside it, and without one
+// checkCredentials rejects every request rather than leaving the server open.
+func (s *Server) authEnabled() bool {
+	return s.currentConfig().Server.AuthUser != ""
+}
+
+// authMiddleware requires HTTP basic authentication for everything except publicPaths,
+// and lets every request through while authentication is not configured
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.authEnabled() || isPublicPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		user, password, ok := r.BasicAuth()
+		if !ok || !s.checkCredentials(user, password) {
+			if ok {
+				logger.Error("Rejected credentials for user %q from %s", user, r.RemoteAddr)
+			}
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Basic realm=%q, charset="UTF-8"`, s.currentConfig().Server.BrandName+" Monitoring"))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkCredentials compares the supplied credentials against the configured user
+// and its bcrypt hash or plain password; with neither configured nothing matches
+func (s *Server) checkCredentials(user, password string) bool {
+	cfg := s.currentConfig().Server
+	userOK := subtle.ConstantTimeCompare([]byte(user), []byte(cfg.AuthUser)) == 1
+
+	var passwordOK bool
+	switch {
+	case cfg.AuthPasswordHash != "":
+		passwordOK = bcrypt.CompareHashAndPassword([]byte(cfg.AuthPasswordHash), []byte(password)) == nil
+	case cfg.AuthPassword != "":
+		passwordOK = subtle.ConstantTimeCompare([]byte(password), []byte(cfg.AuthPassword)) == 1
+	}
+	return userOK && passwordOK
+}
+
+// isPublicPath reports whether a request path bypasses authentication
+func isPublicPath(path string) bool {
+	for _, public := range publicPaths {
+		if path == public || strings.HasPrefix(path, public+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"salam-monitoring/internal/config"
+)
+
+// serve sends a request through the server's full middleware chain
+func serve(s *Server, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, r)
+	return w
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("hashed-secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []struct {
+		name      string
+		configure func(*config.Config)
+		password  string
+	}{
+		{"plain password", func(cfg *config.Config) {
+			cfg.Server.AuthUser, cfg.Server.AuthPassword = "admin", "secret"
+		}, "secret"},
+		{"bcrypt hash", func(cfg *config.Config) {
+			cfg.Server.AuthUser, cfg.Server.AuthPasswordHash = "admin", string(hash)
+		}, "hashed-secret"},
+	} {
+		t.Run(mode.name, func(t *testing.T) {
+			s := newTestServer(t, mode.configure)
+
+			tests := []struct {
+				name           string
+				user, password string
+				noAuth         bool
+				want           int
+			}{
+				{name: "correct credentials", user: "admin", password: mode.password, want: http.StatusOK},
+				{name: "wrong password", user: "admin", password: "wrong", want: http.StatusUnauthorized},
+				{name: "wrong user", user: "root", password: mode.password, want: http.StatusUnauthorized},
+				{name: "missing credentials", noAuth: true, want: http.StatusUnauthorized},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					r := httptest.NewRequest(http.MethodGet, "/api/informatica/workflows", nil)
+					r.Header.Set("Accept", "application/json")
+					if !tt.noAuth {
+						r.SetBasicAuth(tt.user, tt.password)
+					}
+					w := serve(s, r)
+					if w.Code != tt.want {
+						t.Fatalf("got status %d, want %d", w.Code, tt.want)
+					}
+					challenge := w.Header().Get("WWW-Authenticate")
+					if tt.want == http.StatusUnauthorized && !strings.HasPrefix(challenge, "Basic realm=") {
+						t.Errorf("got WWW-Authenticate %q, want a Basic challenge", challenge)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareUserWithoutPassword(t *testing.T) {
+	// Validate rejects this configuration; the middleware must still fail closed
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.Server.AuthUser = "admin"
+	})
+
+	for _, password := range []string{"", "anything"} {
+		r := httptest.NewRequest(http.MethodGet, "/api/informatica/workflows", nil)
+		r.Header.Set("Accept", "application/json")
+		r.SetBasicAuth("admin", password)
+		if w := serve(s, r); w.Code != http.StatusUnauthorized {
+			t.Errorf("password %q: got status %d, want 401", password, w.Code)
+		}
+	}
+}
+
+func TestAuthMiddlewarePublicPaths(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.Server.AuthUser, cfg.Server.AuthPassword = "admin", "secret"
+	})
+
+	for _, path := range []string{"/health", "/healthz", "/readyz", "/static/app.js", "/favicon.ico"} {
+		t.Run(path, func(t *testing.T) {
+			w := serve(s, httptest.NewRequest(http.MethodGet, path, nil))
+			if w.Code == http.StatusUnauthorized {
+				t.Errorf("%s required credentials", path)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareDisabled(t *testing.T) {
+	s := newTestServer(t, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/informatica/workflows", nil)
+	r.Header.Set("Accept", "application/json")
+	if w := serve(s, r); w.Code != http.StatusOK {
+		t.Errorf("got status %d without auth configured, want 200", w.Code)
+	}
+}
@@ -0,0 +1,110 @@
+package web
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"salam-monitoring/internal/config"
+	"salam-monitoring/internal/informatica"
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/nfs"
+	"salam-monitoring/internal/yarn"
+)
+
+// The constructors below build each backend client from config. The server, a
+// config reload and the CLI commands all use them, so a setting added here
+// reaches every caller.
+
+// NewInformaticaClient connects to the repository database in prod mode; test
+// mode points at a placeholder server so the client serves mock data
+func NewInformaticaClient(cfg *config.Config) (*informatica.Client, error) {
+	if !cfg.IsProdMode() {
+		return informatica.NewClient(informatica.DatabaseConfig{
+			Host:       "localhost",
+			Port:       1433,
+			Database:   "INFORMATICA_TEST",
+			Username:   "test",
+			Password:   "test",
+			TimeOffset: 3,
+
+			DayBoundary:  cfg.DayBoundary(),
+			WorkflowSLAs: cfg.WorkflowSLAs(),
+		})
+	}
+
+	db := cfg.Services.InformaticaDB
+	return informatica.NewClient(informatica.DatabaseConfig{
+		DBType:     db.DBType,
+		Host:       db.Host,
+		Port:       db.Port,
+		Database:   db.Database,
+		Username:   db.Username,
+		Password:   db.Password,
+		TimeOffset: db.TimeOffset,
+		Timezone:   db.Timezone,
+
+		DayBoundary:  cfg.DayBoundary(),
+		WorkflowSLAs: cfg.WorkflowSLAs(),
+
+		MaxOpenConns:    db.MaxOpenConns,
+		MaxIdleConns:    db.MaxIdleConns,
+		ConnMaxLifetime: time.Duration(db.ConnMaxLifetime) * time.Second,
+	})
+}
+
+// NewNFSScanner creates a scanner over the NFS root with the scanner settings
+// from config; opts are applied after them, e.g. to observe scans. With
+// scheduled scans today's results stay cached until the next scan replaces them.
+func NewNFSScanner(cfg *config.Config, opts ...nfs.ScannerOption) *nfs.Scanner {
+	todayCacheTTL := time.Duration(cfg.NFS.CacheTTL) * time.Second
+	if scanInterval := time.Duration(cfg.NFS.ScanInterval) * time.Minute; scanInterval > todayCacheTTL {
+		todayCacheTTL = scanInterval
+	}
+	sourceLogRoles := make(map[string]nfs.LogRoles, len(cfg.NFS.SourceLogRoles))
+	for source, roles := range cfg.NFS.SourceLogRoles {
+		sourceLogRoles[source] = nfs.LogRoles{Completion: roles.CompletionLog, Error: roles.ErrorLog}
+	}
+	options := []nfs.ScannerOption{
+		nfs.WithErrorPatterns(cfg.NFS.ErrorPatterns),
+		nfs.WithErrorPatternsIgnoreCase(cfg.NFS.ErrorPatternsIgnoreCase),
+		nfs.WithScanWorkers(cfg.NFS.ScanWorkers),
+		nfs.WithMaxOpenFiles(cfg.NFS.MaxOpenFiles),
+		nfs.WithFollowSymlinks(cfg.NFS.FollowSymlinks),
+		nfs.WithLogFiles(cfg.NFS.LogFiles, cfg.NFS.SourceLogFiles),
+		nfs.WithLogRoles(nfs.LogRoles{Completion: cfg.NFS.CompletionLog, Error: cfg.NFS.ErrorLog}, sourceLogRoles),
+		nfs.WithTimestampLayout(cfg.NFS.TimestampLayout),
+		nfs.WithDayBoundary(cfg.DayBoundary()),
+		nfs.WithCacheTTL(todayCacheTTL, time.Duration(cfg.NFS.PastCacheTTL)*time.Second),
+	}
+	return nfs.NewScanner(cfg.GetNFSRoot(), append(options, opts...)...)
+}
+
+// NewYarnClient creates the Yarn client for the RM URLs of the current mode with
+// the timeout, retry policy and Kerberos settings from config; onKill, when set,
+// is called for every kill the client makes
+func NewYarnClient(cfg *config.Config, onKill func(context.Context, yarn.KillEvent)) *yarn.Client {
+	policy := yarn.DefaultRetryPolicy
+	policy.MaxAttempts = cfg.Services.YarnRetryAttempts
+	policy.BaseDelay = time.Duration(cfg.Services.YarnRetryDelayMs) * time.Millisecond
+	options := yarn.ClientOptions{
+		Timeout: time.Duration(cfg.Services.YarnTimeout) * time.Second,
+		Retry:   &policy,
+		OnKill:  onKill,
+	}
+	if cfg.Services.YarnKerberos.Enabled {
+		krb := cfg.Services.YarnKerberos
+		options.Kerberos = &yarn.KerberosConfig{
+			KeytabPath: krb.Keytab,
+			Principal:  krb.Principal,
+			Realm:      krb.Realm,
+			SPN:        krb.SPN,
+			Krb5Conf:   krb.Krb5Conf,
+		}
+	}
+
+	yarnURLs := cfg.GetYarnURLs()
+	client := yarn.NewClientWithOptions(yarnURLs, options)
+	logger.Info("Yarn client initialized for RM: %s", strings.Join(yarnURLs, ", "))
+	return client
+}
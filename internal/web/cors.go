@@ -0,0 +1,96 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+)
+
+// corsPaths are the path prefixes that accept cross-origin requests: the JSON and
+// HTMX API and the Informatica JSON routes. Pages and static files stay same-origin.
+var corsPaths = []string{"/api/", "/informatica/"}
+
+const (
+	corsAllowMethods  = "GET, POST, OPTIONS"
+	corsAllowHeaders  = "Accept, Authorization, Content-Type, X-Request-ID"
+	corsExposeHeaders = "X-Request-ID, X-Total-Count"
+	corsMaxAge        = "600"
+)
+
+// corsMiddleware adds CORS headers for origins listed in cors_allowed_origins and
+// answers preflight requests. With no origins configured nothing is added, so
+// browsers keep the default same-origin policy. It wraps the router rather than
+// being registered on it because the router rejects OPTIONS before middleware runs.
+func (s *Server) corsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !isCORSPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		allowOrigin := s.corsAllowOrigin(origin)
+		allowed := allowOrigin != ""
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		if allowed {
+			w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
+			w.Header().Set("Access-Control-Expose-Headers", corsExposeHeaders)
+			// Credentials are only shared with origins listed by name, never with "*"
+			if allowOrigin != "*" && s.authEnabled() {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+		}
+
+		if preflight {
+			if !allowed {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Methods", corsAllowMethods)
+			w.Header().Set("Access-Control-Allow-Headers", corsAllowHeaders)
+			w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// corsAllowOrigin returns the Access-Control-Allow-Origin value for origin: the
+// origin itself when it is listed, a literal "*" when only the wildcard matches,
+// and "" when it is not allowed. Validate rejects the wildcard while basic
+// authentication is configured.
+func (s *Server) corsAllowOrigin(origin string) string {
+	if s.corsOriginListed(origin) {
+		return origin
+	}
+	for _, allowed := range s.currentConfig().Server.CORSAllowedOrigins {
+		if allowed == "*" {
+			return "*"
+		}
+	}
+	return ""
+}
+
+// corsOriginListed reports whether origin is named in cors_allowed_origins; the
+// wildcard does not count
+func (s *Server) corsOriginListed(origin string) bool {
+	for _, allowed := range s.currentConfig().Server.CORSAllowedOrigins {
+		if allowed != "*" && strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCORSPath reports whether a request path is covered by corsMiddleware
+func isCORSPath(path string) bool {
+	for _, prefix := range corsPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
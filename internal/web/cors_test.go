@@ -0,0 +1,57 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"salam-monitoring/internal/config"
+)
+
+// corsRequest sends a cross-origin API request from origin through the full handler
+func corsRequest(s *Server, origin string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/api/informatica/workflows", nil)
+	r.Header.Set("Accept", "application/json")
+	r.Header.Set("Origin", origin)
+	r.SetBasicAuth("admin", "secret")
+	w := httptest.NewRecorder()
+	s.corsMiddleware(s.router).ServeHTTP(w, r)
+	return w
+}
+
+func TestCORSListedOrigin(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.Server.AuthUser, cfg.Server.AuthPassword = "admin", "secret"
+		cfg.Server.CORSAllowedOrigins = []string{"https://portal.example.com"}
+	})
+
+	w := corsRequest(s, "https://portal.example.com")
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://portal.example.com" {
+		t.Errorf("got Allow-Origin %q, want the listed origin", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("got Allow-Credentials %q, want true for a listed origin with auth", got)
+	}
+
+	w = corsRequest(s, "https://evil.example.com")
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("got Allow-Origin %q for an unlisted origin, want none", got)
+	}
+}
+
+func TestCORSWildcardWithoutCredentials(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.Server.CORSAllowedOrigins = []string{"*"}
+	})
+
+	w := corsRequest(s, "https://evil.example.com")
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("got Allow-Origin %q, want a literal *", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("got Allow-Credentials %q, want none for the wildcard", got)
+	}
+	if s.corsOriginListed("https://evil.example.com") {
+		t.Error("the wildcard must not admit WebSocket origins")
+	}
+}
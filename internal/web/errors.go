@@ -0,0 +1,50 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"salam-monitoring/internal/logger"
+)
+
+// Error codes returned in the JSON error envelope. They are part of the API:
+// automation branches on them, so existing values must not change.
+const (
+	errCodeInvalidParameter       = "invalid_parameter"
+	errCodeInvalidStatID          = "invalid_stat_id"
+	errCodeUnsupportedMediaType   = "unsupported_media_type"
+	errCodeWorkflowNotFound       = "workflow_not_found"
+	errCodeApplicationNotFound    = "application_not_found"
+	errCodeInformaticaUnavailable = "informatica_unavailable"
+	errCodeInformaticaFailed      = "informatica_query_failed"
+	errCodeYarnUnavailable        = "yarn_unavailable"
+	errCodeYarnFailed             = "yarn_request_failed"
+	errCodeNFSUnavailable         = "nfs_unavailable"
+	errCodeNFSFailed              = "nfs_scan_failed"
+	errCodeSourceNotFound         = "source_not_found"
+	errCodeLogNotFound            = "log_not_found"
+	errCodeHistoryUnavailable     = "history_unavailable"
+	errCodeHistoryFailed          = "history_query_failed"
+	errCodeRateLimited            = "rate_limited"
+	errCodeRequestTimeout         = "request_timeout"
+)
+
+// jsonError is the envelope for JSON error responses:
+// {"error":{"code":"...","message":"..."}}
+type jsonError struct {
+	Error jsonErrorBody `json:"error"`
+}
+
+type jsonErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeJSONError writes a JSON error envelope with the given status
+func writeJSONError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(jsonError{Error: jsonErrorBody{Code: code, Message: message}}); err != nil {
+		logger.LogError("Failed to encode JSON error response", err)
+	}
+}
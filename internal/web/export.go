@@ -0,0 +1,515 @@
+package web
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"salam-monitoring/internal/informatica"
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/nfs"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// exportFlushRows is how many CSV rows are buffered before flushing to the client
+const exportFlushRows = 100
+
+// nfsExportColumns is the CSV header of /api/nfs/export
+var nfsExportColumns = []string{"source", "workflow", "status", "has_errors", "log_count", "newest_mod_time"}
+
+// handleNFSExport downloads the workflow summaries of a date (today by default) as
+// CSV or JSON. The source and status filters of /api/nfs/logs apply. Rows are
+// written one at a time so a large day is never held twice in memory.
+func (s *Server) handleNFSExport(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling NFS export request")
+
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "format must be csv or json")
+		return
+	}
+
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		date = s.currentConfig().DayBoundary().Today()
+	} else if _, err := time.Parse("2006-01-02", date); err != nil {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "date must be YYYY-MM-DD")
+		return
+	}
+
+	if s.nfsScanner == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeNFSUnavailable, "NFS scanner not available")
+		return
+	}
+
+	query := r.URL.Query()
+	query.Set("date", date)
+	r.URL.RawQuery = query.Encode()
+	workflows, err := s.fetchNFSWorkflows(r)
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to scan NFS logs for export", err)
+		writeJSONError(w, fetchErrorStatus(err), errCodeNFSFailed, fmt.Sprintf("Failed to scan NFS logs: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Disposition", attachment(fmt.Sprintf("nfs-workflows-%s.%s", date, format)))
+	if format == "json" {
+		streamNFSExportJSON(w, workflows)
+		return
+	}
+	streamNFSExportCSV(w, workflows)
+}
+
+// streamNFSExportCSV writes one CSV row per workflow, flushing periodically
+func streamNFSExportCSV(w http.ResponseWriter, workflows []*nfs.WorkflowSummary) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	flusher, _ := w.(http.Flusher)
+
+	cw := csv.NewWriter(w)
+	cw.Write(nfsExportColumns)
+	for i, wf := range workflows {
+		newest := ""
+		if t := newestModTime(wf); !t.IsZero() {
+			newest = t.Format(time.RFC3339)
+		}
+		cw.Write([]string{
+			wf.Source,
+			wf.Workflow,
+			wf.Status,
+			strconv.FormatBool(wf.HasErrors),
+			strconv.Itoa(len(wf.Logs)),
+			newest,
+		})
+		if (i+1)%exportFlushRows == 0 {
+			cw.Flush()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		logger.LogError("Failed to write NFS CSV export", err)
+	}
+}
+
+// nfsExportRow is one workflow in the JSON export, with the same fields as the CSV
+type nfsExportRow struct {
+	Source        string     `json:"source"`
+	Workflow      string     `json:"workflow"`
+	Status        string     `json:"status"`
+	HasErrors     bool       `json:"has_errors"`
+	LogCount      int        `json:"log_count"`
+	NewestModTime *time.Time `json:"newest_mod_time"`
+}
+
+// streamNFSExportJSON writes the workflows as a JSON array, encoding one element at a time
+func streamNFSExportJSON(w http.ResponseWriter, workflows []*nfs.WorkflowSummary) {
+	w.Header().Set("Content-Type", "application/json")
+
+	fmt.Fprint(w, "[")
+	for i, wf := range workflows {
+		if i > 0 {
+			fmt.Fprint(w, ",\n")
+		}
+		row := nfsExportRow{
+			Source:    wf.Source,
+			Workflow:  wf.Workflow,
+			Status:    wf.Status,
+			HasErrors: wf.HasErrors,
+			LogCount:  len(wf.Logs),
+		}
+		if t := newestModTime(wf); !t.IsZero() {
+			row.NewestModTime = &t
+		}
+		line, err := json.Marshal(row)
+		if err != nil {
+			logger.LogError("Failed to encode NFS JSON export row", err)
+			return
+		}
+		if _, err := w.Write(line); err != nil {
+			logger.LogError("Failed to write NFS JSON export", err)
+			return
+		}
+	}
+	fmt.Fprint(w, "]\n")
+}
+
+// newestModTime returns the latest modification time of a workflow's logs, or
+// the zero time when it has none
+func newestModTime(wf *nfs.WorkflowSummary) time.Time {
+	var newest time.Time
+	for _, log := range wf.Logs {
+		if log.ModTime.After(newest) {
+			newest = log.ModTime
+		}
+	}
+	return newest
+}
+
+// informaticaExportColumns is the header row of /informatica/workflows/export
+var informaticaExportColumns = []string{"stat_id", "workflow_name", "status", "started_at", "finished_at", "duration"}
+
+// exportTimeLayout formats start and end times in exports
+const exportTimeLayout = "2006-01-02 15:04:05"
+
+// handleInformaticaExport downloads workflows as CSV or XLSX: today's by default,
+// or those started between the optional from and to parameters (same formats and
+// limits as /informatica/workflows)
+func (s *Server) handleInformaticaExport(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Informatica export request")
+
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "xlsx" {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "format must be csv or xlsx")
+		return
+	}
+
+	infClient := s.currentInfClient()
+	if infClient == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeInformaticaUnavailable, "Informatica client not available")
+		return
+	}
+
+	query := r.URL.Query()
+	var workflows []informatica.WorkflowStat
+	var err error
+	period := infClient.Today()
+	switch {
+	case query.Get("from") != "" || query.Get("to") != "":
+		if query.Get("from") == "" || query.Get("to") == "" {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "from and to must be given together")
+			return
+		}
+		from, to, rangeErr := parseWorkflowRange(query.Get("from"), query.Get("to"), infClient.Location())
+		if rangeErr != nil {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, rangeErr.Error())
+			return
+		}
+		period = from.Format("2006-01-02") + "_" + to.Format("2006-01-02")
+		workflows, err = infClient.GetWorkflowsBetween(r.Context(), from, to)
+	default:
+		workflows, err = infClient.GetWorkflowsToday(r.Context())
+	}
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get Informatica workflows for export", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeInformaticaFailed, "Failed to get workflows")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", attachment(fmt.Sprintf("informatica-workflows-%s.%s", period, format)))
+	if format == "xlsx" {
+		writeInformaticaExportXLSX(w, workflows)
+		return
+	}
+	writeInformaticaExportCSV(w, workflows)
+}
+
+// informaticaExportRow returns the export cells of a workflow; a running
+// workflow has no finish time
+func informaticaExportRow(wf informatica.WorkflowStat) []string {
+	finished := ""
+	if wf.FinishedAt != nil {
+		finished = wf.FinishedAt.Format(exportTimeLayout)
+	}
+	return []string{
+		strconv.FormatInt(wf.StatID, 10),
+		wf.WorkflowName,
+		wf.Status,
+		wf.StartedAt.Format(exportTimeLayout),
+		finished,
+		wf.Elapsed.String(),
+	}
+}
+
+// writeInformaticaExportCSV writes one CSV row per workflow
+func writeInformaticaExportCSV(w http.ResponseWriter, workflows []informatica.WorkflowStat) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	cw := csv.NewWriter(w)
+	cw.Write(informaticaExportColumns)
+	for _, wf := range workflows {
+		cw.Write(informaticaExportRow(wf))
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		logger.LogError("Failed to write Informatica CSV export", err)
+	}
+}
+
+// exportStatusFills are the XLSX cell colors for workflow statuses
+var exportStatusFills = map[string]string{
+	"SUCCESS": "C6EFCE",
+	"FAILED":  "FFC7CE",
+	"RUNNING": "DDEBF7",
+}
+
+// writeInformaticaExportXLSX writes the workflows as a single-sheet workbook with a
+// bold header row and status cells colored by outcome
+func writeInformaticaExportXLSX(w http.ResponseWriter, workflows []informatica.WorkflowStat) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	const sheet = "Workflows"
+	f.SetSheetName("Sheet1", sheet)
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "FFFFFF"},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4F46E5"}},
+	})
+	if err != nil {
+		logger.LogError("Failed to create XLSX header style", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeInformaticaFailed, "Failed to build workbook")
+		return
+	}
+	statusStyles := make(map[string]int, len(exportStatusFills))
+	for status, color := range exportStatusFills {
+		style, err := f.NewStyle(&excelize.Style{
+			Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{color}},
+		})
+		if err != nil {
+			logger.LogError("Failed to create XLSX status style", err)
+			writeJSONError(w, http.StatusInternalServerError, errCodeInformaticaFailed, "Failed to build workbook")
+			return
+		}
+		statusStyles[status] = style
+	}
+
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		logger.LogError("Failed to create XLSX stream writer", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeInformaticaFailed, "Failed to build workbook")
+		return
+	}
+	sw.SetColWidth(2, 2, 40)
+	sw.SetColWidth(4, 6, 20)
+
+	header := make([]interface{}, len(informaticaExportColumns))
+	for i, column := range informaticaExportColumns {
+		header[i] = excelize.Cell{StyleID: headerStyle, Value: column}
+	}
+	sw.SetRow("A1", header)
+
+	for i, wf := range workflows {
+		cells := informaticaExportRow(wf)
+		row := make([]interface{}, len(cells))
+		for j, value := range cells {
+			row[j] = value
+		}
+		row[0] = wf.StatID
+		row[2] = excelize.Cell{StyleID: statusStyles[wf.Status], Value: wf.Status}
+
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		if err := sw.SetRow(cell, row); err != nil {
+			logger.LogError("Failed to write XLSX row", err)
+			writeJSONError(w, http.StatusInternalServerError, errCodeInformaticaFailed, "Failed to build workbook")
+			return
+		}
+	}
+	if err := sw.Flush(); err != nil {
+		logger.LogError("Failed to finish XLSX sheet", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeInformaticaFailed, "Failed to build workbook")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	if _, err := f.WriteTo(w); err != nil {
+		logger.LogError("Failed to write Informatica XLSX export", err)
+	}
+}
+
+// handleNFSDownload streams a log file under the NFS root as an attachment.
+// Compressed logs are sent as-is unless decompress=true, which inflates them
+// on the fly; uncompressed files support range requests.
+func (s *Server) handleNFSDownload(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling NFS download request")
+
+	filePath := r.URL.Query().Get("path")
+	if filePath == "" {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "path parameter is required")
+		return
+	}
+	if s.nfsScanner == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeNFSUnavailable, "NFS scanner not available")
+		return
+	}
+
+	path, err := s.nfsScanner.ResolveLogPath(filePath)
+	if errors.Is(err, nfs.ErrOutsideRoot) {
+		writeJSONError(w, http.StatusForbidden, errCodeInvalidParameter, "path is not under the NFS root")
+		return
+	}
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to resolve NFS log path", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeNFSFailed, "Failed to read log file")
+		return
+	}
+
+	name := filepath.Base(path)
+	compressed := strings.HasSuffix(name, ".gz")
+	if compressed && r.URL.Query().Get("decompress") == "true" {
+		file, err := s.nfsScanner.OpenLog(path)
+		if err != nil {
+			writeNFSDownloadError(w, r, err)
+			return
+		}
+		defer file.Close()
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Disposition", attachment(strings.TrimSuffix(name, ".gz")))
+		if _, err := io.Copy(w, file); err != nil {
+			logger.LogErrorCtx(r.Context(), "Failed to send decompressed NFS log", err)
+		}
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		writeNFSDownloadError(w, r, err)
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		writeJSONError(w, http.StatusNotFound, errCodeLogNotFound, "Log file not found")
+		return
+	}
+
+	if compressed {
+		w.Header().Set("Content-Type", "application/gzip")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	w.Header().Set("Content-Disposition", attachment(name))
+	http.ServeContent(w, r, name, info.ModTime(), file)
+}
+
+// attachment returns a Content-Disposition value offering name as a download.
+// The name is quoted and escaped as needed, and non-ASCII names are encoded
+// per RFC 2231, so a log file name can't break out of the header parameter.
+func attachment(name string) string {
+	return mime.FormatMediaType("attachment", map[string]string{"filename": name})
+}
+
+// writeNFSDownloadError reports a log that could not be opened for download
+func writeNFSDownloadError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, fs.ErrNotExist) {
+		writeJSONError(w, http.StatusNotFound, errCodeLogNotFound, "Log file not found")
+		return
+	}
+	logger.LogErrorCtx(r.Context(), "Failed to open NFS log for download", err)
+	writeJSONError(w, http.StatusInternalServerError, errCodeNFSFailed, "Failed to read log file")
+}
+
+// handleNFSArchive streams every log file of one workflow run as a .tar.gz
+// named after the workflow and date, for attaching to tickets. The archive is
+// written as it is built, so nothing is buffered and large runs start
+// downloading at once.
+func (s *Server) handleNFSArchive(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling NFS archive request")
+
+	query := r.URL.Query()
+	source, date, workflow := query.Get("source"), query.Get("date"), query.Get("workflow")
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "date must be YYYY-MM-DD")
+		return
+	}
+	if s.nfsScanner == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeNFSUnavailable, "NFS scanner not available")
+		return
+	}
+
+	dir, err := s.nfsScanner.ResolveWorkflowDir(source, date, workflow)
+	if errors.Is(err, nfs.ErrInvalidSource) {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "source and workflow must be directory names")
+		return
+	}
+	if errors.Is(err, nfs.ErrOutsideRoot) {
+		writeJSONError(w, http.StatusForbidden, errCodeInvalidParameter, "workflow directory is not under the NFS root")
+		return
+	}
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to resolve NFS workflow directory", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeNFSFailed, "Failed to read workflow logs")
+		return
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		writeJSONError(w, http.StatusNotFound, errCodeWorkflowNotFound, fmt.Sprintf("No logs for workflow %s on %s", workflow, date))
+		return
+	}
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to list NFS workflow directory", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeNFSFailed, "Failed to read workflow logs")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", attachment(fmt.Sprintf("%s-%s.tar.gz", workflow, date)))
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		// The headers are sent, so a failure can only cut the archive short
+		if err := r.Context().Err(); err != nil {
+			return
+		}
+		if err := addArchiveFile(tw, filepath.Join(dir, entry.Name()), workflow+"/"+entry.Name()); err != nil {
+			logger.LogErrorCtx(r.Context(), fmt.Sprintf("Failed to archive NFS log %s", entry.Name()), err)
+			return
+		}
+	}
+	if err := tw.Close(); err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to finish NFS archive", err)
+		return
+	}
+	if err := gz.Close(); err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to finish NFS archive", err)
+	}
+}
+
+// addArchiveFile writes one file to the archive under name. Logs may still be
+// growing, so only the size seen when the header was written is copied.
+func addArchiveFile(tw *tar.Writer, path, name string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.CopyN(tw, file, header.Size)
+	return err
+}
@@ -0,0 +1,62 @@
+package web
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// downloadRequest requests the log at path through /api/nfs/download
+func downloadRequest(path string) *http.Request {
+	return httptest.NewRequest(http.MethodGet, "/api/nfs/download?path="+url.QueryEscape(path), nil)
+}
+
+func TestNFSDownloadFilename(t *testing.T) {
+	s := newTestServer(t, nil)
+	for _, name := range []string{"run.log", `run "nightly".log`, "run;x=y.log", "résumé.log"} {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join(s.currentConfig().Paths.NFSRoot, "src1", "2024-11-21", "wf1")
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte("2024-11-21 10:30:00 INFO: done\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			w := serve(s, downloadRequest(path))
+			if w.Code != http.StatusOK {
+				t.Fatalf("got status %d, want 200", w.Code)
+			}
+			disposition, params, err := mime.ParseMediaType(w.Header().Get("Content-Disposition"))
+			if err != nil {
+				t.Fatalf("unparseable Content-Disposition %q: %v", w.Header().Get("Content-Disposition"), err)
+			}
+			if disposition != "attachment" || params["filename"] != name {
+				t.Errorf("got %s with filename %q, want attachment with %q", disposition, params["filename"], name)
+			}
+		})
+	}
+}
+
+func TestNFSDownloadNotFound(t *testing.T) {
+	s := newTestServer(t, nil)
+	path := filepath.Join(s.currentConfig().Paths.NFSRoot, "src1", "2024-11-21", "wf1", "missing.log")
+
+	w := serve(s, downloadRequest(path))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("got status %d, want 404", w.Code)
+	}
+	var body jsonError
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Error.Code != errCodeLogNotFound {
+		t.Errorf("got code %q, want %q", body.Error.Code, errCodeLogNotFound)
+	}
+}
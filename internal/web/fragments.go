@@ -0,0 +1,161 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"time"
+
+	"salam-monitoring/internal/informatica"
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/yarn"
+)
+
+// fragmentPattern selects the templates for the HTML fragments HTMX swaps into
+// pages, relative to the template directory
+const fragmentPattern = "fragments/*.html"
+
+// templateFuncs are the functions available to page and fragment templates:
+//
+//	{{asset "css/styles.css"}}            /static/css/styles.css?v=<content hash>
+//	{{formatDuration .ElapsedTime}}       RM milliseconds, time.Duration or Informatica elapsed time
+//	{{formatBytes .Size}}                 byte counts as B, KB, MB or GB
+//	{{formatMemory .AllocatedMB}}         RM megabyte counts
+//	{{formatTime .StartedAt "15:04:05"}}  time.Time, *time.Time or RM epoch milliseconds
+//	{{statusClass "yarn" .State}}         badge classes for yarn, node, nfs or informatica states
+func (s *Server) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"asset":          s.static.url,
+		"formatDuration": formatDuration,
+		"formatBytes":    formatBytes,
+		"formatMemory":   yarn.FormatMemory,
+		"formatTime":     formatTimeValue,
+		"statusClass":    statusClass,
+		"firstLine":      firstLine,
+	}
+}
+
+// fragmentTemplates returns the fragment templates, re-parsed from the template
+// directory on every call in dev mode like pageTemplates
+func (s *Server) fragmentTemplates() (*template.Template, error) {
+	cfg := s.currentConfig()
+	if !cfg.Server.DevMode {
+		if s.fragments == nil {
+			return nil, fmt.Errorf("fragment templates not loaded")
+		}
+		return s.fragments, nil
+	}
+	return template.New("").Funcs(s.templateFuncs()).ParseFS(os.DirFS(cfg.Server.TemplateDir), fragmentPattern)
+}
+
+// renderFragment executes the named fragment template as the HTML response. It
+// renders into a buffer first so a template error never leaves half a fragment
+// in the page.
+func (s *Server) renderFragment(w http.ResponseWriter, name string, data interface{}) {
+	w.Header().Set("Content-Type", "text/html")
+	templates, err := s.fragmentTemplates()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to load fragment templates for %s", name), err)
+		fmt.Fprintf(w, `<div class="text-red-600">Template error</div>`)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to execute fragment template %s", name), err)
+		fmt.Fprintf(w, `<div class="text-red-600">Template error</div>`)
+		return
+	}
+	buf.WriteTo(w)
+}
+
+// formatDuration formats RM milliseconds (ElapsedTime), a time.Duration or an
+// Informatica elapsed time in the RM style, e.g. 1.5h. Unknown or negative
+// durations give N/A.
+func formatDuration(v interface{}) string {
+	var d time.Duration
+	switch v := v.(type) {
+	case time.Duration:
+		d = v
+	case int64:
+		d = time.Duration(v) * time.Millisecond
+	case int:
+		d = time.Duration(v) * time.Millisecond
+	case informatica.ElapsedTime:
+		d = v.Duration()
+	case *informatica.ElapsedTime:
+		if v == nil {
+			return "N/A"
+		}
+		d = v.Duration()
+	default:
+		return "N/A"
+	}
+	if d < 0 {
+		return "N/A"
+	}
+	return yarn.FormatDuration(d.Milliseconds())
+}
+
+// formatBytes formats a byte count with a binary unit, e.g. 1.5 KB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, suffix := float64(n)/unit, "KB"
+	for _, next := range []string{"MB", "GB", "TB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
+
+// formatTimeValue formats a time.Time, *time.Time or RM epoch-millisecond
+// timestamp with layout (2006-01-02 15:04:05 by default). Zero, nil and
+// unset timestamps give N/A.
+func formatTimeValue(v interface{}, layout ...string) string {
+	format := "2006-01-02 15:04:05"
+	if len(layout) > 0 {
+		format = layout[0]
+	}
+
+	var t time.Time
+	switch v := v.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v != nil {
+			t = *v
+		}
+	case int64:
+		if v > 0 {
+			t = time.UnixMilli(v)
+		}
+	}
+	if t.IsZero() {
+		return "N/A"
+	}
+	return t.Format(format)
+}
+
+// statusClass returns the badge classes for a status of the given kind: yarn
+// (application states), node, nfs (workflow log status) or informatica
+func statusClass(kind, status string) string {
+	switch kind {
+	case "yarn":
+		return getStateColor(status)
+	case "node":
+		return getNodeStateColor(status)
+	case "nfs":
+		return getWorkflowStatusClass(status)
+	case "informatica":
+		return getInformaticaStatusClass(status)
+	default:
+		return "bg-gray-100 text-gray-800"
+	}
+}
@@ -0,0 +1,70 @@
+package web
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"salam-monitoring/internal/informatica"
+	"salam-monitoring/internal/nfs"
+	"salam-monitoring/internal/yarn"
+)
+
+func TestRenderFragmentEscapesNames(t *testing.T) {
+	const (
+		name    = "<script>alert(1)</script>"
+		escaped = "&lt;script&gt;alert(1)&lt;/script&gt;"
+	)
+	s := newTestServer(t, nil)
+
+	tests := []struct {
+		fragment string
+		data     interface{}
+	}{
+		{"informatica-workflows", []informatica.WorkflowStat{
+			{StatID: 1, WorkflowName: name, Status: "RUNNING", StartedAt: time.Now()},
+		}},
+		{"nfs-logs", []*nfs.WorkflowSummary{
+			{Source: "src1", Date: "2024-11-21", Workflow: name, Status: "Failed", Logs: []*nfs.LogEntry{
+				{Source: "src1", Date: "2024-11-21", Workflow: name, LogType: "info.log", FilePath: "/nfs/src1/" + name},
+			}},
+		}},
+		{"yarn-apps", struct {
+			State string
+			Apps  []yarnAppRow
+		}{"RUNNING", []yarnAppRow{{Application: &yarn.Application{ID: "application_1", Name: name, State: "RUNNING"}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fragment, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.renderFragment(w, tt.fragment, tt.data)
+			body := w.Body.String()
+
+			if strings.Contains(body, "Template error") {
+				t.Fatalf("fragment failed to render: %s", body)
+			}
+			if strings.Contains(body, "<script>") {
+				t.Errorf("output contains the raw tag:\n%s", body)
+			}
+			if !strings.Contains(body, escaped) {
+				t.Errorf("output lacks the escaped name %s:\n%s", escaped, body)
+			}
+		})
+	}
+}
+
+func TestRenderFallbackHTMLEscapes(t *testing.T) {
+	s := newTestServer(t, nil)
+
+	w := httptest.NewRecorder()
+	s.renderFallbackHTML(w, "<script>alert(1)</script>", "<img src=x onerror=alert(1)>")
+	body := w.Body.String()
+
+	if strings.Contains(body, "<script>alert(1)") || strings.Contains(body, "<img src=x") {
+		t.Errorf("output contains raw markup:\n%s", body)
+	}
+	if !strings.Contains(body, "&lt;script&gt;alert(1)&lt;/script&gt;") {
+		t.Errorf("output lacks the escaped title:\n%s", body)
+	}
+}
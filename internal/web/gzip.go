@@ -0,0 +1,148 @@
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// gzipMinSize is the smallest response body worth compressing
+const gzipMinSize = 1400
+
+// compressibleTypes are the content types gzipMiddleware will compress
+var compressibleTypes = []string{"text/html", "application/json"}
+
+// gzipMiddleware compresses large HTML and JSON responses for clients that accept gzip
+func (s *Server) gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// WebSocket upgrades hijack the connection and must not be wrapped
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") || r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		gw := &gzipResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		defer gw.finish()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// gzipResponseWriter buffers the start of a response until it knows whether to
+// compress it: the content type must be compressible, the body must reach
+// gzipMinSize, and the handler must not have encoded or started streaming it.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	decided     bool
+	buf         bytes.Buffer
+	gz          *gzip.Writer
+}
+
+// WriteHeader holds the status until the compression decision is made
+func (gw *gzipResponseWriter) WriteHeader(status int) {
+	if gw.wroteHeader {
+		return
+	}
+	gw.status = status
+	gw.wroteHeader = true
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		gw.passthrough()
+	}
+}
+
+// Write buffers output until the threshold is reached, then commits to gzip or plain
+func (gw *gzipResponseWriter) Write(b []byte) (int, error) {
+	if gw.decided {
+		if gw.gz != nil {
+			return gw.gz.Write(b)
+		}
+		return gw.ResponseWriter.Write(b)
+	}
+
+	if !gw.compressible() {
+		gw.passthrough()
+		return gw.ResponseWriter.Write(b)
+	}
+
+	gw.buf.Write(b)
+	if gw.buf.Len() >= gzipMinSize {
+		if err := gw.startGzip(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Flush sends buffered data immediately; a flush before the threshold means the
+// handler is streaming, so the response is left uncompressed
+func (gw *gzipResponseWriter) Flush() {
+	if !gw.decided {
+		gw.passthrough()
+	}
+	if gw.gz != nil {
+		gw.gz.Flush()
+	}
+	if flusher, ok := gw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (gw *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return gw.ResponseWriter
+}
+
+// compressible reports whether the response headers allow compression
+func (gw *gzipResponseWriter) compressible() bool {
+	header := gw.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	contentType := header.Get("Content-Type")
+	for _, t := range compressibleTypes {
+		if strings.HasPrefix(contentType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// startGzip commits to a compressed response and writes out the buffered body
+func (gw *gzipResponseWriter) startGzip() error {
+	gw.decided = true
+	header := gw.Header()
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+	gw.ResponseWriter.WriteHeader(gw.status)
+
+	gw.gz = gzip.NewWriter(gw.ResponseWriter)
+	_, err := gw.gz.Write(gw.buf.Bytes())
+	gw.buf.Reset()
+	return err
+}
+
+// passthrough commits to an uncompressed response and writes out the buffered body
+func (gw *gzipResponseWriter) passthrough() {
+	if gw.decided {
+		return
+	}
+	gw.decided = true
+	gw.ResponseWriter.WriteHeader(gw.status)
+	if gw.buf.Len() > 0 {
+		gw.ResponseWriter.Write(gw.buf.Bytes())
+		gw.buf.Reset()
+	}
+}
+
+// finish completes the response once the handler returns
+func (gw *gzipResponseWriter) finish() {
+	if !gw.decided {
+		gw.passthrough()
+	}
+	if gw.gz != nil {
+		gw.gz.Close()
+	}
+}
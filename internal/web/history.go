@@ -0,0 +1,70 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// historyRecordTask is the task registry name of the background history recorder
+const historyRecordTask = "history-record"
+
+// historyRecordTimeout bounds one history snapshot
+const historyRecordTimeout = 2 * time.Minute
+
+// startHistoryRecorder snapshots today's workflows into the history database every
+// configured interval, so trends don't depend on which pages were viewed or filtered
+func (s *Server) startHistoryRecorder() {
+	if !s.history.Enabled() {
+		return
+	}
+
+	interval := time.Duration(s.currentConfig().Database.RecordInterval) * time.Second
+	logger.Info("History recording enabled, snapshotting today's workflows every %v", interval)
+	s.tasks.Register(historyRecordTask, interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), historyRecordTimeout)
+			err := s.recordHistory(ctx)
+			cancel()
+			if err != nil {
+				logger.LogError("History recording failed", err)
+			}
+			s.tasks.Report(historyRecordTask, err)
+			<-ticker.C
+		}
+	}()
+}
+
+// recordHistory records the full set of today's Informatica runs, and today's NFS
+// summaries unless the scheduled NFS scan already records them
+func (s *Server) recordHistory(ctx context.Context) error {
+	var errs []error
+
+	if infClient := s.currentInfClient(); infClient != nil {
+		workflows, err := infClient.GetWorkflowsToday(ctx)
+		if err == nil {
+			infClient.AnnotateSLA(workflows)
+			err = s.history.RecordWorkflows(workflows)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if s.nfsScanInterval() <= 0 {
+		summaries, err := s.nfsScanner.ScanTodaysLogs(ctx)
+		if err == nil {
+			err = s.history.RecordNFSSummaries(summaries)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,32 @@
+package web
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestRecordHistoryRecordsTodaysWorkflows(t *testing.T) {
+	s := newTestServer(t, nil)
+	if !s.history.Enabled() {
+		t.Fatal("history store is not enabled")
+	}
+	if err := os.MkdirAll(s.currentConfig().Paths.NFSRoot, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.recordHistory(context.Background()); err != nil {
+		t.Fatalf("recordHistory() error = %v", err)
+	}
+
+	// Recorded without any page view, and regardless of filters a view might apply
+	for _, name := range []string{"BRM_LOAD_JOB", "BILLING_ETL_WORKFLOW", "CUSTOMER_DATA_SYNC"} {
+		runs, err := s.history.GetWorkflowHistory(name, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(runs) == 0 {
+			t.Errorf("no history recorded for %s", name)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"salam-monitoring/internal/config"
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/yarn"
+)
+
+// staleKillConfirmation is the dry run of a stale kill: the applications that
+// would be killed and the token handleYarnKillStale requires to kill them
+type staleKillConfirmation struct {
+	MaxDuration  string              `json:"max_duration"`
+	Applications []*yarn.Application `json:"applications"`
+	AppIDs       []string            `json:"app_ids"`
+	Token        string              `json:"token,omitempty"`
+	ExpiresAt    *time.Time          `json:"expires_at,omitempty"`
+}
+
+// staleKillTokenSubject is what a stale kill token is signed over, so a token
+// only confirms the exact applications and threshold that were shown
+func staleKillTokenSubject(maxDuration time.Duration, appIDs []string) string {
+	return "stale|" + maxDuration.String() + "|" + strings.Join(appIDs, ",")
+}
+
+// parseMaxDuration reads the maxDuration parameter, a Go duration such as 6h
+func parseMaxDuration(r *http.Request) (time.Duration, error) {
+	value := r.FormValue("maxDuration")
+	if value == "" {
+		return 0, fmt.Errorf("maxDuration parameter is required (e.g. 6h)")
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("maxDuration must be a positive duration such as 6h or 90m")
+	}
+	return d, nil
+}
+
+// handleYarnKillStaleConfirm is the dry run of a stale kill: it lists the
+// applications running longer than maxDuration with a token that
+// handleYarnKillStale requires. Nothing is killed.
+func (s *Server) handleYarnKillStaleConfirm(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Yarn stale kill confirmation request")
+
+	if s.currentYarnClient() == nil || s.killTokens == nil {
+		logger.ErrorCtx(r.Context(), "Yarn client not available")
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeYarnUnavailable, "Yarn client not available")
+		return
+	}
+
+	maxDuration, err := parseMaxDuration(r)
+	if err != nil {
+		writeFragmentError(w, r, http.StatusBadRequest, errCodeInvalidParameter, err.Error())
+		return
+	}
+
+	apps, err := s.currentYarnClient().GetStaleApplicationsContext(r.Context(), maxDuration)
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get stale Yarn applications", err)
+		writeFragmentError(w, r, http.StatusBadGateway, errCodeYarnFailed, fmt.Sprintf("Failed to connect to Yarn RM: %v", err))
+		return
+	}
+	if len(apps) > maxBulkKill {
+		writeFragmentError(w, r, http.StatusBadRequest, errCodeInvalidParameter,
+			fmt.Sprintf("%d applications are stale, more than the %d one request may kill; use a longer maxDuration", len(apps), maxBulkKill))
+		return
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].ID < apps[j].ID })
+
+	confirmation := staleKillConfirmation{
+		MaxDuration:  maxDuration.String(),
+		Applications: apps,
+		AppIDs:       make([]string, len(apps)),
+	}
+	for i, app := range apps {
+		confirmation.AppIDs[i] = app.ID
+	}
+	if len(apps) > 0 {
+		token, expires := s.killTokens.issue(staleKillTokenSubject(maxDuration, confirmation.AppIDs))
+		confirmation.Token = token
+		confirmation.ExpiresAt = &expires
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, confirmation)
+		return
+	}
+
+	vals, _ := json.Marshal(map[string]string{
+		"maxDuration": maxDuration.String(),
+		"appIds":      strings.Join(confirmation.AppIDs, ","),
+		"token":       confirmation.Token,
+	})
+	s.renderFragment(w, "yarn-kill-stale-confirm", struct {
+		staleKillConfirmation
+		Vals string
+	}{confirmation, string(vals)})
+}
+
+// handleYarnKillStale kills the applications confirmed by
+// handleYarnKillStaleConfirm (the maxDuration, appIds and token it returned) and
+// reports a result per application. Each one is checked again first: an
+// application that has finished since the dry run is reported, not killed.
+func (s *Server) handleYarnKillStale(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Yarn stale kill request")
+
+	if s.currentYarnClient() == nil || s.killTokens == nil {
+		logger.ErrorCtx(r.Context(), "Yarn client not available")
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeYarnUnavailable, "Yarn client not available")
+		return
+	}
+
+	maxDuration, err := parseMaxDuration(r)
+	if err != nil {
+		writeFragmentError(w, r, http.StatusBadRequest, errCodeInvalidParameter, err.Error())
+		return
+	}
+	appIDs := config.SplitList(r.FormValue("appIds"))
+	if len(appIDs) == 0 {
+		writeFragmentError(w, r, http.StatusBadRequest, errCodeInvalidParameter, "appIds parameter is required")
+		return
+	}
+	if err := s.killTokens.verify(staleKillTokenSubject(maxDuration, appIDs), r.FormValue("token")); err != nil {
+		logger.ErrorCtx(r.Context(), "Rejected stale kill of %d applications: %v", len(appIDs), err)
+		writeFragmentError(w, r, http.StatusForbidden, errCodeInvalidParameter, "Kill not confirmed: "+err.Error())
+		return
+	}
+
+	stale, err := s.currentYarnClient().GetStaleApplicationsContext(r.Context(), maxDuration)
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get stale Yarn applications", err)
+		writeFragmentError(w, r, http.StatusBadGateway, errCodeYarnFailed, fmt.Sprintf("Failed to connect to Yarn RM: %v", err))
+		return
+	}
+	stillStale := make(map[string]bool, len(stale))
+	for _, app := range stale {
+		stillStale[app.ID] = true
+	}
+
+	var toKill []string
+	var skipped []yarn.KillResult
+	for _, id := range appIDs {
+		if stillStale[id] {
+			toKill = append(toKill, id)
+		} else {
+			skipped = append(skipped, yarn.KillResult{ID: id, Error: "no longer running"})
+		}
+	}
+	results := s.currentYarnClient().KillApplicationsContext(auditContext(r), toKill)
+	results = append(results, skipped...)
+
+	if wantsJSON(r) {
+		writeJSON(w, results)
+		return
+	}
+
+	s.renderFragment(w, "yarn-kill-results", results)
+}
@@ -0,0 +1,139 @@
+package web
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"salam-monitoring/internal/audit"
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/yarn"
+)
+
+// killTokenTTL is how long a kill confirmation stays valid
+const killTokenTTL = 2 * time.Minute
+
+var errInvalidKillToken = errors.New("kill confirmation is missing, invalid or expired")
+
+// killTokenSigner issues and checks the short-lived tokens that /api/yarn/kill
+// requires. The key is generated per process, so restarting the server
+// invalidates outstanding confirmations.
+type killTokenSigner struct {
+	key []byte
+}
+
+// newKillTokenSigner creates a signer with a random key
+func newKillTokenSigner() (*killTokenSigner, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate kill token key: %w", err)
+	}
+	return &killTokenSigner{key: key}, nil
+}
+
+// issue returns a token confirming the kill of appID and when it expires
+func (k *killTokenSigner) issue(appID string) (string, time.Time) {
+	expires := time.Now().Add(killTokenTTL).Truncate(time.Second)
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	return exp + "." + k.sign(appID, exp), expires
+}
+
+// verify checks that token was issued for appID and has not expired
+func (k *killTokenSigner) verify(appID, token string) error {
+	exp, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return errInvalidKillToken
+	}
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || time.Now().After(time.Unix(unix, 0)) {
+		return errInvalidKillToken
+	}
+	if !hmac.Equal([]byte(sig), []byte(k.sign(appID, exp))) {
+		return errInvalidKillToken
+	}
+	return nil
+}
+
+// sign computes the HMAC binding an application ID to an expiry
+func (k *killTokenSigner) sign(appID, exp string) string {
+	mac := hmac.New(sha256.New, k.key)
+	mac.Write([]byte(appID + "|" + exp))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// killConfirmation is the JSON form of a kill confirmation
+type killConfirmation struct {
+	AppID     string    `json:"app_id"`
+	Name      string    `json:"name"`
+	State     string    `json:"state"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// handleYarnKillConfirm is the first step of a kill: it looks up the application
+// and returns its name with a token that handleYarnKill requires
+func (s *Server) handleYarnKillConfirm(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Yarn kill confirmation request")
+
+	if s.currentYarnClient() == nil || s.killTokens == nil {
+		logger.ErrorCtx(r.Context(), "Yarn client not available")
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeYarnUnavailable, "Yarn client not available")
+		return
+	}
+
+	appID := r.URL.Query().Get("appId")
+	if appID == "" {
+		writeFragmentError(w, r, http.StatusBadRequest, errCodeInvalidParameter, "Application ID required")
+		return
+	}
+
+	app, err := s.currentYarnClient().GetApplicationContext(r.Context(), appID)
+	if errors.Is(err, yarn.ErrNotFound) || (err == nil && app == nil) {
+		writeFragmentError(w, r, http.StatusNotFound, errCodeApplicationNotFound, "Application "+appID+" not found")
+		return
+	}
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get Yarn application", err)
+		writeFragmentError(w, r, http.StatusBadGateway, errCodeYarnFailed, fmt.Sprintf("Failed to connect to Yarn RM: %v", err))
+		return
+	}
+
+	token, expires := s.killTokens.issue(app.ID)
+	confirmation := killConfirmation{
+		AppID:     app.ID,
+		Name:      app.Name,
+		State:     app.State,
+		Token:     token,
+		ExpiresAt: expires,
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, confirmation)
+		return
+	}
+
+	vals, _ := json.Marshal(map[string]string{"appId": app.ID, "token": token})
+	s.renderFragment(w, "yarn-kill-confirm", struct {
+		killConfirmation
+		Vals string
+	}{confirmation, string(vals)})
+}
+
+// auditContext tags the request context with who is killing, for the audit log:
+// the client IP, prefixed with the basic auth user when there is one
+func auditContext(r *http.Request) context.Context {
+	requester := clientIP(r)
+	if user, _, ok := r.BasicAuth(); ok && user != "" {
+		requester = user + "@" + requester
+	}
+	return audit.WithRequester(r.Context(), requester)
+}
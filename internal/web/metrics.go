@@ -0,0 +1,238 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/nfs"
+	"salam-monitoring/internal/yarn"
+)
+
+// serverMetrics holds the Prometheus collectors exposed on /metrics
+type serverMetrics struct {
+	registry         *prometheus.Registry
+	requests         *prometheus.CounterVec
+	requestDuration  *prometheus.HistogramVec
+	nfsScanDuration  prometheus.Histogram
+	nfsScanFiles     prometheus.Counter
+	nfsScanBytes     prometheus.Counter
+	nfsScanSources   prometheus.Gauge
+	nfsScanWorkflows prometheus.Gauge
+}
+
+// newServerMetrics creates and registers the server's collectors under the given prefix
+func newServerMetrics(s *Server, prefix string) *serverMetrics {
+	m := &serverMetrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prefix,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests served, by method, route and status code.",
+		}, []string{"method", "route", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency, by method and route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		nfsScanDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Name:      "nfs_scan_duration_seconds",
+			Help:      "Time taken to scan the NFS root for one date.",
+			Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60},
+		}),
+		nfsScanFiles: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: prefix,
+			Name:      "nfs_scan_files_opened_total",
+			Help:      "Log files opened by NFS scans.",
+		}),
+		nfsScanBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: prefix,
+			Name:      "nfs_scan_bytes_read_total",
+			Help:      "Bytes read from disk by NFS scans, before decompression.",
+		}),
+		nfsScanSources: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: prefix,
+			Name:      "nfs_scan_sources",
+			Help:      "Source directories in the latest NFS scan.",
+		}),
+		nfsScanWorkflows: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: prefix,
+			Name:      "nfs_scan_workflows",
+			Help:      "Workflows found by the latest NFS scan.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.requestDuration,
+		m.nfsScanDuration,
+		m.nfsScanFiles,
+		m.nfsScanBytes,
+		m.nfsScanSources,
+		m.nfsScanWorkflows,
+		newBackendCollector(s, prefix),
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// observeRequest records one served request
+func (m *serverMetrics) observeRequest(r *http.Request, status int, duration time.Duration) {
+	route := routeTemplate(r)
+	m.requests.WithLabelValues(r.Method, route, strconv.Itoa(status)).Inc()
+	m.requestDuration.WithLabelValues(r.Method, route).Observe(duration.Seconds())
+}
+
+// observeNFSScan records the stats of an uncached NFS scan
+func (m *serverMetrics) observeNFSScan(stats nfs.ScanStats) {
+	m.nfsScanDuration.Observe(stats.Duration.Seconds())
+	m.nfsScanFiles.Add(float64(stats.FilesOpened))
+	m.nfsScanBytes.Add(float64(stats.BytesRead))
+	m.nfsScanSources.Set(float64(stats.Sources))
+	m.nfsScanWorkflows.Set(float64(stats.Workflows))
+}
+
+// handler serves the registry in the Prometheus exposition format
+func (m *serverMetrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// routeTemplate returns the matched route pattern so path parameters don't explode label cardinality
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tmpl, err := route.GetPathTemplate(); err == nil {
+			return tmpl
+		}
+	}
+	return "unmatched"
+}
+
+// backendMetricsTask is the task registry name of the backend gauge poller
+const backendMetricsTask = "backend-metrics"
+
+// backendMetricsInterval is how often the backend gauges are refreshed; scrapes
+// only read the cached values, so a busy Prometheus can't load the backends
+const backendMetricsInterval = 30 * time.Second
+
+// backendMetricsState holds the latest backend values reported on /metrics.
+// A nil field means the backend couldn't be queried on the latest poll.
+type backendMetricsState struct {
+	mu           sync.RWMutex
+	cluster      *yarn.ClusterMetrics
+	statusCounts map[string]int
+}
+
+// set replaces the cached values
+func (st *backendMetricsState) set(cluster *yarn.ClusterMetrics, statusCounts map[string]int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.cluster = cluster
+	st.statusCounts = statusCounts
+}
+
+// get returns the cached values
+func (st *backendMetricsState) get() (*yarn.ClusterMetrics, map[string]int) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.cluster, st.statusCounts
+}
+
+// startBackendMetrics refreshes the backend gauges every backendMetricsInterval
+func (s *Server) startBackendMetrics() {
+	s.tasks.Register(backendMetricsTask, backendMetricsInterval)
+	go func() {
+		ticker := time.NewTicker(backendMetricsInterval)
+		defer ticker.Stop()
+		for {
+			s.tasks.Report(backendMetricsTask, s.pollBackendMetrics(context.Background()))
+			<-ticker.C
+		}
+	}()
+}
+
+// pollBackendMetrics queries the cluster metrics and today's workflow counts by
+// status, each bounded like an overview section, and caches them for scrapes
+func (s *Server) pollBackendMetrics(ctx context.Context) error {
+	var errs []error
+
+	var cluster *yarn.ClusterMetrics
+	if client := s.currentYarnClient(); client != nil {
+		yarnCtx, cancel := context.WithTimeout(ctx, overviewSectionTimeout)
+		metrics, err := client.GetClusterMetricsContext(yarnCtx)
+		cancel()
+		if err != nil {
+			logger.LogError("Metrics: failed to get Yarn cluster metrics", err)
+			errs = append(errs, err)
+		} else {
+			cluster = metrics
+		}
+	}
+
+	var statusCounts map[string]int
+	if client := s.currentInfClient(); client != nil {
+		infCtx, cancel := context.WithTimeout(ctx, overviewSectionTimeout)
+		counts, err := client.GetStatusCounts(infCtx)
+		cancel()
+		if err != nil {
+			logger.LogError("Metrics: failed to count Informatica workflows", err)
+			errs = append(errs, err)
+		} else {
+			statusCounts = counts
+		}
+	}
+
+	s.backend.set(cluster, statusCounts)
+	return errors.Join(errs...)
+}
+
+// backendCollector reports the backend values cached by pollBackendMetrics
+type backendCollector struct {
+	server                 *Server
+	yarnAppsRunning        *prometheus.Desc
+	yarnAvailableMB        *prometheus.Desc
+	informaticaFailedToday *prometheus.Desc
+}
+
+// newBackendCollector describes the gauges reported from the backend poller
+func newBackendCollector(s *Server, prefix string) *backendCollector {
+	return &backendCollector{
+		server: s,
+		yarnAppsRunning: prometheus.NewDesc(prometheus.BuildFQName(prefix, "yarn", "apps_running"),
+			"Applications currently running on the Yarn cluster.", nil, nil),
+		yarnAvailableMB: prometheus.NewDesc(prometheus.BuildFQName(prefix, "yarn", "available_mb"),
+			"Memory available on the Yarn cluster in MB.", nil, nil),
+		informaticaFailedToday: prometheus.NewDesc(prometheus.BuildFQName(prefix, "informatica", "failed_workflows_today"),
+			"Informatica workflows that failed today.", nil, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *backendCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.yarnAppsRunning
+	ch <- c.yarnAvailableMB
+	ch <- c.informaticaFailedToday
+}
+
+// Collect implements prometheus.Collector. Backends that were unavailable on the
+// latest poll are skipped so the series go absent rather than reporting a misleading zero.
+func (c *backendCollector) Collect(ch chan<- prometheus.Metric) {
+	cluster, statusCounts := c.server.backend.get()
+	if cluster != nil {
+		ch <- prometheus.MustNewConstMetric(c.yarnAppsRunning, prometheus.GaugeValue, float64(cluster.AppsRunning))
+		ch <- prometheus.MustNewConstMetric(c.yarnAvailableMB, prometheus.GaugeValue, float64(cluster.AvailableMB))
+	}
+	if statusCounts != nil {
+		ch <- prometheus.MustNewConstMetric(c.informaticaFailedToday, prometheus.GaugeValue, float64(statusCounts["FAILED"]))
+	}
+}
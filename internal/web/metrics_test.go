@@ -0,0 +1,79 @@
+package web
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"salam-monitoring/internal/config"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.Server.MetricsPrefix = "salam"
+	})
+	// The backend gauges are served from the poller's cache, never queried by a scrape
+	if err := s.pollBackendMetrics(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(s.router)
+	defer srv.Close()
+
+	// Request counters only appear once a request has been served
+	resp, err := http.Get(srv.URL + "/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	resp, err = http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d, want 200", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{
+		`salam_http_requests_total{code="200",method="GET",route="/health"}`,
+		`salam_http_request_duration_seconds_bucket{`,
+		"salam_nfs_scan_duration_seconds_count",
+		"salam_nfs_scan_files_opened_total",
+		"salam_nfs_scan_bytes_read_total",
+		"salam_nfs_scan_sources",
+		"salam_nfs_scan_workflows",
+		"salam_yarn_apps_running",
+		"salam_yarn_available_mb",
+		"salam_informatica_failed_workflows_today",
+		"go_goroutines",
+	} {
+		if !strings.Contains(string(body), name) {
+			t.Errorf("metrics output lacks %s", name)
+		}
+	}
+}
+
+func TestMetricsScrapeDoesNotQueryBackends(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.Server.MetricsPrefix = "salam"
+	})
+
+	// Before the first poll there is nothing cached, so the backend series are absent
+	w := serve(s, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, want 200", w.Code)
+	}
+	for _, name := range []string{"salam_yarn_apps_running", "salam_informatica_failed_workflows_today"} {
+		if strings.Contains(w.Body.String(), name) {
+			t.Errorf("metrics output has %s before any backend poll", name)
+		}
+	}
+}
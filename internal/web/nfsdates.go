@@ -0,0 +1,44 @@
+package web
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/nfs"
+)
+
+// nfsDatesResponse is the /api/nfs/dates payload
+type nfsDatesResponse struct {
+	Source string   `json:"source,omitempty"` // empty for the union across sources
+	Dates  []string `json:"dates"`            // YYYY-MM-DD, newest first
+}
+
+// handleNFSDates lists the dates that have logs for ?source=, or for any source
+// when it is omitted, so date pickers only offer dates with data
+func (s *Server) handleNFSDates(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling NFS dates request")
+
+	if s.nfsScanner == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeNFSUnavailable, "NFS scanner not available")
+		return
+	}
+
+	source := r.URL.Query().Get("source")
+	dates, err := s.nfsScanner.GetAvailableDates(source)
+	switch {
+	case errors.Is(err, nfs.ErrInvalidSource):
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "source must be a source directory name")
+		return
+	case errors.Is(err, fs.ErrNotExist):
+		writeJSONError(w, http.StatusNotFound, errCodeSourceNotFound, "Unknown NFS source: "+source)
+		return
+	case err != nil:
+		logger.LogErrorCtx(r.Context(), "Failed to list NFS log dates", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeNFSFailed, "Failed to list NFS log dates")
+		return
+	}
+
+	writeJSON(w, nfsDatesResponse{Source: source, Dates: dates})
+}
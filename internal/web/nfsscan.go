@@ -0,0 +1,114 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// nfsScanTask is the task registry name of the scheduled NFS scan
+const nfsScanTask = "nfs-scan"
+
+// nfsScanTimeout bounds one scheduled scan of today's logs
+const nfsScanTimeout = 5 * time.Minute
+
+// nfsScanResult is the outcome of the latest scheduled NFS scan
+type nfsScanResult struct {
+	LastScan  time.Time `json:"last_scan"`
+	Workflows int       `json:"workflows"`
+	Failed    int       `json:"failed"`
+	Duration  float64   `json:"duration_seconds"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// nfsScanState holds the latest scheduled scan result for the dashboard and /healthz
+type nfsScanState struct {
+	mu     sync.RWMutex
+	result *nfsScanResult // nil until the first scan completes
+}
+
+// set records a scan result
+func (st *nfsScanState) set(result nfsScanResult) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.result = &result
+}
+
+// get returns the latest scan result, or nil if no scan has run yet
+func (st *nfsScanState) get() *nfsScanResult {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.result
+}
+
+// nfsScanInterval returns the configured scheduled scan interval, zero when disabled
+func (s *Server) nfsScanInterval() time.Duration {
+	return time.Duration(s.currentConfig().NFS.ScanInterval) * time.Minute
+}
+
+// startScheduledNFSScan rescans today's logs every configured interval, refreshing
+// the scanner cache so page loads don't wait on the NFS walk
+func (s *Server) startScheduledNFSScan() {
+	interval := s.nfsScanInterval()
+	if interval <= 0 {
+		return
+	}
+
+	logger.Info("Scheduled NFS scan enabled, scanning today's logs every %v", interval)
+	s.tasks.Register(nfsScanTask, interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			s.runScheduledNFSScan()
+			<-ticker.C
+		}
+	}()
+}
+
+// runScheduledNFSScan scans today's logs once and records the outcome
+func (s *Server) runScheduledNFSScan() {
+	ctx, cancel := context.WithTimeout(context.Background(), nfsScanTimeout)
+	defer cancel()
+
+	start := time.Now()
+	summaries, err := s.nfsScanner.RefreshLogsForDate(ctx, s.nfsScanner.Today())
+	result := nfsScanResult{LastScan: start, Duration: time.Since(start).Seconds()}
+	if err != nil {
+		result.Error = err.Error()
+		logger.LogError("Scheduled NFS scan failed", err)
+	} else {
+		result.Workflows = len(summaries)
+		for _, summary := range summaries {
+			if summary.HasErrors {
+				result.Failed++
+			}
+		}
+		logger.Info("Scheduled NFS scan: %d workflows, %d failed (%.1fs)", result.Workflows, result.Failed, result.Duration)
+		if err := s.history.RecordNFSSummaries(summaries); err != nil {
+			logger.LogError("Failed to record NFS history", err)
+		}
+	}
+
+	s.nfsScan.set(result)
+	s.tasks.Report(nfsScanTask, err)
+}
+
+// handleDashboardNFSSummary renders the latest scheduled scan for the dashboard card
+func (s *Server) handleDashboardNFSSummary(w http.ResponseWriter, r *http.Request) {
+	result := s.nfsScan.get()
+	if wantsJSON(r) {
+		writeJSON(w, map[string]interface{}{
+			"scheduled": s.nfsScanInterval() > 0,
+			"scan":      result,
+		})
+		return
+	}
+	s.renderFragment(w, "dashboard-nfs-summary", struct {
+		Scheduled bool
+		Scan      *nfsScanResult
+	}{s.nfsScanInterval() > 0, result})
+}
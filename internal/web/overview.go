@@ -0,0 +1,128 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/yarn"
+)
+
+// overviewSectionTimeout bounds each backend query of /api/dashboard/overview,
+// so one slow backend costs its own section rather than the whole response
+const overviewSectionTimeout = 10 * time.Second
+
+// dashboardOverview is the /api/dashboard/overview payload. Each section is
+// gathered independently; a section whose backend failed carries only Error.
+type dashboardOverview struct {
+	Yarn        yarnOverview        `json:"yarn"`
+	Informatica informaticaOverview `json:"informatica"`
+	NFS         nfsOverview         `json:"nfs"`
+	Timestamp   time.Time           `json:"timestamp"`
+}
+
+// yarnOverview holds the Yarn cluster metrics
+type yarnOverview struct {
+	Metrics *yarn.ClusterMetrics `json:"metrics,omitempty"`
+	Error   string               `json:"error,omitempty"`
+}
+
+// informaticaOverview holds today's workflow counts by status
+type informaticaOverview struct {
+	Counts map[string]int `json:"counts,omitempty"`
+	Mock   bool           `json:"mock"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// nfsOverview holds today's workflow log counts
+type nfsOverview struct {
+	Workflows int    `json:"workflows"`
+	Failed    int    `json:"failed"`
+	Error     string `json:"error,omitempty"`
+}
+
+// handleDashboardOverview returns the Yarn, Informatica and NFS dashboard
+// summaries in one JSON response, queried concurrently. It answers 200 even
+// when backends are down; check each section's error field.
+func (s *Server) handleDashboardOverview(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling dashboard overview request")
+
+	overview := dashboardOverview{Timestamp: time.Now()}
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		overview.Yarn = s.yarnOverview(r.Context())
+	}()
+	go func() {
+		defer wg.Done()
+		overview.Informatica = s.informaticaOverview(r.Context())
+	}()
+	go func() {
+		defer wg.Done()
+		overview.NFS = s.nfsOverview(r.Context())
+	}()
+	wg.Wait()
+
+	writeJSON(w, overview)
+}
+
+// yarnOverview fetches the cluster metrics for the overview
+func (s *Server) yarnOverview(ctx context.Context) yarnOverview {
+	client := s.currentYarnClient()
+	if client == nil {
+		return yarnOverview{Error: "Yarn client not available"}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, overviewSectionTimeout)
+	defer cancel()
+	metrics, err := client.GetClusterMetricsContext(ctx)
+	if err != nil {
+		logger.LogErrorCtx(ctx, "Overview: failed to get Yarn cluster metrics", err)
+		return yarnOverview{Error: "Unable to connect to Yarn RM"}
+	}
+	return yarnOverview{Metrics: metrics}
+}
+
+// informaticaOverview counts today's workflows by status for the overview
+func (s *Server) informaticaOverview(ctx context.Context) informaticaOverview {
+	client := s.currentInfClient()
+	if client == nil {
+		return informaticaOverview{Error: "Informatica client not available"}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, overviewSectionTimeout)
+	defer cancel()
+	counts, err := client.GetStatusCounts(ctx)
+	if err != nil {
+		logger.LogErrorCtx(ctx, "Overview: failed to count Informatica workflows", err)
+		return informaticaOverview{Error: "Unable to query Informatica"}
+	}
+	return informaticaOverview{Counts: counts, Mock: client.IsMockMode()}
+}
+
+// nfsOverview counts today's workflows and those whose logs have errors, served
+// from the scanner cache when the scheduled scan keeps it warm
+func (s *Server) nfsOverview(ctx context.Context) nfsOverview {
+	if s.nfsScanner == nil {
+		return nfsOverview{Error: "NFS scanner not available"}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, overviewSectionTimeout)
+	defer cancel()
+	summaries, err := s.nfsScanner.ScanTodaysLogs(ctx)
+	if err != nil {
+		logger.LogErrorCtx(ctx, "Overview: failed to scan NFS logs", err)
+		return nfsOverview{Error: "Unable to scan NFS logs"}
+	}
+
+	overview := nfsOverview{Workflows: len(summaries)}
+	for _, summary := range summaries {
+		if summary.HasErrors {
+			overview.Failed++
+		}
+	}
+	return overview
+}
@@ -0,0 +1,104 @@
+package web
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	defaultPageSize = 25
+	maxPageSize     = 500
+)
+
+// pagination describes the slice of a result list requested via ?page=&pageSize=
+type pagination struct {
+	Page     int
+	PageSize int
+	Total    int
+}
+
+// parsePagination reads page and pageSize from the query, falling back to page 1 of 25
+func parsePagination(r *http.Request) pagination {
+	p := pagination{Page: 1, PageSize: defaultPageSize}
+	if page, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && page > 0 {
+		p.Page = page
+	}
+	if size, err := strconv.Atoi(r.URL.Query().Get("pageSize")); err == nil && size > 0 {
+		p.PageSize = size
+		if p.PageSize > maxPageSize {
+			p.PageSize = maxPageSize
+		}
+	}
+	return p
+}
+
+// bounds returns the start and end indexes of the current page for total items,
+// clamping the page number to the last page
+func (p *pagination) bounds(total int) (int, int) {
+	p.Total = total
+	if last := p.pageCount(); p.Page > last {
+		p.Page = last
+	}
+
+	start := (p.Page - 1) * p.PageSize
+	end := start + p.PageSize
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// pageCount returns the number of pages, which is at least one
+func (p pagination) pageCount() int {
+	if p.Total == 0 {
+		return 1
+	}
+	return (p.Total + p.PageSize - 1) / p.PageSize
+}
+
+// setTotalHeader exposes the unpaginated result count to the client
+func (p pagination) setTotalHeader(w http.ResponseWriter) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(p.Total))
+}
+
+// renderControls writes previous/next buttons that reload target with the same
+// query parameters and a different page
+func (p pagination) renderControls(w http.ResponseWriter, r *http.Request, target string) {
+	if p.Total == 0 {
+		return
+	}
+
+	start := (p.Page-1)*p.PageSize + 1
+	end := start + p.PageSize - 1
+	if end > p.Total {
+		end = p.Total
+	}
+
+	fmt.Fprintf(w, `<div class="flex items-center justify-between mt-6 text-sm text-gray-600">
+		<span>Showing %d–%d of %d</span>
+		<div class="flex space-x-2">`, start, end, p.Total)
+	p.renderButton(w, r, target, "Previous", p.Page-1, p.Page > 1)
+	p.renderButton(w, r, target, "Next", p.Page+1, p.Page < p.pageCount())
+	fmt.Fprintf(w, `</div></div>`)
+}
+
+// renderButton writes a single pagination button, disabled when the page is out of range
+func (p pagination) renderButton(w http.ResponseWriter, r *http.Request, target, label string, page int, enabled bool) {
+	if !enabled {
+		fmt.Fprintf(w, `<button class="px-3 py-1 border border-gray-200 rounded text-gray-400" disabled>%s</button>`, label)
+		return
+	}
+
+	query := url.Values{}
+	for key, values := range r.URL.Query() {
+		query[key] = values
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("pageSize", strconv.Itoa(p.PageSize))
+
+	fmt.Fprintf(w, `<button class="px-3 py-1 border border-gray-300 rounded hover:bg-gray-100" hx-get="%s" hx-target="%s">%s</button>`,
+		template.HTMLEscapeString(r.URL.Path+"?"+query.Encode()), target, label)
+}
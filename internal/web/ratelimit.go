@@ -0,0 +1,116 @@
+package web
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// rateLimiter is a token bucket per client key. Rate and burst are passed on each
+// call so a config reload takes effect immediately.
+type rateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// tokenBucket holds the tokens left for one client as of updated
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{buckets: make(map[string]*tokenBucket)}
+}
+
+// allow takes a token from key's bucket, which refills at perMinute tokens per
+// minute up to burst. When the bucket is empty it returns false and how long
+// until the next token is available.
+func (l *rateLimiter) allow(key string, perMinute, burst int, now time.Time) (bool, time.Duration) {
+	if burst < 1 {
+		burst = 1
+	}
+	perSecond := float64(perMinute) / 60
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now, perSecond, burst)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.updated).Seconds()*perSecond)
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / perSecond * float64(time.Second))
+}
+
+// sweep drops, at most once a minute, buckets idle long enough to have refilled,
+// since a fresh bucket behaves the same
+func (l *rateLimiter) sweep(now time.Time, perSecond float64, burst int) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	refill := time.Duration(float64(burst) / perSecond * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// killRateLimit throttles a mutating Yarn endpoint per client IP using the
+// kill_rate_per_minute and kill_burst settings. Rejected requests get 429 with
+// Retry-After.
+func (s *Server) killRateLimit(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cfg := s.currentConfig().Server
+		if cfg.KillRatePerMinute <= 0 {
+			next(w, r)
+			return
+		}
+
+		ip := clientIP(r)
+		ok, wait := s.killLimiter.allow(ip, cfg.KillRatePerMinute, cfg.KillBurst, time.Now())
+		if ok {
+			next(w, r)
+			return
+		}
+
+		retryAfter := int(math.Ceil(wait.Seconds()))
+		logger.ErrorCtx(r.Context(), "Rate limited %s %s from %s, retry after %ds", r.Method, r.URL.Path, ip, retryAfter)
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		message := fmt.Sprintf("Too many kill requests, retry in %d seconds", retryAfter)
+		if wantsJSON(r) || r.Header.Get("Content-Type") == "application/json" {
+			writeJSONError(w, http.StatusTooManyRequests, errCodeRateLimited, message)
+			return
+		}
+		http.Error(w, message, http.StatusTooManyRequests)
+	}
+}
+
+// clientIP returns the IP of the connecting client. Forwarding headers are
+// ignored since a client could set them to dodge the limit.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
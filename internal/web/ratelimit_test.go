@@ -0,0 +1,65 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"salam-monitoring/internal/config"
+)
+
+func TestKillRateLimit(t *testing.T) {
+	const burst = 3
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.Server.KillRatePerMinute = 1
+		cfg.Server.KillBurst = burst
+	})
+	handler := s.killRateLimit(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	kill := func(remoteAddr string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/yarn/kill", nil)
+		r.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler(w, r)
+		return w
+	}
+
+	for i := 0; i < burst; i++ {
+		if w := kill("10.0.0.1:40000"); w.Code != http.StatusOK {
+			t.Fatalf("request %d within the burst got status %d, want 200", i+1, w.Code)
+		}
+	}
+
+	// The port changes per connection; the limit is per IP
+	w := kill("10.0.0.1:40001")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("request past the burst got status %d, want 429", w.Code)
+	}
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	if err != nil || retryAfter < 1 || retryAfter > 60 {
+		t.Errorf("got Retry-After %q, want 1-60 seconds", w.Header().Get("Retry-After"))
+	}
+
+	if w := kill("10.0.0.2:40000"); w.Code != http.StatusOK {
+		t.Errorf("another IP got status %d, want 200", w.Code)
+	}
+}
+
+func TestKillRateLimitDisabled(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.Server.KillRatePerMinute = 0
+	})
+	handler := s.killRateLimit(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for i := 0; i < 20; i++ {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodPost, "/api/yarn/kill", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d got status %d with the limit disabled, want 200", i+1, w.Code)
+		}
+	}
+}
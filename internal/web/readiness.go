@@ -0,0 +1,128 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// readinessState tracks whether the backends the server depends on have become reachable
+type readinessState struct {
+	mu      sync.RWMutex
+	ready   bool
+	pending []string
+}
+
+// set records the outcome of a dependency check
+func (rs *readinessState) set(ready bool, pending []string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.ready = ready
+	rs.pending = pending
+}
+
+// get returns the current readiness and any dependencies still being waited on
+func (rs *readinessState) get() (bool, []string) {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.ready, rs.pending
+}
+
+// Intervals between dependency checks while the gate is closed: frequent at
+// first, then slower once DependencyTimeout has passed
+var (
+	dependencyPollInterval    = 5 * time.Second
+	dependencyOverdueInterval = 30 * time.Second
+)
+
+// waitForDependencies polls NFS and Informatica until both respond, and only then
+// reports ready. The server keeps accepting traffic meanwhile; only /readyz is
+// held back. Past the configured timeout the wait is logged as an error and
+// polling slows down, but readiness stays false until every backend answers.
+func (s *Server) waitForDependencies() {
+	timeout := time.Duration(s.currentConfig().Server.DependencyTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+	deadline := time.Now().Add(timeout)
+	logger.Info("Waiting up to %v for dependencies before reporting ready", timeout)
+
+	overdue := false
+	for attempt := 1; ; attempt++ {
+		pending := s.checkDependencies()
+		if len(pending) == 0 {
+			s.tasks.Report("dependency-gate", nil)
+			s.readiness.set(true, nil)
+			logger.Info("All dependencies reachable after %d attempt(s), server is ready", attempt)
+			return
+		}
+
+		s.readiness.set(false, pending)
+		s.tasks.Report("dependency-gate", fmt.Errorf("waiting for %v", pending))
+
+		interval := dependencyPollInterval
+		if time.Now().After(deadline) {
+			if !overdue {
+				logger.Error("Dependencies still unavailable after %v: %v - staying not ready and checking every %v",
+					timeout, pending, dependencyOverdueInterval)
+				overdue = true
+			}
+			interval = dependencyOverdueInterval
+		} else {
+			logger.Info("Waiting for dependencies (attempt %d): %v", attempt, pending)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// checkDependencies returns the names of dependencies that are not yet reachable
+func (s *Server) checkDependencies() []string {
+	var pending []string
+
+	if _, err := os.ReadDir(s.currentConfig().GetNFSRoot()); err != nil {
+		logger.LogError("NFS root not readable", err)
+		pending = append(pending, "nfs")
+	}
+
+	// Test mode runs Informatica against mock data, so only wait on the real database in prod
+	if s.currentConfig().IsProdMode() {
+		if s.currentInfClient() == nil {
+			pending = append(pending, "informatica")
+		} else {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			err := s.currentInfClient().Ping(ctx)
+			cancel()
+			if err != nil {
+				logger.LogError("Informatica database not reachable", err)
+				pending = append(pending, "informatica")
+			}
+		}
+	}
+
+	return pending
+}
+
+// handleReady reports whether the startup dependency gate has opened
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	ready, pending := s.readiness.get()
+
+	status := "ready"
+	code := http.StatusOK
+	if !ready {
+		status = "waiting"
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  status,
+		"pending": pending,
+	})
+}
@@ -0,0 +1,63 @@
+package web
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"salam-monitoring/internal/config"
+	"salam-monitoring/internal/tasks"
+)
+
+func TestDependencyGateStaysClosedPastTimeout(t *testing.T) {
+	defer func(poll, overdue time.Duration) {
+		dependencyPollInterval, dependencyOverdueInterval = poll, overdue
+	}(dependencyPollInterval, dependencyOverdueInterval)
+	dependencyPollInterval, dependencyOverdueInterval = 10*time.Millisecond, 20*time.Millisecond
+
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.Server.WaitForDependencies = true
+		cfg.Server.DependencyTimeout = 1
+	})
+	done := make(chan struct{})
+	go func() {
+		s.waitForDependencies()
+		close(done)
+	}()
+
+	// The NFS root does not exist yet, so the gate must stay closed past the timeout
+	time.Sleep(1500 * time.Millisecond)
+	if ready, pending := s.readiness.get(); ready || len(pending) == 0 {
+		t.Fatalf("got ready=%v pending=%v after the timeout, want not ready", ready, pending)
+	}
+	if gate := taskStatus(t, s, "dependency-gate"); gate.LastError == "" {
+		t.Error("dependency-gate reported no error while waiting")
+	}
+
+	if err := os.MkdirAll(s.currentConfig().GetNFSRoot(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("gate did not open once NFS became readable")
+	}
+	if ready, pending := s.readiness.get(); !ready || len(pending) != 0 {
+		t.Errorf("got ready=%v pending=%v after recovery, want ready", ready, pending)
+	}
+	if gate := taskStatus(t, s, "dependency-gate"); gate.LastError != "" {
+		t.Errorf("dependency-gate still reports %q after recovery", gate.LastError)
+	}
+}
+
+// taskStatus returns the registry status of the named background task
+func taskStatus(t *testing.T, s *Server, name string) tasks.Status {
+	t.Helper()
+	for _, status := range s.tasks.Snapshot() {
+		if status.Name == name {
+			return status
+		}
+	}
+	t.Fatalf("task %s not registered", name)
+	return tasks.Status{}
+}
@@ -0,0 +1,120 @@
+package web
+
+import (
+	"time"
+
+	"salam-monitoring/internal/config"
+	"salam-monitoring/internal/informatica"
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/yarn"
+)
+
+// reloadGracePeriod is how long a replaced Informatica client stays open so
+// requests that already hold it can finish their queries
+const reloadGracePeriod = time.Minute
+
+// currentConfig returns the active configuration
+func (s *Server) currentConfig() *config.Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config
+}
+
+// currentInfClient returns the active Informatica client, which may be nil
+func (s *Server) currentInfClient() *informatica.Client {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.infClient
+}
+
+// currentYarnClient returns the active Yarn client
+func (s *Server) currentYarnClient() *yarn.Client {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.yarnClient
+}
+
+// Reload switches the server to a new configuration without a restart. The
+// Informatica and Yarn clients are re-created when their settings changed and
+// the log level is applied. Settings bound at startup (listen address, TLS,
+// mode, NFS, history database, log output, alerts, business day, SLAs) keep
+// their current values.
+func (s *Server) Reload(cfg *config.Config) {
+	old := s.currentConfig()
+
+	next := *cfg
+	keepStartupSettings(&next, old)
+	for _, held := range config.Diff(&next, cfg) {
+		logger.Info("Config reload: %s requires a restart, keeping the current value", held)
+	}
+
+	changes := config.Diff(old, &next)
+	if len(changes) == 0 {
+		logger.Info("Config reload: no changes")
+		return
+	}
+	for _, change := range changes {
+		logger.Info("Config reload: %s", change)
+	}
+
+	infClient := s.currentInfClient()
+	var replacedInf *informatica.Client
+	if next.Services.InformaticaDB != old.Services.InformaticaDB {
+		if fresh, err := NewInformaticaClient(&next); err == nil {
+			replacedInf, infClient = infClient, fresh
+		} else {
+			logger.LogError("Config reload: keeping the current Informatica client", err)
+		}
+	}
+
+	yarnClient := s.currentYarnClient()
+	if yarnSettingsChanged(old, &next) {
+		yarnClient = NewYarnClient(&next, s.audit.ObserveKill)
+	}
+
+	s.mu.Lock()
+	s.config = &next
+	s.infClient = infClient
+	s.yarnClient = yarnClient
+	s.mu.Unlock()
+
+	if replacedInf != nil {
+		time.AfterFunc(reloadGracePeriod, func() {
+			if err := replacedInf.Close(); err != nil {
+				logger.LogError("Failed to close replaced Informatica client", err)
+			}
+		})
+	}
+	logger.Info("Config reload completed with %d changes", len(changes))
+	logger.SetLevel(next.Logging.Level)
+}
+
+// keepStartupSettings copies the settings that only take effect at startup from
+// the running configuration into next
+func keepStartupSettings(next, running *config.Config) {
+	next.Mode = running.Mode
+	next.Server.Port = running.Server.Port
+	next.Server.Host = running.Server.Host
+	next.Server.TLS = running.Server.TLS
+	next.Server.EnableGzip = running.Server.EnableGzip
+	next.Server.MetricsPrefix = running.Server.MetricsPrefix
+	next.Server.WaitForDependencies = running.Server.WaitForDependencies
+	next.Server.DependencyTimeout = running.Server.DependencyTimeout
+	next.Paths = running.Paths
+	next.NFS = running.NFS
+	next.Database = running.Database
+	next.Logging.FilePath = running.Logging.FilePath
+	next.Logging.FileLog = running.Logging.FileLog
+	next.Logging.JSONLog = running.Logging.JSONLog
+	next.Logging.AuditFile = running.Logging.AuditFile
+	next.Alerts = running.Alerts
+	next.BusinessDay = running.BusinessDay
+	next.SLA = running.SLA
+}
+
+// yarnSettingsChanged reports whether any setting used to build the Yarn client differs
+func yarnSettingsChanged(old, next *config.Config) bool {
+	a, b := old.Services, next.Services
+	a.InformaticaDB, b.InformaticaDB = config.InformaticaConfig{}, config.InformaticaConfig{}
+	return a != b
+}
@@ -0,0 +1,159 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// unifiedSearchTimeout bounds /api/search as a whole; backends that haven't
+// answered by then are reported in errors and their results left out
+const unifiedSearchTimeout = 10 * time.Second
+
+// searchResult is one workflow found by /api/search. Source says which backend
+// it came from ("nfs" or "informatica"); the other fields are set as available.
+type searchResult struct {
+	Source    string     `json:"source"`
+	Name      string     `json:"name"`
+	Status    string     `json:"status"`
+	Time      *time.Time `json:"time,omitempty"`       // newest log modification (nfs) or start time (informatica)
+	NFSSource string     `json:"nfs_source,omitempty"` // source directory of an nfs workflow
+	StatID    int64      `json:"stat_id,omitempty"`    // run ID of an informatica workflow
+}
+
+// searchOutcome is what one backend returned to handleUnifiedSearch
+type searchOutcome struct {
+	backend string
+	results []searchResult
+	err     error
+}
+
+// handleUnifiedSearch finds today's workflows whose name contains q (ignoring
+// case) in both the NFS logs and Informatica. The backends are queried
+// concurrently; if one fails or is too slow the other's results are still
+// returned, with the failure listed under errors.
+func (s *Server) handleUnifiedSearch(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "q parameter is required")
+		return
+	}
+	logger.InfoCtx(r.Context(), "Handling unified search request for %q", q)
+
+	ctx, cancel := context.WithTimeout(r.Context(), unifiedSearchTimeout)
+	defer cancel()
+
+	backends := map[string]func(context.Context, string) ([]searchResult, error){
+		"nfs":         s.searchNFSWorkflows,
+		"informatica": s.searchInformaticaWorkflows,
+	}
+	// Buffered so a backend finishing after the timeout doesn't block forever
+	outcomes := make(chan searchOutcome, len(backends))
+	for name, search := range backends {
+		go func(name string, search func(context.Context, string) ([]searchResult, error)) {
+			results, err := search(ctx, q)
+			outcomes <- searchOutcome{backend: name, results: results, err: err}
+		}(name, search)
+	}
+
+	results := []searchResult{}
+	errs := make(map[string]string)
+	pending := make(map[string]bool, len(backends))
+	for name := range backends {
+		pending[name] = true
+	}
+	for len(pending) > 0 {
+		select {
+		case outcome := <-outcomes:
+			delete(pending, outcome.backend)
+			if outcome.err != nil {
+				logger.LogErrorCtx(r.Context(), "Unified search failed for "+outcome.backend, outcome.err)
+				errs[outcome.backend] = outcome.err.Error()
+				continue
+			}
+			results = append(results, outcome.results...)
+		case <-ctx.Done():
+			for name := range pending {
+				errs[name] = "timed out"
+			}
+			pending = nil
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Source != results[j].Source {
+			return results[i].Source < results[j].Source
+		}
+		return results[i].Name < results[j].Name
+	})
+
+	body := map[string]interface{}{
+		"query":   q,
+		"count":   len(results),
+		"results": results,
+	}
+	if len(errs) > 0 {
+		body["errors"] = errs
+	}
+	writeJSON(w, body)
+}
+
+// searchNFSWorkflows matches q against the names of today's scanned workflows
+func (s *Server) searchNFSWorkflows(ctx context.Context, q string) ([]searchResult, error) {
+	if s.nfsScanner == nil {
+		return nil, errors.New("NFS scanner not available")
+	}
+	summaries, err := s.nfsScanner.ScanTodaysLogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	needle := strings.ToLower(q)
+	var results []searchResult
+	for _, summary := range summaries {
+		if !strings.Contains(strings.ToLower(summary.Workflow), needle) {
+			continue
+		}
+		result := searchResult{
+			Source:    "nfs",
+			Name:      summary.Workflow,
+			Status:    summary.Status,
+			NFSSource: summary.Source,
+		}
+		if t := newestModTime(summary); !t.IsZero() {
+			result.Time = &t
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// searchInformaticaWorkflows matches q against today's Informatica workflow names
+func (s *Server) searchInformaticaWorkflows(ctx context.Context, q string) ([]searchResult, error) {
+	infClient := s.currentInfClient()
+	if infClient == nil {
+		return nil, errors.New("Informatica client not available")
+	}
+	workflows, err := infClient.SearchWorkflows(ctx, q, "")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]searchResult, 0, len(workflows))
+	for _, wf := range workflows {
+		started := wf.StartedAt
+		results = append(results, searchResult{
+			Source: "informatica",
+			Name:   wf.WorkflowName,
+			Status: wf.Status,
+			Time:   &started,
+			StatID: wf.StatID,
+		})
+	}
+	return results, nil
+}
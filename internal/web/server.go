@@ -1,19 +1,34 @@
 package web
 
 import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
 	"embed"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"io/fs"
+	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"salam-monitoring/internal/audit"
 	"salam-monitoring/internal/config"
+	"salam-monitoring/internal/history"
 	"salam-monitoring/internal/informatica"
 	"salam-monitoring/internal/logger"
 	"salam-monitoring/internal/nfs"
+	"salam-monitoring/internal/tasks"
 	"salam-monitoring/internal/yarn"
 
 	"github.com/gorilla/mux"
@@ -21,15 +36,33 @@ import (
 
 // Server represents the web server
 type Server struct {
+	// mu guards config and the clients, which Reload swaps while handlers run
+	mu          sync.RWMutex
 	config      *config.Config
 	staticFiles embed.FS
 	templates   *template.Template
+	fragments   *template.Template // HTMX fragments, see renderFragment
 	router      *mux.Router
 	infClient   *informatica.Client
 	yarnClient  *yarn.Client
 	nfsScanner  *nfs.Scanner
+	readiness   readinessState
+	tasks       *tasks.Registry
+	startTime   time.Time
+	metrics     *serverMetrics
+	history     *history.Store
+	killTokens  *killTokenSigner
+	killLimiter *rateLimiter
+	audit       *audit.Log
+	nfsScan     nfsScanState
+	backend     backendMetricsState // cached values behind the /metrics backend gauges
+	yarnHub     *yarnHub            // shared RM poller behind /ws/yarn
+	static      *staticAssets       // hashed /static/ files
 }
 
+// Version is the application version reported by /healthz; set by main before NewServer
+var Version = "dev"
+
 // NewServer creates a new web server instance
 func NewServer(cfg *config.Config, staticFiles embed.FS) *Server {
 	logger.Info("Initializing web server...")
@@ -38,53 +71,54 @@ func NewServer(cfg *config.Config, staticFiles embed.FS) *Server {
 		config:      cfg,
 		staticFiles: staticFiles,
 		router:      mux.NewRouter(),
+		tasks:       tasks.NewRegistry(),
+		killLimiter: newRateLimiter(),
+		startTime:   time.Now(),
 	}
+	server.metrics = newServerMetrics(server, cfg.Server.MetricsPrefix)
 
-	// Initialize Informatica client if in production mode
-	if cfg.IsProdMode() {
-		infConfig := informatica.DatabaseConfig{
-			Host:       cfg.Services.InformaticaDB.Host,
-			Port:       cfg.Services.InformaticaDB.Port,
-			Database:   cfg.Services.InformaticaDB.Database,
-			Username:   cfg.Services.InformaticaDB.Username,
-			Password:   cfg.Services.InformaticaDB.Password,
-			TimeOffset: cfg.Services.InformaticaDB.TimeOffset,
-		}
+	// Initialize Informatica client; test mode serves mock data
+	infClient, err := NewInformaticaClient(cfg)
+	if err != nil {
+		logger.LogError("Failed to initialize Informatica client", err)
+	}
+	server.infClient = infClient
 
-		infClient, err := informatica.NewClient(infConfig)
-		if err != nil {
-			logger.LogError("Failed to initialize Informatica client", err)
-		} else {
-			server.infClient = infClient
-		}
-	} else {
-		// In test mode, create a mock client
-		infConfig := informatica.DatabaseConfig{
-			Host:       "localhost",
-			Port:       1433,
-			Database:   "INFORMATICA_TEST",
-			Username:   "test",
-			Password:   "test",
-			TimeOffset: 3,
-		}
+	// Open workflow history; persistence is optional so failures only disable it
+	store, err := history.Open(cfg.Database.SQLitePath)
+	if err != nil {
+		logger.LogError("Failed to open history database, history disabled", err)
+		store = &history.Store{}
+	}
+	server.history = store
 
-		infClient, err := informatica.NewClient(infConfig)
-		if err != nil {
-			logger.LogError("Failed to initialize Informatica mock client", err)
-		} else {
-			server.infClient = infClient
-		}
+	// Audit trail for kills; without it kills still work but are only in the main log
+	auditLog, err := audit.Open(cfg.Logging.AuditFile, store)
+	if err != nil {
+		logger.LogError("Failed to open audit log, kills are recorded in the history database only", err)
+		auditLog, _ = audit.Open("", store)
 	}
+	server.audit = auditLog
 
 	// Initialize NFS scanner
-	nfsScanner := nfs.NewScanner(cfg.GetNFSRoot())
-	server.nfsScanner = nfsScanner
+	server.nfsScanner = NewNFSScanner(cfg, nfs.WithScanObserver(server.metrics.observeNFSScan))
 	logger.Info("NFS scanner initialized for root: %s", cfg.GetNFSRoot())
 
+	// Kills need a signed confirmation; without a key they are refused
+	killTokens, err := newKillTokenSigner()
+	if err != nil {
+		logger.LogError("Failed to initialize kill confirmations, Yarn kills disabled", err)
+	}
+	server.killTokens = killTokens
+
 	// Initialize Yarn client
-	yarnClient := yarn.NewClient(cfg.Services.YarnRMURL)
-	server.yarnClient = yarnClient
-	logger.Info("Yarn client initialized for RM: %s", cfg.Services.YarnRMURL)
+	server.yarnClient = NewYarnClient(cfg, auditLog.ObserveKill)
+	server.yarnHub = newYarnHub(server)
+
+	// Without the dependency gate the server is ready as soon as it is constructed
+	if !cfg.Server.WaitForDependencies {
+		server.readiness.set(true, nil)
+	}
 
 	server.setupRoutes()
 	server.loadTemplates()
@@ -95,38 +129,185 @@ func NewServer(cfg *config.Config, staticFiles embed.FS) *Server {
 
 // Start starts the web server
 func (s *Server) Start() error {
-	addr := fmt.Sprintf(":%d", s.config.Server.Port)
-	logger.Info("Starting HTTP server on %s", addr)
+	addr := fmt.Sprintf(":%d", s.currentConfig().Server.Port)
+
+	tlsConfig := s.currentConfig().Server.TLS
+	if tlsConfig.Enabled {
+		if err := validateTLSFiles(tlsConfig.CertFile, tlsConfig.KeyFile); err != nil {
+			return err
+		}
+	}
+
+	if s.currentConfig().Server.WaitForDependencies {
+		go s.waitForDependencies()
+	}
+	s.startAlerts()
+	s.startScheduledNFSScan()
+	s.startHistoryRecorder()
+	s.startBackendMetrics()
+
+	handler := s.corsMiddleware(s.router)
+
+	if tlsConfig.Enabled {
+		logger.Info("Starting HTTPS server on %s (cert: %s)", addr, tlsConfig.CertFile)
+		fmt.Printf("Server starting on https://localhost%s\n", addr)
+		return http.ListenAndServeTLS(addr, tlsConfig.CertFile, tlsConfig.KeyFile, handler)
+	}
+
+	logger.Info("Starting HTTP server on %s (TLS disabled)", addr)
 	fmt.Printf("Server starting on http://localhost%s\n", addr)
-	return http.ListenAndServe(addr, s.router)
+	return http.ListenAndServe(addr, handler)
+}
+
+// validateTLSFiles checks that the certificate and key are readable and form a valid pair
+// so a misconfiguration is reported at startup rather than on the first handshake
+func validateTLSFiles(certFile, keyFile string) error {
+	for _, file := range []struct{ name, path string }{{"certificate", certFile}, {"key", keyFile}} {
+		if file.path == "" {
+			return fmt.Errorf("TLS is enabled but no %s file is configured", file.name)
+		}
+		f, err := os.Open(file.path)
+		if err != nil {
+			return fmt.Errorf("TLS %s file %s is not readable: %w", file.name, file.path, err)
+		}
+		f.Close()
+	}
+
+	if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		return fmt.Errorf("invalid TLS certificate/key pair (%s, %s): %w", certFile, keyFile, err)
+	}
+	return nil
+}
+
+// requestIDMiddleware tags each request with an ID, reusing a well-formed inbound
+// X-Request-ID, and echoes it on the response so log lines can be correlated
+func (s *Server) requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-ID")
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set("X-Request-ID", id)
+		next.ServeHTTP(w, r.WithContext(logger.WithRequestID(r.Context(), id)))
+	})
+}
+
+// validRequestID accepts short IDs made of letters, digits, '-' and '_'
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random 16-character hex ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
 }
 
 // loggingMiddleware logs all HTTP requests
 func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
-		next.ServeHTTP(w, r)
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
 		duration := time.Since(start)
-		logger.LogRequest(r.Method, r.URL.Path, r.RemoteAddr, 200, duration)
+		logger.LogRequest(r.Context(), r.Method, r.URL.Path, r.RemoteAddr, recorder.status, recorder.bytes, duration)
+		s.metrics.observeRequest(r, recorder.status, duration)
 	})
 }
 
+// statusRecorder captures the status code and body size written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+// WriteHeader records the status code before passing it on
+func (rec *statusRecorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.status = status
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// Write counts the bytes written to the response
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	n, err := rec.ResponseWriter.Write(b)
+	rec.bytes += int64(n)
+	return n, err
+}
+
+// Flush forwards to the underlying writer so streamed responses still reach the client
+func (rec *statusRecorder) Flush() {
+	if flusher, ok := rec.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack hands the connection to WebSocket upgrades, which are logged as 101
+func (rec *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rec.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil && !rec.wroteHeader {
+		rec.status = http.StatusSwitchingProtocols
+		rec.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
 // setupRoutes configures all the routes
 func (s *Server) setupRoutes() {
 	logger.Info("Setting up HTTP routes...")
 
-	// Add logging middleware
+	// Add request ID and logging middleware
+	s.router.Use(s.requestIDMiddleware)
 	s.router.Use(s.loggingMiddleware)
 
-	// Static files
+	if s.currentConfig().Server.EnableGzip {
+		s.router.Use(s.gzipMiddleware)
+		logger.Info("Gzip response compression enabled")
+	}
+
+	// Always installed so a config reload can turn authentication on or off
+	s.router.Use(s.authMiddleware)
+	if s.authEnabled() {
+		logger.Info("Basic authentication enabled for user %s", s.currentConfig().Server.AuthUser)
+	}
+
+	// Also always installed; a request_timeout of 0 turns it off
+	s.router.Use(s.timeoutMiddleware)
+
+	// Static files, with cache validators and content-hash cache busting
 	staticSubFS, err := fs.Sub(s.staticFiles, "static")
 	if err != nil {
 		logger.LogError("Failed to create static sub-filesystem", err)
 		staticSubFS = s.staticFiles
 	}
-	s.router.PathPrefix("/static/").Handler(
-		http.StripPrefix("/static/", http.FileServer(http.FS(staticSubFS))),
-	)
+	s.static = newStaticAssets(staticSubFS, s.startTime, func() int { return s.currentConfig().Server.StaticMaxAge })
+	s.router.PathPrefix("/static/").Handler(http.StripPrefix("/static/", s.static))
+	s.router.Handle("/favicon.ico", s.static).Methods("GET", "HEAD")
 
 	// Main pages
 	s.router.HandleFunc("/", s.handleHome).Methods("GET")
@@ -135,21 +316,52 @@ func (s *Server) setupRoutes() {
 	s.router.HandleFunc("/informatica", s.handleInformatica).Methods("GET")
 	s.router.HandleFunc("/dashboard", s.handleDashboard).Methods("GET")
 	s.router.HandleFunc("/health", s.handleHealth).Methods("GET")
+	s.router.HandleFunc("/health/json", s.handleHealthJSON).Methods("GET")
+	s.router.HandleFunc("/readyz", s.handleReady).Methods("GET")
+	s.router.HandleFunc("/healthz", s.handleHealthz).Methods("GET")
+	s.router.Handle("/metrics", s.metrics.handler()).Methods("GET")
 
 	// HTMX endpoints
 	s.router.HandleFunc("/api/nfs/logs", s.handleNFSLogs).Methods("GET")
 	s.router.HandleFunc("/api/nfs/search", s.handleNFSSearch).Methods("POST")
 	s.router.HandleFunc("/api/nfs/log-content", s.handleNFSLogContent).Methods("GET")
+	s.router.HandleFunc("/api/nfs/download", s.handleNFSDownload).Methods("GET")
+	s.router.HandleFunc("/api/nfs/export", s.handleNFSExport).Methods("GET")
+	s.router.HandleFunc("/api/nfs/archive", s.handleNFSArchive).Methods("GET")
+	s.router.HandleFunc("/api/nfs/dates", s.handleNFSDates).Methods("GET")
 	s.router.HandleFunc("/api/yarn/apps", s.handleYarnApps).Methods("GET")
 	s.router.HandleFunc("/api/yarn/cluster-metrics", s.handleYarnClusterMetrics).Methods("GET")
-	s.router.HandleFunc("/api/yarn/kill", s.handleYarnKill).Methods("POST")
+	s.router.HandleFunc("/api/yarn/kill", s.killRateLimit(s.handleYarnKill)).Methods("POST")
+	s.router.HandleFunc("/api/yarn/kill/confirm", s.handleYarnKillConfirm).Methods("GET")
+	s.router.HandleFunc("/api/yarn/kill-bulk", s.killRateLimit(s.handleYarnKillBulk)).Methods("POST")
+	s.router.HandleFunc("/api/yarn/kill-stale", s.killRateLimit(s.handleYarnKillStale)).Methods("POST")
+	s.router.HandleFunc("/api/yarn/kill-stale/confirm", s.handleYarnKillStaleConfirm).Methods("GET")
+	s.router.HandleFunc("/api/yarn/app/{id}/detail", s.handleYarnAppDetail).Methods("GET")
+	s.router.HandleFunc("/api/yarn/nodes", s.handleYarnNodes).Methods("GET")
+	s.router.HandleFunc("/api/yarn/queues", s.handleYarnQueues).Methods("GET")
+	s.router.HandleFunc("/api/yarn/summary", s.handleYarnSummary).Methods("GET")
 	s.router.HandleFunc("/api/informatica/workflows", s.handleInformaticaWorkflows).Methods("GET")
+	s.router.HandleFunc("/api/dashboard/overview", s.handleDashboardOverview).Methods("GET")
 	s.router.HandleFunc("/api/dashboard/yarn-summary", s.handleDashboardYarnSummary).Methods("GET")
+	s.router.HandleFunc("/api/dashboard/informatica-summary", s.handleDashboardInformaticaSummary).Methods("GET")
+	s.router.HandleFunc("/api/dashboard/nfs-summary", s.handleDashboardNFSSummary).Methods("GET")
+	s.router.HandleFunc("/api/dashboard/yarn-failures", s.handleDashboardYarnFailures).Methods("GET")
 	s.router.HandleFunc("/api/health/status", s.handleHealthStatus).Methods("GET")
+	s.router.HandleFunc("/api/stream/dashboard", s.handleDashboardStream).Methods("GET")
+	s.router.HandleFunc("/ws/yarn", s.handleYarnWebSocket).Methods("GET")
 
 	// New Informatica endpoints as per specs
 	s.router.HandleFunc("/informatica/workflows/today", s.handleInformaticaWorkflowsToday).Methods("GET")
+	s.router.HandleFunc("/informatica/workflows", s.handleInformaticaWorkflowsRange).Methods("GET")
+	s.router.HandleFunc("/informatica/workflows/export", s.handleInformaticaExport).Methods("GET")
 	s.router.HandleFunc("/informatica/workflow/{statId:[0-9]+}", s.handleInformaticaWorkflowDetail).Methods("GET")
+	s.router.HandleFunc("/informatica/workflow/{statId:[0-9]+}/tree", s.handleInformaticaWorkflowTree).Methods("GET")
+	s.router.HandleFunc("/informatica/task-log", s.handleInformaticaTaskLog).Methods("GET")
+
+	// JSON API for scripts and external automation
+	s.router.HandleFunc("/api/v1/nfs/workflows", s.handleNFSWorkflowsJSON).Methods("GET")
+	s.router.HandleFunc("/api/history/workflow", s.handleWorkflowHistory).Methods("GET")
+	s.router.HandleFunc("/api/search", s.handleUnifiedSearch).Methods("GET")
 
 	logger.Info("HTTP routes configured successfully")
 }
@@ -158,50 +370,74 @@ func (s *Server) setupRoutes() {
 func (s *Server) loadTemplates() {
 	logger.Info("Loading HTML templates...")
 	var err error
-	s.templates, err = template.ParseFS(s.staticFiles, "templates-deploy/*.html")
+	s.templates, err = template.New("").Funcs(s.templateFuncs()).ParseFS(s.staticFiles, "templates-deploy/*.html")
 	if err != nil {
 		logger.LogError("Failed to load templates", err)
 	} else {
 		logger.Info("Templates loaded successfully")
 	}
+	s.fragments, err = template.New("").Funcs(s.templateFuncs()).ParseFS(s.staticFiles, "templates-deploy/"+fragmentPattern)
+	if err != nil {
+		logger.LogError("Failed to load fragment templates", err)
+	}
+
+	if cfg := s.currentConfig(); cfg.Server.DevMode {
+		logger.Info("Dev mode: page templates are re-parsed from %s on every request", cfg.Server.TemplateDir)
+	} else {
+		logger.Info("Page templates served from the embedded filesystem")
+	}
+}
+
+// pageTemplates returns the templates for rendering a page. In dev mode they are
+// parsed from the template directory on disk for every call so edits show up
+// without a rebuild; otherwise the embedded set loaded at startup is used.
+func (s *Server) pageTemplates() (*template.Template, error) {
+	cfg := s.currentConfig()
+	if !cfg.Server.DevMode {
+		return s.templates, nil
+	}
+	return template.New("").Funcs(s.templateFuncs()).ParseFS(os.DirFS(cfg.Server.TemplateDir), "*.html")
 }
 
 // Template data structure
 type TemplateData struct {
-	Title   string
-	Mode    string
-	IsProd  bool
-	NFSRoot string
-	Data    interface{}
+	Title string
+	Brand string // product name, see config.ServerConfig.BrandName
+	// RefreshInterval is the seconds between panel refreshes, 0 when auto-refresh is disabled
+	RefreshInterval int
+	Mode            string
+	IsProd          bool
+	NFSRoot         string
+	Data            interface{}
 }
 
 // Route handlers
 func (s *Server) handleHome(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling home page request")
+	logger.InfoCtx(r.Context(), "Handling home page request")
 	data := map[string]string{
-		"message":    "Welcome to Salam Unified Monitoring Platform",
+		"message":    "Welcome to " + s.currentConfig().Server.BrandName + " Unified Monitoring Platform",
 		"LastUpdate": time.Now().Format("2006-01-02 15:04:05"),
 	}
 	s.renderPageTemplate(w, "Dashboard", "index.html", data)
 }
 
 func (s *Server) handleNFS(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling NFS page request")
+	logger.InfoCtx(r.Context(), "Handling NFS page request")
 	s.renderPageTemplate(w, "NFS Monitoring", "nfs.html", nil)
 }
 
 func (s *Server) handleYarn(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling Yarn page request")
+	logger.InfoCtx(r.Context(), "Handling Yarn page request")
 	s.renderPageTemplate(w, "Yarn Applications", "yarn.html", nil)
 }
 
 func (s *Server) handleInformatica(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling Informatica page request")
+	logger.InfoCtx(r.Context(), "Handling Informatica page request")
 	s.renderPageTemplate(w, "Informatica Workflows", "informatica.html", nil)
 }
 
 func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling dashboard page request")
+	logger.InfoCtx(r.Context(), "Handling dashboard page request")
 	data := map[string]string{
 		"message":    "Dashboard Overview",
 		"LastUpdate": time.Now().Format("2006-01-02 15:04:05"),
@@ -210,26 +446,42 @@ func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling health page request")
+	logger.InfoCtx(r.Context(), "Handling health page request")
 	s.renderPageTemplate(w, "System Health", "health.html", nil)
 }
 
 // renderPageTemplate renders a full page template with layout
 func (s *Server) renderPageTemplate(w http.ResponseWriter, title, contentTemplate string, data interface{}) {
+	// An out-of-range interval was already reported by Validate; don't let it
+	// make every open page poll the APIs
+	refreshInterval := s.currentConfig().Server.RefreshInterval
+	if refreshInterval < 0 || (refreshInterval > 0 && refreshInterval < config.MinRefreshInterval) {
+		refreshInterval = config.DefaultRefreshInterval
+	}
+
 	templateData := TemplateData{
-		Title:   title,
-		Mode:    s.config.Mode,
-		IsProd:  s.config.IsProdMode(),
-		NFSRoot: s.config.GetNFSRoot(),
-		Data:    data,
+		Title:           title,
+		Brand:           s.currentConfig().Server.BrandName,
+		RefreshInterval: refreshInterval,
+		Mode:            s.currentConfig().Mode,
+		IsProd:          s.currentConfig().IsProdMode(),
+		NFSRoot:         s.currentConfig().GetNFSRoot(),
+		Data:            data,
 	}
 
-	if s.templates != nil {
+	templates, err := s.pageTemplates()
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to parse templates for %s", contentTemplate), err)
+		s.renderFallbackHTML(w, title, fmt.Sprintf("Template error: %v", err))
+		return
+	}
+
+	if templates != nil {
 		// First try to render the layout which includes the content template
-		if err := s.templates.ExecuteTemplate(w, "layout.html", templateData); err != nil {
+		if err := templates.ExecuteTemplate(w, "layout.html", templateData); err != nil {
 			logger.LogError(fmt.Sprintf("Failed to execute template layout for %s", contentTemplate), err)
 			// Fallback: try to render just the content template directly
-			if err2 := s.templates.ExecuteTemplate(w, contentTemplate, templateData); err2 != nil {
+			if err2 := templates.ExecuteTemplate(w, contentTemplate, templateData); err2 != nil {
 				logger.LogError(fmt.Sprintf("Fallback template execution also failed for %s", contentTemplate), err2)
 				s.renderFallbackHTML(w, title, fmt.Sprintf("Template errors: %v, %v", err, err2))
 			}
@@ -249,7 +501,7 @@ func (s *Server) renderFallbackHTML(w http.ResponseWriter, title, message string
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>%s - Salam Monitoring</title>
+    <title>%s - %s Monitoring</title>
     <script src="https://cdn.tailwindcss.com"></script>
 </head>
 <body class="bg-gray-100">
@@ -267,22 +519,82 @@ func (s *Server) renderFallbackHTML(w http.ResponseWriter, title, message string
         </div>
     </div>
 </body>
-</html>`, title, title, message, s.config.Mode, s.config.GetNFSRoot())
+</html>`, template.HTMLEscapeString(title), template.HTMLEscapeString(s.currentConfig().Server.BrandName), template.HTMLEscapeString(title), template.HTMLEscapeString(message),
+		template.HTMLEscapeString(s.currentConfig().Mode), template.HTMLEscapeString(s.currentConfig().GetNFSRoot()))
 
 	w.Write([]byte(html))
 }
 
+// wantsJSON reports whether the client asked for JSON via ?format=json or the Accept header
+func wantsJSON(r *http.Request) bool {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return strings.EqualFold(format, "json")
+	}
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+// writeJSON encodes data as a JSON response
+func writeJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		logger.LogError("Failed to encode JSON response", err)
+	}
+}
+
+// writeFragmentError reports an error as a JSON error envelope or as an HTML fragment
+// depending on the request. HTML errors keep a 200 status so HTMX still swaps the
+// message into the page; the message is escaped there, so callers pass it raw.
+func writeFragmentError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	if wantsJSON(r) {
+		writeJSONError(w, status, code, message)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	fmt.Fprintf(w, `<div class="text-red-600">%s</div>`, template.HTMLEscapeString(message))
+}
+
 // HTMX API handlers
 func (s *Server) handleNFSLogs(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling NFS logs request")
+	logger.InfoCtx(r.Context(), "Handling NFS logs request")
 
 	if s.nfsScanner == nil {
-		logger.Error("NFS scanner not available")
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, `<div class="text-red-600">NFS scanner not available</div>`)
+		logger.ErrorCtx(r.Context(), "NFS scanner not available")
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeNFSUnavailable, "NFS scanner not available")
+		return
+	}
+
+	filteredWorkflows, err := s.fetchNFSWorkflows(r)
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to scan NFS logs", err)
+		writeFragmentError(w, r, fetchErrorStatus(err), errCodeNFSFailed, fmt.Sprintf("Failed to scan NFS logs: %v", err))
 		return
 	}
 
+	page := parsePagination(r)
+	start, end := page.bounds(len(filteredWorkflows))
+	page.setTotalHeader(w)
+
+	if wantsJSON(r) {
+		writeJSON(w, filteredWorkflows[start:end])
+		return
+	}
+	s.renderFragment(w, "nfs-logs", filteredWorkflows[start:end])
+	page.renderControls(w, r, "#logs-container")
+}
+
+// errInvalidQuery marks fetch errors caused by bad query parameters rather than backend failures
+var errInvalidQuery = errors.New("invalid query")
+
+// fetchErrorStatus maps a fetch error to the HTTP status it should be reported with
+func fetchErrorStatus(err error) int {
+	if errors.Is(err, errInvalidQuery) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// fetchNFSWorkflows scans the requested date and applies the source and status filters
+func (s *Server) fetchNFSWorkflows(r *http.Request) ([]*nfs.WorkflowSummary, error) {
 	// Get query parameters
 	source := r.URL.Query().Get("source")
 	status := r.URL.Query().Get("status")
@@ -294,84 +606,35 @@ func (s *Server) handleNFSLogs(w http.ResponseWriter, r *http.Request) {
 
 	if dateStr != "" {
 		// Use specific date
-		workflowSummaries, err = s.nfsScanner.ScanLogsForDate(dateStr)
+		workflowSummaries, err = s.nfsScanner.ScanLogsForDate(r.Context(), dateStr)
 	} else {
 		// Use today's logs
-		workflowSummaries, err = s.nfsScanner.ScanTodaysLogs()
+		workflowSummaries, err = s.nfsScanner.ScanTodaysLogs(r.Context())
+	}
+	if errors.Is(err, nfs.ErrInvalidDate) {
+		return nil, fmt.Errorf("%w: %v", errInvalidQuery, err)
 	}
-
 	if err != nil {
-		logger.LogError("Failed to scan NFS logs", err)
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, `<div class="text-red-600">Failed to scan NFS logs: %v</div>`, err)
-		return
+		return nil, err
 	}
 
 	// Filter workflows by source and status
-	filteredWorkflows := filterWorkflows(workflowSummaries, source, status)
-
-	w.Header().Set("Content-Type", "text/html")
-	if len(filteredWorkflows) == 0 {
-		fmt.Fprintf(w, `<div class="text-gray-600 p-8 text-center">No logs found for the selected criteria</div>`)
-		return
-	}
-
-	// Render workflows
-	fmt.Fprintf(w, `<div class="space-y-6">`)
-	for _, workflow := range filteredWorkflows {
-		statusClass := getWorkflowStatusClass(workflow.Status)
-		fmt.Fprintf(w, `
-			<div class="bg-white rounded-xl shadow-sm border border-gray-200 overflow-hidden hover:shadow-md transition-shadow">
-				<div class="px-6 py-4 bg-gradient-to-r from-gray-50 to-white border-b border-gray-200">
-					<div class="flex items-center justify-between">
-						<div class="flex items-center space-x-4">
-							<h3 class="text-lg font-semibold text-gray-900">%s</h3>
-							<span class="px-3 py-1 text-xs font-medium rounded-full %s">%s</span>
-						</div>
-						<div class="flex items-center space-x-2 text-sm text-gray-500">
-							<span>%s</span>
-							<span>•</span>
-							<span>%d files</span>
-						</div>
-					</div>
-				</div>
-				<div class="px-6 py-4">
-					<div class="space-y-3">
-		`, workflow.Workflow, statusClass, workflow.Status, workflow.Source, len(workflow.Logs))
-
-		for _, log := range workflow.Logs {
-			errorIcon := ""
-			if log.HasErrors {
-				errorIcon = `<svg class="w-5 h-5 text-red-500" fill="currentColor" viewBox="0 0 20 20"><path fill-rule="evenodd" d="M18 10a8 8 0 11-16 0 8 8 0 0116 0zm-7 4a1 1 0 11-2 0 1 1 0 012 0zm-1-9a1 1 0 00-1 1v4a1 1 0 102 0V6a1 1 0 00-1-1z" clip-rule="evenodd"></path></svg>`
-			}
+	filtered := filterWorkflows(workflowSummaries, source, status)
 
-			fmt.Fprintf(w, `
-				<div class="flex items-center justify-between p-3 bg-gray-50 rounded-lg hover:bg-gray-100 transition-colors cursor-pointer" 
-					 onclick="showLogDetails('%s', '%s', '%s')">
-					<div class="flex items-center space-x-3">
-						%s
-						<div>
-							<div class="font-medium text-gray-900">%s</div>
-							<div class="text-sm text-gray-500">%s • %.1f KB</div>
-						</div>
-					</div>
-					<div class="text-xs text-gray-400">%s</div>
-				</div>
-			`, log.FilePath, log.LogType, log.Workflow, errorIcon, log.LogType, log.Date, float64(log.Size)/1024, log.ModTime.Format("15:04"))
+	// Optionally keep only workflows whose logs changed recently
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		since, err := nfs.ParseSince(sinceStr, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidQuery, err)
 		}
-
-		fmt.Fprintf(w, `
-					</div>
-				</div>
-			</div>
-		`)
+		filtered = nfs.FilterModifiedSince(filtered, since)
 	}
-	fmt.Fprintf(w, `</div>`)
+	return filtered, nil
 }
 
 // filterWorkflows filters workflows by source and status
 func filterWorkflows(workflows []*nfs.WorkflowSummary, source, status string) []*nfs.WorkflowSummary {
-	var filtered []*nfs.WorkflowSummary
+	filtered := make([]*nfs.WorkflowSummary, 0, len(workflows))
 	for _, workflow := range workflows {
 		// Filter by source
 		if source != "" && workflow.Source != source {
@@ -388,9 +651,11 @@ func filterWorkflows(workflows []*nfs.WorkflowSummary, source, status string) []
 
 // getWorkflowStatusClass returns CSS classes for workflow status
 func getWorkflowStatusClass(status string) string {
-	switch status {
+	switch strings.ToLower(status) {
 	case "completed":
 		return "bg-green-100 text-green-800"
+	case "completed with warnings":
+		return "bg-amber-100 text-amber-800"
 	case "failed":
 		return "bg-red-100 text-red-800"
 	case "running":
@@ -401,7 +666,7 @@ func getWorkflowStatusClass(status string) string {
 }
 
 func (s *Server) handleNFSSearch(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling NFS search request")
+	logger.InfoCtx(r.Context(), "Handling NFS search request")
 
 	searchQuery := r.FormValue("search")
 	if searchQuery == "" {
@@ -410,154 +675,361 @@ func (s *Server) handleNFSSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// TODO: Implement search functionality
-	w.Header().Set("Content-Type", "text/html")
-	fmt.Fprintf(w, `<div class="bg-yellow-100 p-4 rounded">Search for "%s" - Feature coming soon!</div>`, searchQuery)
+	if s.nfsScanner == nil {
+		logger.ErrorCtx(r.Context(), "NFS scanner not available")
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeNFSUnavailable, "NFS scanner not available")
+		return
+	}
+
+	var results []*nfs.LogEntry
+	var err error
+	if r.FormValue("regex") == "true" {
+		results, err = s.nfsScanner.SearchLogsRegex(r.Context(), searchQuery)
+	} else {
+		results, err = s.nfsScanner.SearchLogs(r.Context(), searchQuery)
+	}
+	switch {
+	case errors.Is(err, nfs.ErrInvalidPattern):
+		writeFragmentError(w, r, http.StatusBadRequest, errCodeInvalidParameter, err.Error())
+		return
+	case errors.Is(err, context.DeadlineExceeded):
+		logger.LogErrorCtx(r.Context(), "NFS search timed out", err)
+		writeFragmentError(w, r, http.StatusGatewayTimeout, errCodeRequestTimeout, "NFS search timed out; try a narrower search")
+		return
+	case err != nil:
+		logger.LogErrorCtx(r.Context(), "NFS search failed", err)
+		writeFragmentError(w, r, http.StatusInternalServerError, errCodeNFSFailed, "NFS search failed")
+		return
+	}
+
+	if wantsJSON(r) {
+		if results == nil {
+			results = []*nfs.LogEntry{}
+		}
+		writeJSON(w, results)
+		return
+	}
+
+	s.renderFragment(w, "nfs-search-results", struct {
+		Query   string
+		Results []*nfs.LogEntry
+	}{searchQuery, results})
 }
 
+// logContentMaxLines caps how many lines of a log the highlighted view renders;
+// the raw download always has the whole file
+const logContentMaxLines = 5000
+
+// logSeverityClasses color log lines by severity on the terminal-style background
+var logSeverityClasses = map[string]string{
+	nfs.SeverityError: "text-red-400",
+	nfs.SeverityWarn:  "text-yellow-300",
+	nfs.SeverityInfo:  "text-green-400",
+}
+
+// handleNFSLogContent shows a log file under the NFS root with each line colored
+// by severity, using the scanner's error patterns. raw=true downloads the plain
+// text instead.
 func (s *Server) handleNFSLogContent(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling NFS log content request")
+	logger.InfoCtx(r.Context(), "Handling NFS log content request")
 
 	filePath := r.URL.Query().Get("path")
 	if filePath == "" {
 		http.Error(w, "File path required", http.StatusBadRequest)
 		return
 	}
+	if s.nfsScanner == nil {
+		http.Error(w, "NFS scanner not available", http.StatusServiceUnavailable)
+		return
+	}
+	level := strings.ToUpper(r.URL.Query().Get("level"))
+	minRank, ok := nfs.LevelRank(level)
+	if level != "" && !ok {
+		http.Error(w, "Unknown log level", http.StatusBadRequest)
+		return
+	}
 
-	// TODO: Read and return actual log file content
-	w.Header().Set("Content-Type", "text/html")
-	fmt.Fprintf(w, `
-		<div class="bg-gray-900 text-green-400 p-4 rounded font-mono text-sm overflow-x-auto">
-			<div class="mb-2 text-gray-400">File: %s</div>
-			<pre class="whitespace-pre-wrap">2024-11-21 10:30:00 INFO  Starting workflow execution\n2024-11-21 10:30:01 INFO  Connecting to database\n2024-11-21 10:30:02 INFO  Processing data batch 1/10\n2024-11-21 10:30:05 WARN  Slow query detected\n2024-11-21 10:30:10 INFO  Workflow completed successfully</pre>
-		</div>
-	`, filePath)
+	file, err := s.nfsScanner.OpenLog(filePath)
+	switch {
+	case errors.Is(err, nfs.ErrOutsideRoot):
+		http.Error(w, "File is not an NFS log", http.StatusForbidden)
+		return
+	case errors.Is(err, fs.ErrNotExist):
+		http.Error(w, "Log file not found", http.StatusNotFound)
+		return
+	case err != nil:
+		logger.LogErrorCtx(r.Context(), "Failed to open NFS log", err)
+		http.Error(w, "Failed to read log file", http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	if r.URL.Query().Get("raw") == "true" {
+		name := strings.TrimSuffix(filepath.Base(filePath), ".gz")
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Disposition", attachment(name))
+		if _, err := io.Copy(w, file); err != nil {
+			logger.LogErrorCtx(r.Context(), "Failed to send NFS log", err)
+		}
+		return
+	}
+
+	view := logContentView{Path: filePath, MaxLines: logContentMaxLines, Level: level}
+	if level != "" {
+		err := s.filterLogContent(r.Context(), file, minRank, &view)
+		if r.Context().Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.LogErrorCtx(r.Context(), "Failed to read NFS log", err)
+			view.ReadError = err.Error()
+		}
+		s.renderFragment(w, "nfs-log-content", view)
+		return
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		// A slow mount can outlast the request; stop reading once it is abandoned
+		if r.Context().Err() != nil {
+			return
+		}
+		if len(view.Lines) == logContentMaxLines {
+			view.Truncated = true
+			break
+		}
+		line := scanner.Text()
+		view.Lines = append(view.Lines, logContentLine{Text: line, Class: logSeverityClasses[s.nfsScanner.LineSeverity(line)]})
+	}
+	if err := scanner.Err(); err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to read NFS log", err)
+		view.ReadError = err.Error()
+	}
+	s.renderFragment(w, "nfs-log-content", view)
+}
+
+// filterLogContent fills view with the log entries at minRank or above, each
+// with its continuation lines, counting the log's lines and the lines that
+// passed the filter. Entries without a level are dropped.
+func (s *Server) filterLogContent(ctx context.Context, file io.Reader, minRank int, view *logContentView) error {
+	return s.nfsScanner.ScanLogEntries(file, func(entry nfs.ParsedLine) bool {
+		// A slow mount can outlast the request; stop reading once it is abandoned
+		if ctx.Err() != nil {
+			return false
+		}
+		lines := entry.LineCount()
+		view.TotalLines += lines
+
+		level := s.nfsScanner.EntryLevel(entry)
+		if rank, ok := nfs.LevelRank(level); !ok || rank < minRank {
+			return true
+		}
+		view.ShownLines += lines
+		if view.Truncated {
+			return true
+		}
+
+		class := logSeverityClasses[nfs.LevelSeverity(level)]
+		for _, line := range strings.Split(entry.Raw, "\n") {
+			if len(view.Lines) == logContentMaxLines {
+				view.Truncated = true
+				break
+			}
+			view.Lines = append(view.Lines, logContentLine{Text: line, Class: class})
+		}
+		return true
+	})
+}
+
+// logContentView is the highlighted log shown by handleNFSLogContent
+type logContentView struct {
+	Path      string
+	Lines     []logContentLine
+	MaxLines  int
+	Truncated bool   // the log has more than MaxLines lines
+	ReadError string // why reading stopped early, if it did
+
+	// Level is the minimum level shown, empty when the log is unfiltered. The
+	// counts are set only when filtering.
+	Level      string
+	TotalLines int // lines in the log
+	ShownLines int // lines in entries at Level or above, including any past MaxLines
+}
+
+// logContentLine is one log line with the text class for its severity
+type logContentLine struct {
+	Text  string
+	Class string
 }
 
 func (s *Server) handleDashboardYarnSummary(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling dashboard Yarn summary request")
+	logger.InfoCtx(r.Context(), "Handling dashboard Yarn summary request")
 
-	if s.yarnClient == nil {
+	if s.currentYarnClient() == nil {
+		if wantsJSON(r) {
+			writeJSONError(w, http.StatusServiceUnavailable, errCodeYarnUnavailable, "Yarn client not available")
+			return
+		}
 		w.Header().Set("Content-Type", "text/html")
 		fmt.Fprintf(w, `<div class="text-gray-600">Yarn client not available</div>`)
 		return
 	}
 
-	metrics, err := s.yarnClient.GetClusterMetrics()
+	metrics, err := s.currentYarnClient().GetClusterMetricsContext(r.Context())
 	if err != nil {
+		if wantsJSON(r) {
+			writeJSONError(w, http.StatusBadGateway, errCodeYarnFailed, "Unable to connect to Yarn RM")
+			return
+		}
 		w.Header().Set("Content-Type", "text/html")
 		fmt.Fprintf(w, `<div class="text-gray-600">Unable to connect to Yarn RM</div>`)
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/html")
-	fmt.Fprintf(w, `
-		<div class="grid grid-cols-2 gap-4">
-			<div class="bg-blue-50 p-4 rounded-lg">
-				<div class="text-2xl font-bold text-blue-600">%d</div>
-				<div class="text-sm text-gray-600">Running Apps</div>
-			</div>
-			<div class="bg-green-50 p-4 rounded-lg">
-				<div class="text-2xl font-bold text-green-600">%.1f GB</div>
-				<div class="text-sm text-gray-600">Available Memory</div>
-			</div>
-		</div>
-	`, metrics.AppsRunning, float64(metrics.AvailableMB)/1024)
+	if wantsJSON(r) {
+		writeJSON(w, metrics)
+		return
+	}
+
+	s.renderFragment(w, "dashboard-yarn-summary", metrics)
 }
 
-func (s *Server) handleYarnClusterMetrics(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling Yarn cluster metrics request")
+func (s *Server) handleDashboardInformaticaSummary(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling dashboard Informatica summary request")
 
-	if s.yarnClient == nil {
-		logger.Error("Yarn client not available")
+	if s.currentInfClient() == nil {
+		if wantsJSON(r) {
+			writeJSONError(w, http.StatusServiceUnavailable, errCodeInformaticaUnavailable, "Informatica client not available")
+			return
+		}
 		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, `<div class="text-red-600">Yarn client not available</div>`)
+		fmt.Fprintf(w, `<div class="text-gray-600">Informatica client not available</div>`)
 		return
 	}
 
-	metrics, err := s.yarnClient.GetClusterMetrics()
+	counts, err := s.currentInfClient().GetStatusCounts(r.Context())
 	if err != nil {
-		logger.LogError("Failed to get Yarn cluster metrics", err)
+		logger.LogErrorCtx(r.Context(), "Failed to count Informatica workflows", err)
+		if wantsJSON(r) {
+			writeJSONError(w, http.StatusBadGateway, errCodeInformaticaFailed, "Unable to query Informatica")
+			return
+		}
 		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, `<div class="text-red-600">Failed to get cluster metrics: %v</div>`, err)
+		fmt.Fprintf(w, `<div class="text-gray-600">Unable to query Informatica</div>`)
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/html")
-	fmt.Fprintf(w, `
-		<div class="bg-blue-50 p-3 rounded text-center">
-			<div class="text-2xl font-bold text-blue-600">%d</div>
-			<div class="text-sm text-gray-600">Running Apps</div>
-		</div>
-		<div class="bg-yellow-50 p-3 rounded text-center">
-			<div class="text-2xl font-bold text-yellow-600">%d</div>
-			<div class="text-sm text-gray-600">Pending Apps</div>
-		</div>
-		<div class="bg-green-50 p-3 rounded text-center">
-			<div class="text-2xl font-bold text-green-600">%.1f GB</div>
-			<div class="text-sm text-gray-600">Available Memory</div>
-		</div>
-		<div class="bg-purple-50 p-3 rounded text-center">
-			<div class="text-2xl font-bold text-purple-600">%d</div>
-			<div class="text-sm text-gray-600">Active Nodes</div>
-		</div>
-	`, metrics.AppsRunning, metrics.AppsPending, float64(metrics.AvailableMB)/1024, metrics.ActiveNodes)
+	if wantsJSON(r) {
+		writeJSON(w, counts)
+		return
+	}
+
+	s.renderFragment(w, "dashboard-informatica-summary", struct {
+		Counts map[string]int
+		Mock   bool
+	}{counts, s.currentInfClient().IsMockMode()})
+}
+
+func (s *Server) handleYarnClusterMetrics(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Yarn cluster metrics request")
+
+	if s.currentYarnClient() == nil {
+		logger.ErrorCtx(r.Context(), "Yarn client not available")
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeYarnUnavailable, "Yarn client not available")
+		return
+	}
+
+	metrics, err := s.currentYarnClient().GetClusterMetricsContext(r.Context())
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get Yarn cluster metrics", err)
+		writeFragmentError(w, r, http.StatusBadGateway, errCodeYarnFailed, fmt.Sprintf("Failed to get cluster metrics: %v", err))
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, metrics)
+		return
+	}
+	s.renderFragment(w, "yarn-cluster-metrics", metrics)
 }
 
 func (s *Server) handleYarnApps(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling Yarn applications request")
+	logger.InfoCtx(r.Context(), "Handling Yarn applications request")
 
-	if s.yarnClient == nil {
-		logger.Error("Yarn client not available")
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, `<div class="text-red-600">Yarn client not available</div>`)
+	if s.currentYarnClient() == nil {
+		logger.ErrorCtx(r.Context(), "Yarn client not available")
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeYarnUnavailable, "Yarn client not available")
 		return
 	}
 
 	// Get query parameters
-	state := r.URL.Query().Get("state")
+	query := r.URL.Query()
+	state := query.Get("state")
 	if state == "" {
 		state = "RUNNING"
 	}
+	// Blank filters mean no filtering; name is the older spelling of nameContains
+	nameContains := strings.TrimSpace(query.Get("nameContains"))
+	if nameContains == "" {
+		nameContains = strings.TrimSpace(query.Get("name"))
+	}
+	filter := yarn.AppFilter{
+		States:          config.SplitList(state),
+		Queue:           strings.TrimSpace(query.Get("queue")),
+		User:            strings.TrimSpace(query.Get("user")),
+		ApplicationType: strings.TrimSpace(query.Get("type")),
+		NameContains:    nameContains,
+	}
 
-	apps, err := s.yarnClient.GetApplicationsByState(state)
+	// Optional server-side ordering, e.g. sort=elapsedTime&order=desc; without
+	// sort the RM's order is kept
+	sortField := query.Get("sort")
+	order := strings.ToLower(query.Get("order"))
+	if order != "" && order != "asc" && order != "desc" {
+		writeFragmentError(w, r, http.StatusBadRequest, errCodeInvalidParameter, "order must be asc or desc")
+		return
+	}
+	if sortField != "" {
+		if _, err := yarn.LessFunc(sortField, false); err != nil {
+			writeFragmentError(w, r, http.StatusBadRequest, errCodeInvalidParameter, err.Error())
+			return
+		}
+	}
+
+	apps, err := s.currentYarnClient().GetApplicationsContext(r.Context(), filter)
 	if err != nil {
-		logger.LogError("Failed to get Yarn applications", err)
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, `<div class="text-red-600">Failed to connect to Yarn RM: %v</div>`, err)
+		logger.LogErrorCtx(r.Context(), "Failed to get Yarn applications", err)
+		writeFragmentError(w, r, http.StatusBadGateway, errCodeYarnFailed, fmt.Sprintf("Failed to connect to Yarn RM: %v", err))
 		return
 	}
+	if sortField != "" {
+		yarn.SortApplications(apps, sortField, order == "desc")
+	}
 
-	w.Header().Set("Content-Type", "text/html")
-	if len(apps) == 0 {
-		fmt.Fprintf(w, `<div class="text-gray-600 p-4">No %s applications found</div>`, state)
-		return
-	}
-
-	// Render applications table
-	fmt.Fprintf(w, `<div class="overflow-x-auto">`)
-	fmt.Fprintf(w, `<table class="min-w-full bg-white border border-gray-300">`)
-	fmt.Fprintf(w, `<thead class="bg-gray-50">`)
-	fmt.Fprintf(w, `<tr><th class="px-4 py-2 text-left">Application ID</th><th class="px-4 py-2 text-left">Name</th><th class="px-4 py-2 text-left">Type</th><th class="px-4 py-2 text-left">State</th><th class="px-4 py-2 text-left">Progress</th><th class="px-4 py-2 text-left">Actions</th></tr>`)
-	fmt.Fprintf(w, `</thead><tbody>`)
-
-	for _, app := range apps {
-		fmt.Fprintf(w, `<tr class="border-t">`)
-		fmt.Fprintf(w, `<td class="px-4 py-2 font-mono text-sm">%s</td>`, app.ID)
-		fmt.Fprintf(w, `<td class="px-4 py-2">%s</td>`, app.Name)
-		fmt.Fprintf(w, `<td class="px-4 py-2">%s</td>`, app.ApplicationType)
-		fmt.Fprintf(w, `<td class="px-4 py-2"><span class="px-2 py-1 text-xs rounded %s">%s</span></td>`,
-			getStateColor(app.State), app.State)
-		fmt.Fprintf(w, `<td class="px-4 py-2">%.1f%%</td>`, app.Progress)
-		fmt.Fprintf(w, `<td class="px-4 py-2">`)
-		if app.State == "RUNNING" {
-			fmt.Fprintf(w, `<button onclick="killApplication('%s')" class="bg-red-500 text-white px-2 py-1 rounded text-xs hover:bg-red-600">Kill</button>`, app.ID)
+	if wantsJSON(r) {
+		if apps == nil {
+			apps = []*yarn.Application{}
 		}
-		fmt.Fprintf(w, `</td>`)
-		fmt.Fprintf(w, `</tr>`)
+		writeJSON(w, apps)
+		return
+	}
+	client := s.currentYarnClient()
+	rows := make([]yarnAppRow, len(apps))
+	for i, app := range apps {
+		rows[i] = yarnAppRow{Application: app, Link: client.TrackingURL(r.Context(), app)}
 	}
+	s.renderFragment(w, "yarn-apps", struct {
+		State string
+		Apps  []yarnAppRow
+	}{state, rows})
+}
 
-	fmt.Fprintf(w, `</tbody></table></div>`)
+// yarnAppRow is an application in the applications table. Link is its UI,
+// resolved by Client.TrackingURL, or "" when it has none yet.
+type yarnAppRow struct {
+	*yarn.Application
+	Link string
 }
 
 // getStateColor returns CSS classes for different application states
@@ -579,10 +1051,10 @@ func getStateColor(state string) string {
 }
 
 func (s *Server) handleYarnKill(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling Yarn kill request")
+	logger.InfoCtx(r.Context(), "Handling Yarn kill request")
 
-	if s.yarnClient == nil {
-		logger.Error("Yarn client not available")
+	if s.currentYarnClient() == nil {
+		logger.ErrorCtx(r.Context(), "Yarn client not available")
 		http.Error(w, "Yarn client not available", http.StatusServiceUnavailable)
 		return
 	}
@@ -593,218 +1065,490 @@ func (s *Server) handleYarnKill(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := s.yarnClient.KillApplication(appID)
+	// Require the token from /api/yarn/kill/confirm so a stray or cross-site
+	// request cannot kill an application in one step
+	if s.killTokens == nil {
+		http.Error(w, "Kill confirmations unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	if err := s.killTokens.verify(appID, r.FormValue("token")); err != nil {
+		logger.ErrorCtx(r.Context(), "Rejected kill of %s: %v", appID, err)
+		http.Error(w, "Kill not confirmed: "+err.Error(), http.StatusForbidden)
+		return
+	}
+
+	err := s.currentYarnClient().KillApplicationContext(auditContext(r), appID)
 	if err != nil {
-		logger.LogError("Failed to kill Yarn application", err)
+		logger.LogErrorCtx(r.Context(), "Failed to kill Yarn application", err)
 		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, `<div class="text-red-600">Failed to kill application: %v</div>`, err)
+		fmt.Fprintf(w, `<div class="text-red-600">Failed to kill application: %s</div>`, template.HTMLEscapeString(err.Error()))
 		return
 	}
 
 	w.Header().Set("Content-Type", "text/html")
-	fmt.Fprintf(w, `<div class="text-green-600">Application %s killed successfully</div>`, appID)
+	fmt.Fprintf(w, `<div class="text-green-600">Application %s killed successfully</div>`, template.HTMLEscapeString(appID))
 }
 
 func (s *Server) handleInformaticaWorkflows(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling Informatica workflows request")
+	logger.InfoCtx(r.Context(), "Handling Informatica workflows request")
 
-	if s.infClient == nil {
-		logger.Error("Informatica client not available")
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, `<div class="text-red-600">Informatica client not available</div>`)
+	if s.currentInfClient() == nil {
+		logger.ErrorCtx(r.Context(), "Informatica client not available")
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeInformaticaUnavailable, "Informatica client not available")
 		return
 	}
 
-	view := r.URL.Query().Get("view")
-
-	var workflows []informatica.WorkflowStat
-	var err error
-
-	if view == "running" {
-		workflows, err = s.infClient.GetRunningWorkflows()
-	} else {
-		workflows, err = s.infClient.GetWorkflowsToday()
+	workflows, err := s.fetchInformaticaWorkflows(r, nil)
+	if errors.Is(err, informatica.ErrUnknownStatus) {
+		writeFragmentError(w, r, http.StatusBadRequest, errCodeInvalidParameter, err.Error())
+		return
 	}
 	if err != nil {
-		logger.LogError("Failed to get Informatica workflows", err)
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, `<div class="text-red-600">Failed to get workflows: %v</div>`, err)
+		logger.LogErrorCtx(r.Context(), "Failed to get Informatica workflows", err)
+		writeFragmentError(w, r, http.StatusInternalServerError, errCodeInformaticaFailed, fmt.Sprintf("Failed to get workflows: %v", err))
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/html")
-	if len(workflows) == 0 {
-		fmt.Fprintf(w, `<div class="text-gray-600 p-8 text-center">No workflows found for today</div>`)
+	if wantsJSON(r) {
+		writeJSON(w, workflows)
 		return
 	}
+	s.renderFragment(w, "informatica-workflows", workflows)
+}
 
-	// Render workflows
-	fmt.Fprintf(w, `<div class="space-y-4">`)
-	for _, workflow := range workflows {
-		statusClass := getInformaticaStatusClass(workflow.Status)
-		fmt.Fprintf(w, `
-			<div class="bg-white rounded-xl shadow-sm border border-gray-200 overflow-hidden hover:shadow-lg transition-all duration-300">
-				<div class="px-6 py-4 bg-gradient-to-r from-purple-50 to-indigo-50 border-b border-gray-200">
-					<div class="flex items-center justify-between">
-						<div class="flex items-center space-x-4">
-							<div class="flex-shrink-0">
-								<div class="w-10 h-10 bg-purple-100 rounded-full flex items-center justify-center">
-									<svg class="w-6 h-6 text-purple-600" fill="none" stroke="currentColor" viewBox="0 0 24 24">
-										<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M9 5H7a2 2 0 00-2 2v10a2 2 0 002 2h8a2 2 0 002-2V7a2 2 0 00-2-2h-2M9 5a2 2 0 002 2h2a2 2 0 002-2M9 5a2 2 0 012-2h2a2 2 0 012 2m-3 7h3m-3 4h3m-6-4h.01M9 16h.01"></path>
-									</svg>
-								</div>
-							</div>
-							<div>
-								<h3 class="text-lg font-semibold text-gray-900">%s</h3>
-								<p class="text-sm text-gray-600">%s</p>
-							</div>
-						</div>
-						<div class="flex items-center space-x-3">
-							<span class="px-3 py-1 text-xs font-medium rounded-full %s">%s</span>
-							<button onclick="showWorkflowDetails(%d)" class="text-indigo-600 hover:text-indigo-900 text-sm font-medium">
-								View Details
-							</button>
-						</div>
-					</div>
-				</div>
-				<div class="px-6 py-4">
-					<div class="grid grid-cols-2 md:grid-cols-4 gap-4 text-sm">
-						<div><span class="text-gray-500">Start Time:</span> <span class="font-medium">%s</span></div>
-						<div><span class="text-gray-500">End Time:</span> <span class="font-medium">%s</span></div>
-						<div><span class="text-gray-500">Duration:</span> <span class="font-medium">%s</span></div>
-						<div><span class="text-gray-500">Folder:</span> <span class="font-medium">%s</span></div>
-					</div>
-				</div>
-			</div>
-		`, workflow.WorkflowName, "Folder", statusClass, workflow.Status, workflow.StatID,
-			formatTime(workflow.StartedAt), formatTimePtr(workflow.FinishedAt),
-			calculateDurationPtr(workflow.StartedAt, workflow.FinishedAt), "Default")
+// fetchInformaticaWorkflows returns running, failed, matching or all of today's
+// workflows depending on the view, name and status params. When page is non-nil
+// only that page is returned and page.Total is set to the full count.
+func (s *Server) fetchInformaticaWorkflows(r *http.Request, page *pagination) ([]informatica.WorkflowStat, error) {
+	var workflows []informatica.WorkflowStat
+	var err error
+
+	q := r.URL.Query()
+	name, status := strings.TrimSpace(q.Get("name")), q.Get("status")
+	paged := false
+	switch {
+	case page != nil && name == "" && status == "" && q.Get("view") != "running" && q.Get("view") != "failures":
+		// Only the plain listing is paged in SQL; the filtered views are small
+		var total int
+		workflows, total, err = s.currentInfClient().GetWorkflowsTodayPaged(r.Context(), (page.Page-1)*page.PageSize, page.PageSize)
+		page.Total = total
+		paged = true
+	case name != "" || status != "":
+		workflows, err = s.currentInfClient().SearchWorkflows(r.Context(), name, status)
+	case q.Get("view") == "running":
+		workflows, err = s.currentInfClient().GetRunningWorkflows(r.Context())
+	case q.Get("view") == "failures":
+		workflows, err = s.currentInfClient().GetFailedWorkflowsToday(r.Context())
+	default:
+		workflows, err = s.currentInfClient().GetWorkflowsToday(r.Context())
 	}
-	fmt.Fprintf(w, `</div>`)
+	if err != nil {
+		return nil, err
+	}
+	if page != nil && !paged {
+		start, end := page.bounds(len(workflows))
+		workflows = workflows[start:end]
+	}
+	s.currentInfClient().AnnotateSLA(workflows)
+	if workflows == nil {
+		workflows = []informatica.WorkflowStat{}
+	}
+	return workflows, nil
 }
 
-// getInformaticaStatusClass returns CSS classes for Informatica workflow status
+// getInformaticaStatusClass returns CSS classes for Informatica workflow status.
+// It accepts both the client's status names (SUCCESS, FAILED, RUNNING) and the
+// PowerCenter display names (Succeeded, Failed, Running), in any case.
 func getInformaticaStatusClass(status string) string {
-	switch status {
-	case "Succeeded":
+	switch strings.ToUpper(status) {
+	case "SUCCESS", "SUCCEEDED":
 		return "bg-green-100 text-green-800"
-	case "Failed":
+	case "FAILED":
 		return "bg-red-100 text-red-800"
-	case "Running":
+	case "RUNNING":
 		return "bg-yellow-100 text-yellow-800"
-	case "Suspended":
+	case "SUSPENDED":
 		return "bg-orange-100 text-orange-800"
 	default:
 		return "bg-gray-100 text-gray-800"
 	}
 }
 
-// Helper functions for time formatting
-func formatTime(t time.Time) string {
-	if t.IsZero() {
-		return "N/A"
+func (s *Server) handleHealthStatus(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling health status request")
+
+	// Check various system components
+	health := map[string]string{
+		"Server":    "OK",
+		"Config":    "OK",
+		"Templates": "ERROR",
+	}
+	if s.templates != nil {
+		health["Templates"] = "OK"
 	}
-	return t.Format("15:04:05")
-}
 
-func formatTimePtr(t *time.Time) string {
-	if t == nil || t.IsZero() {
-		return "N/A"
+	dependencies := s.checkSubsystems(r.Context())
+	healthy := true
+	for name, status := range dependencies {
+		health[name] = status
+		if status == "ERROR" {
+			healthy = false
+		}
+	}
+
+	// Uptime monitors get a 503 when a dependency is down; HTMX requests keep 200
+	// so the panel is still swapped in and shows which check failed
+	w.Header().Set("Content-Type", "text/html")
+	if !healthy && r.Header.Get("HX-Request") == "" {
+		w.WriteHeader(http.StatusServiceUnavailable)
 	}
-	return t.Format("15:04:05")
+
+	fmt.Fprintf(w, `<div class="grid grid-cols-2 gap-4">`)
+	for _, name := range []string{"Server", "Config", "Templates", "NFS", "Yarn", "Informatica"} {
+		fmt.Fprintf(w, `<div class="bg-%s-100 p-4 rounded"><strong>%s:</strong> %s</div>`,
+			healthColor(health[name]), name, template.HTMLEscapeString(health[name]))
+	}
+	fmt.Fprintf(w, `</div>`)
+
+	s.renderTaskStatuses(w)
 }
 
-func calculateDuration(start, end time.Time) string {
-	if start.IsZero() || end.IsZero() {
-		return "N/A"
+// healthCheckTimeout bounds how long a single subsystem probe may take
+var healthCheckTimeout = 3 * time.Second
+
+// healthDegradedMock is reported for Informatica while it serves mock data
+// because the real database is unreachable
+const healthDegradedMock = "DEGRADED (mock)"
+
+// checkSubsystems probes NFS, Yarn and Informatica concurrently and returns
+// "OK" or "ERROR" for each, or healthDegradedMock for an Informatica client in
+// mock mode. Probes that exceed healthCheckTimeout count as errors; the Yarn and
+// Informatica probes are canceled at the deadline rather than left running.
+func (s *Server) checkSubsystems(ctx context.Context) map[string]string {
+	checks := map[string]func(context.Context) bool{
+		"NFS": func(context.Context) bool {
+			return s.nfsScanner != nil && s.nfsScanner.IsHealthy()
+		},
+		"Yarn": func(ctx context.Context) bool {
+			return s.currentYarnClient() != nil && s.currentYarnClient().IsHealthyContext(ctx)
+		},
+		"Informatica": func(ctx context.Context) bool {
+			return s.currentInfClient() != nil && s.currentInfClient().IsHealthyContext(ctx)
+		},
 	}
-	duration := end.Sub(start)
-	if duration < 0 {
-		return "In Progress"
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]string, len(checks))
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(context.Context) bool) {
+			defer wg.Done()
+
+			// Buffered so a probe that finishes after the deadline can still
+			// send and exit; os.Stat on a hung NFS mount can't be canceled
+			done := make(chan bool, 1)
+			go func() { done <- check(ctx) }()
+
+			status := "ERROR"
+			select {
+			case ok := <-done:
+				if ok {
+					status = "OK"
+				}
+			case <-ctx.Done():
+				logger.Error("%s health check timed out after %v", name, healthCheckTimeout)
+			}
+
+			mu.Lock()
+			results[name] = status
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	if results["Informatica"] == "OK" && s.currentInfClient().IsMockMode() {
+		results["Informatica"] = healthDegradedMock
+	}
+	return results
+}
+
+// healthColor returns the Tailwind color name for a health status
+func healthColor(status string) string {
+	switch status {
+	case "OK":
+		return "green"
+	case "ERROR":
+		return "red"
+	case healthDegradedMock:
+		return "yellow"
+	default:
+		return "gray"
 	}
-	return duration.Truncate(time.Second).String()
 }
 
-func calculateDurationPtr(start time.Time, end *time.Time) string {
-	if start.IsZero() || end == nil || end.IsZero() {
-		return "In Progress"
+// renderTaskStatuses renders the background task registry as an HTML list
+func (s *Server) renderTaskStatuses(w http.ResponseWriter) {
+	statuses := s.tasks.Snapshot()
+	if len(statuses) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, `<div class="mt-4 space-y-2"><h4 class="text-sm font-semibold text-gray-700">Background Tasks</h4>`)
+	for _, status := range statuses {
+		color := "green"
+		if status.Stale {
+			color = "red"
+		} else if status.LastError != "" {
+			color = "yellow"
+		}
+		fmt.Fprintf(w, `<div class="bg-%s-100 p-2 rounded text-sm"><strong>%s:</strong> %s</div>`,
+			color, template.HTMLEscapeString(status.Name), template.HTMLEscapeString(status.Summary()))
 	}
-	duration := end.Sub(start)
-	if duration < 0 {
-		return "In Progress"
+	fmt.Fprintf(w, `</div>`)
+}
+
+// handleHealthJSON reports background task health as JSON, returning 503 if any task is stale
+func (s *Server) handleHealthJSON(w http.ResponseWriter, r *http.Request) {
+	statuses := s.tasks.Snapshot()
+
+	status := "ok"
+	code := http.StatusOK
+	if !tasks.Healthy(statuses) {
+		status = "stale"
+		code = http.StatusServiceUnavailable
 	}
-	return duration.Truncate(time.Second).String()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": status,
+		"tasks":  statuses,
+	})
 }
 
-func (s *Server) handleHealthStatus(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling health status request")
+// criticalSubsystems are the dependencies whose failure makes the service unusable.
+// Other subsystems only degrade it.
+var criticalSubsystems = map[string]bool{"NFS": true}
+
+// handleHealthz reports subsystem health as JSON for probes: 200 when ok or degraded,
+// 503 when a critical subsystem is down
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	overall := "ok"
+	checks := make(map[string]string)
+	for name, status := range s.checkSubsystems(r.Context()) {
+		key := strings.ToLower(name)
+		switch {
+		case status == "OK":
+			checks[key] = "ok"
+		case criticalSubsystems[name]:
+			checks[key] = "down"
+			overall = "down"
+		default:
+			checks[key] = "degraded"
+			if overall == "ok" {
+				overall = "degraded"
+			}
+		}
+	}
 
-	// Check various system components
-	health := map[string]string{
-		"Server":      "OK",
-		"Config":      "OK",
-		"Templates":   "Unknown",
-		"NFS":         "Unknown",
-		"Yarn":        "Unknown",
-		"Informatica": "Unknown",
+	code := http.StatusOK
+	if overall == "down" {
+		code = http.StatusServiceUnavailable
 	}
 
-	if s.templates != nil {
-		health["Templates"] = "OK"
-	} else {
-		health["Templates"] = "ERROR"
+	body := map[string]interface{}{
+		"status":         overall,
+		"checks":         checks,
+		"version":        Version,
+		"uptime_seconds": int64(time.Since(s.startTime).Seconds()),
+	}
+	if s.nfsScanInterval() > 0 {
+		body["nfs_scan"] = s.nfsScan.get()
 	}
 
-	w.Header().Set("Content-Type", "text/html")
-	fmt.Fprintf(w, `
-		<div class="grid grid-cols-2 gap-4">
-			<div class="bg-green-100 p-4 rounded"><strong>Server:</strong> %s</div>
-			<div class="bg-green-100 p-4 rounded"><strong>Config:</strong> %s</div>
-			<div class="bg-%s-100 p-4 rounded"><strong>Templates:</strong> %s</div>
-			<div class="bg-gray-100 p-4 rounded"><strong>NFS:</strong> %s</div>
-			<div class="bg-gray-100 p-4 rounded"><strong>Yarn:</strong> %s</div>
-			<div class="bg-gray-100 p-4 rounded"><strong>Informatica:</strong> %s</div>
-		</div>
-	`, health["Server"], health["Config"],
-		map[string]string{"OK": "green", "ERROR": "red", "Unknown": "gray"}[health["Templates"]],
-		health["Templates"], health["NFS"], health["Yarn"], health["Informatica"])
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
 }
 
 // handleInformaticaWorkflowsToday returns today's workflows from Informatica in JSON format
 func (s *Server) handleInformaticaWorkflowsToday(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling Informatica workflows today request")
+	logger.InfoCtx(r.Context(), "Handling Informatica workflows today request")
 
-	if s.infClient == nil {
-		http.Error(w, "Informatica client not available", http.StatusServiceUnavailable)
+	if s.currentInfClient() == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeInformaticaUnavailable, "Informatica client not available")
 		return
 	}
 
-	view := r.URL.Query().Get("view")
+	// Paging is opt-in so existing callers keep receiving the full list
+	var page *pagination
+	if query := r.URL.Query(); query.Has("page") || query.Has("pageSize") {
+		p := parsePagination(r)
+		page = &p
+	}
 
-	var workflows []informatica.WorkflowStat
-	var err error
+	workflows, err := s.fetchInformaticaWorkflows(r, page)
+	if errors.Is(err, informatica.ErrUnknownStatus) {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, err.Error())
+		return
+	}
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get Informatica workflows", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeInformaticaFailed, "Failed to get workflows")
+		return
+	}
 
-	if view == "running" {
-		workflows, err = s.infClient.GetRunningWorkflows()
-	} else {
-		workflows, err = s.infClient.GetWorkflowsToday()
+	if page != nil {
+		page.setTotalHeader(w)
 	}
+	writeJSON(w, workflows)
+}
+
+// maxWorkflowRange bounds /informatica/workflows so one request can't pull months of runs
+const maxWorkflowRange = 31 * 24 * time.Hour
+
+// workflowRangeLayouts are the accepted from/to formats, read as wall-clock time in
+// the same offset-adjusted zone the Informatica client reports start times in
+var workflowRangeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+// parseWorkflowRangeBound parses a from/to value. A bare date as the upper bound
+// covers that whole day.
+func parseWorkflowRangeBound(value string, upper bool, loc *time.Location) (time.Time, error) {
+	for _, layout := range workflowRangeLayouts {
+		t, err := time.ParseInLocation(layout, value, loc)
+		if err != nil {
+			continue
+		}
+		if upper && layout == "2006-01-02" {
+			// AddDate rather than 24h so days with a DST change end at midnight
+			t = t.AddDate(0, 0, 1).Add(-time.Millisecond)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: expected YYYY-MM-DD or YYYY-MM-DDTHH:MM[:SS]", value)
+}
+
+// parseWorkflowRange parses and checks a from/to pair: to must not precede from
+// and the range must not exceed maxWorkflowRange
+func parseWorkflowRange(fromStr, toStr string, loc *time.Location) (time.Time, time.Time, error) {
+	from, err := parseWorkflowRangeBound(fromStr, false, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := parseWorkflowRangeBound(toStr, true, loc)
 	if err != nil {
-		logger.LogError("Failed to get Informatica workflows", err)
-		http.Error(w, "Failed to get workflows", http.StatusInternalServerError)
+		return time.Time{}, time.Time{}, err
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("to must not be before from")
+	}
+	if to.Sub(from) > maxWorkflowRange {
+		return time.Time{}, time.Time{}, fmt.Errorf("range must not exceed %d days", int(maxWorkflowRange.Hours()/24))
+	}
+	return from, to, nil
+}
+
+// handleInformaticaWorkflowsRange returns workflows started between the from and to query params as JSON
+func (s *Server) handleInformaticaWorkflowsRange(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Informatica workflows range request")
+
+	if s.currentInfClient() == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeInformaticaUnavailable, "Informatica client not available")
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(workflows)
+	query := r.URL.Query()
+	if query.Get("from") == "" || query.Get("to") == "" {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "from and to parameters are required")
+		return
+	}
+	from, to, err := parseWorkflowRange(query.Get("from"), query.Get("to"), s.currentInfClient().Location())
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, err.Error())
+		return
+	}
+
+	workflows, err := s.currentInfClient().GetWorkflowsBetween(r.Context(), from, to)
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get Informatica workflows", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeInformaticaFailed, "Failed to get workflows")
+		return
+	}
+	s.currentInfClient().AnnotateSLA(workflows)
+	if workflows == nil {
+		workflows = []informatica.WorkflowStat{}
+	}
+
+	writeJSON(w, workflows)
+}
+
+// handleNFSWorkflowsJSON returns NFS workflow summaries as JSON, filtered by source, status and date
+func (s *Server) handleNFSWorkflowsJSON(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling NFS workflows JSON request")
+
+	if s.nfsScanner == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeNFSUnavailable, "NFS scanner not available")
+		return
+	}
+
+	workflows, err := s.fetchNFSWorkflows(r)
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to scan NFS logs", err)
+		writeJSONError(w, fetchErrorStatus(err), errCodeNFSFailed, fmt.Sprintf("Failed to scan NFS logs: %v", err))
+		return
+	}
+
+	writeJSON(w, workflows)
+}
+
+// maxHistoryDays bounds the trend window so a typo can't scan years of history
+const maxHistoryDays = 365
+
+// handleWorkflowHistory returns per-day success/failure counts for a workflow as JSON
+func (s *Server) handleWorkflowHistory(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling workflow history request")
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "name parameter is required")
+		return
+	}
+
+	days := 30
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		d, err := strconv.Atoi(daysStr)
+		if err != nil || d < 1 || d > maxHistoryDays {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, fmt.Sprintf("days must be between 1 and %d", maxHistoryDays))
+			return
+		}
+		days = d
+	}
+
+	if !s.history.Enabled() {
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeHistoryUnavailable, "Workflow history is not enabled")
+		return
+	}
+
+	trend, err := s.history.GetWorkflowTrend(name, days)
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get workflow history", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeHistoryFailed, "Failed to get workflow history")
+		return
+	}
+
+	writeJSON(w, trend)
 }
 
 // handleInformaticaWorkflowDetail returns a specific workflow with its tasks
 func (s *Server) handleInformaticaWorkflowDetail(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Handling Informatica workflow detail request")
+	logger.InfoCtx(r.Context(), "Handling Informatica workflow detail request")
 
-	if s.infClient == nil {
-		http.Error(w, "Informatica client not available", http.StatusServiceUnavailable)
+	if s.currentInfClient() == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeInformaticaUnavailable, "Informatica client not available")
 		return
 	}
 
@@ -813,17 +1557,100 @@ func (s *Server) handleInformaticaWorkflowDetail(w http.ResponseWriter, r *http.
 
 	statID, err := strconv.ParseInt(statIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid stat ID", http.StatusBadRequest)
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidStatID, "Invalid stat ID")
 		return
 	}
 
-	workflowWithTasks, err := s.infClient.GetWorkflowWithTasks(statID)
+	workflowWithTasks, err := s.currentInfClient().GetWorkflowWithTasks(r.Context(), statID)
+	if errors.Is(err, informatica.ErrWorkflowNotFound) {
+		writeJSONError(w, http.StatusNotFound, errCodeWorkflowNotFound, "Workflow not found")
+		return
+	}
 	if err != nil {
-		logger.LogError("Failed to get workflow with tasks", err)
-		http.Error(w, "Failed to get workflow", http.StatusInternalServerError)
+		logger.LogErrorCtx(r.Context(), "Failed to get workflow with tasks", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeInformaticaFailed, "Failed to get workflow")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(workflowWithTasks)
 }
+
+// handleInformaticaWorkflowTree returns a workflow with its tasks and nested child workflows
+func (s *Server) handleInformaticaWorkflowTree(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Informatica workflow tree request")
+
+	if s.currentInfClient() == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeInformaticaUnavailable, "Informatica client not available")
+		return
+	}
+
+	statID, err := strconv.ParseInt(mux.Vars(r)["statId"], 10, 64)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidStatID, "Invalid stat ID")
+		return
+	}
+
+	tree, err := s.currentInfClient().GetWorkflowTree(r.Context(), statID)
+	if errors.Is(err, informatica.ErrWorkflowNotFound) {
+		writeJSONError(w, http.StatusNotFound, errCodeWorkflowNotFound, "Workflow not found")
+		return
+	}
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get workflow tree", err)
+		writeJSONError(w, http.StatusInternalServerError, errCodeInformaticaFailed, "Failed to get workflow tree")
+		return
+	}
+
+	writeJSON(w, tree)
+}
+
+// taskSessionLog is the JSON form of a task's session log
+type taskSessionLog struct {
+	StatID    int64  `json:"stat_id"`
+	Task      string `json:"task"`
+	Available bool   `json:"available"`
+	Log       string `json:"log"`
+}
+
+// handleInformaticaTaskLog returns the session error log of one task as JSON or
+// an HTML fragment. A missing log is reported in the body rather than as an
+// error so the surrounding page still renders.
+func (s *Server) handleInformaticaTaskLog(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Informatica task log request")
+
+	if s.currentInfClient() == nil {
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeInformaticaUnavailable, "Informatica client not available")
+		return
+	}
+
+	statID, err := strconv.ParseInt(r.URL.Query().Get("statId"), 10, 64)
+	if err != nil {
+		writeFragmentError(w, r, http.StatusBadRequest, errCodeInvalidStatID, "Invalid stat ID")
+		return
+	}
+	task := r.URL.Query().Get("task")
+	if task == "" {
+		writeFragmentError(w, r, http.StatusBadRequest, errCodeInvalidParameter, "Task name required")
+		return
+	}
+
+	result := taskSessionLog{StatID: statID, Task: task}
+	result.Log, err = s.currentInfClient().GetTaskSessionLog(r.Context(), statID, task)
+	switch {
+	case errors.Is(err, informatica.ErrLogUnavailable):
+		result.Log = "log unavailable"
+	case err != nil:
+		logger.LogErrorCtx(r.Context(), "Failed to get task session log", err)
+		result.Log = "log unavailable"
+	default:
+		result.Available = true
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, result)
+		return
+	}
+
+	s.renderFragment(w, "informatica-task-log", result)
+}
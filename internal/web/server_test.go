@@ -0,0 +1,177 @@
+package web
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"salam-monitoring/internal/config"
+)
+
+// newTestServer builds a test-mode server over temporary NFS, history and audit
+// paths, serving Yarn data from mock/yarn and templates from the source tree.
+// configure, when non-nil, adjusts the config before the server is built.
+func newTestServer(t *testing.T, configure func(*config.Config)) *Server {
+	t.Helper()
+	dir := t.TempDir()
+
+	cfg := config.LoadFromEnv()
+	cfg.Mode = "test"
+	cfg.Paths.NFSRoot = filepath.Join(dir, "nfs")
+	cfg.Services.YarnRMURLTest = "../../mock/yarn/apps.json"
+	cfg.Database.SQLitePath = filepath.Join(dir, "history.db")
+	cfg.Logging.AuditFile = filepath.Join(dir, "audit.log")
+	cfg.Server.DevMode = true
+	cfg.Server.TemplateDir = "../../cmd/templates-deploy"
+	if configure != nil {
+		configure(cfg)
+	}
+	return NewServer(cfg, embed.FS{})
+}
+
+func TestGetInformaticaStatusClass(t *testing.T) {
+	const defaultClass = "bg-gray-100 text-gray-800"
+	tests := []struct {
+		status string
+		want   string
+	}{
+		// Every status mapWorkflowState reports for a known POW_STATE
+		{"RUNNING", "bg-yellow-100 text-yellow-800"},
+		{"SUCCESS", "bg-green-100 text-green-800"},
+		{"FAILED", "bg-red-100 text-red-800"},
+		// PowerCenter display names, in any case
+		{"Running", "bg-yellow-100 text-yellow-800"},
+		{"Succeeded", "bg-green-100 text-green-800"},
+		{"failed", "bg-red-100 text-red-800"},
+		{"Suspended", "bg-orange-100 text-orange-800"},
+		// Unmapped states keep the neutral badge
+		{"UNKNOWN_5", defaultClass},
+		{"", defaultClass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := getInformaticaStatusClass(tt.status); got != tt.want {
+				t.Errorf("getInformaticaStatusClass(%q) = %q, want %q", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInformaticaStatusesHaveBadges(t *testing.T) {
+	s := newTestServer(t, nil)
+	workflows, err := s.currentInfClient().GetWorkflowsToday(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, wf := range workflows {
+		if getInformaticaStatusClass(wf.Status) == "bg-gray-100 text-gray-800" {
+			t.Errorf("status %q of %s gets the default badge", wf.Status, wf.WorkflowName)
+		}
+	}
+}
+
+func TestNFSSearchErrorStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       url.Values
+		noNFSRoot  bool
+		expired    bool
+		wantStatus int
+		wantCode   string
+	}{
+		{"invalid regex", url.Values{"search": {"("}, "regex": {"true"}}, false, false,
+			http.StatusBadRequest, errCodeInvalidParameter},
+		{"regex too long", url.Values{"search": {strings.Repeat("a", 300)}, "regex": {"true"}}, false, false,
+			http.StatusBadRequest, errCodeInvalidParameter},
+		// A broken NFS mount is the server's problem, not the caller's
+		{"scan failure", url.Values{"search": {"ERROR"}}, true, false,
+			http.StatusInternalServerError, errCodeNFSFailed},
+		{"deadline", url.Values{"search": {"ERROR"}}, false, true,
+			http.StatusGatewayTimeout, errCodeRequestTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, nil)
+			if !tt.noNFSRoot {
+				if err := os.MkdirAll(s.currentConfig().Paths.NFSRoot, 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/api/nfs/search", strings.NewReader(tt.form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.Header.Set("Accept", "application/json")
+			if tt.expired {
+				ctx, cancel := context.WithDeadline(r.Context(), time.Now().Add(-time.Second))
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+			s.handleNFSSearch(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", w.Code, tt.wantStatus)
+			}
+			var body jsonError
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Error.Code != tt.wantCode {
+				t.Errorf("got code %q, want %q", body.Error.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestNFSLogsRejectsInvalidDate(t *testing.T) {
+	s := newTestServer(t, nil)
+	if err := os.MkdirAll(s.currentConfig().Paths.NFSRoot, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, date := range []string{"not-a-date", "2024-13-01", "1999-01-01", "9999-01-01"} {
+		r := httptest.NewRequest(http.MethodGet, "/api/nfs/logs?format=json&date="+url.QueryEscape(date), nil)
+		w := httptest.NewRecorder()
+		s.handleNFSLogs(w, r)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("date %q: got status %d, want 400", date, w.Code)
+		}
+	}
+}
+
+func TestCheckSubsystemsCancelsProbes(t *testing.T) {
+	canceled := make(chan struct{}, 1)
+	rm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		select {
+		case canceled <- struct{}{}:
+		default:
+		}
+	}))
+	defer rm.Close()
+
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.Services.YarnRMURLTest = rm.URL
+	})
+	timeout := healthCheckTimeout
+	healthCheckTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { healthCheckTimeout = timeout })
+
+	if got := s.checkSubsystems(context.Background())["Yarn"]; got != "ERROR" {
+		t.Errorf("hung Yarn probe reported %q, want ERROR", got)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(2 * time.Second):
+		t.Error("the Yarn probe was left running after the health check timed out")
+	}
+}
@@ -0,0 +1,103 @@
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// staticImmutableMaxAge is how long a URL carrying the asset's content hash may
+// be cached: the hash changes whenever the content does, so it never goes stale
+const staticImmutableMaxAge = 365 * 24 * 60 * 60
+
+// staticAsset is one embedded file under /static/ with its cache validators
+type staticAsset struct {
+	content []byte
+	hash    string // truncated hex SHA-256 of content
+	etag    string
+}
+
+// staticAssets serves the embedded static files with ETag and Last-Modified
+// validators. Embedded files carry no modification time, so every asset uses the
+// time the server started; assets cannot change without a restart anyway.
+type staticAssets struct {
+	files    map[string]*staticAsset // by path relative to /static/
+	modTime  time.Time
+	maxAge   func() int
+	notFound http.Handler
+}
+
+// newStaticAssets reads and hashes every file in fsys. maxAge is consulted per
+// request so a config reload applies to the Cache-Control header.
+func newStaticAssets(fsys fs.FS, modTime time.Time, maxAge func() int) *staticAssets {
+	a := &staticAssets{
+		files:    make(map[string]*staticAsset),
+		modTime:  modTime.UTC().Truncate(time.Second),
+		maxAge:   maxAge,
+		notFound: http.NotFoundHandler(),
+	}
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(content)
+		hash := hex.EncodeToString(sum[:])[:16]
+		a.files[name] = &staticAsset{content: content, hash: hash, etag: `"` + hash + `"`}
+		return nil
+	})
+	if err != nil {
+		logger.LogError("Failed to hash static assets", err)
+	}
+	return a
+}
+
+// url returns the link for a static asset with its content hash appended for
+// cache busting, e.g. /static/css/styles.css?v=3f2a9c1d0b7e4a65. Unknown assets
+// get the plain path.
+func (a *staticAssets) url(name string) string {
+	name = strings.TrimPrefix(name, "/")
+	name = strings.TrimPrefix(name, "static/")
+	if asset, ok := a.files[name]; ok {
+		return "/static/" + name + "?v=" + asset.hash
+	}
+	return "/static/" + name
+}
+
+// ServeHTTP serves an asset by its path relative to /static/. Conditional
+// requests (If-None-Match, If-Modified-Since) that still match get 304.
+func (a *staticAssets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	asset, ok := a.files[name]
+	if !ok {
+		a.notFound.ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Set("ETag", asset.etag)
+	switch maxAge := a.maxAge(); {
+	case r.URL.Query().Get("v") == asset.hash:
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", staticImmutableMaxAge))
+	case maxAge > 0:
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	default:
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	http.ServeContent(w, r, name, a.modTime, bytes.NewReader(asset.content))
+}
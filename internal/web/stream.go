@@ -0,0 +1,117 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// Event names sent by /api/stream/dashboard. Subscribe with
+//
+//	const source = new EventSource("/api/stream/dashboard");
+//	source.addEventListener("cluster-metrics", e => render(JSON.parse(e.data)));
+const (
+	// eventClusterMetrics carries the Yarn cluster metrics object
+	eventClusterMetrics = "cluster-metrics"
+	// eventWorkflowCounts carries a workflowCounts object
+	eventWorkflowCounts = "workflow-counts"
+	// eventStreamError carries a streamError when a backend could not be queried
+	eventStreamError = "stream-error"
+)
+
+// workflowCounts summarises what is currently running across backends
+type workflowCounts struct {
+	YarnRunning        int64     `json:"yarn_running"`
+	InformaticaRunning int       `json:"informatica_running"`
+	Timestamp          time.Time `json:"timestamp"`
+}
+
+// streamError reports a backend failure without ending the stream
+type streamError struct {
+	Source  string `json:"source"`
+	Message string `json:"message"`
+}
+
+// handleDashboardStream pushes cluster metrics and running workflow counts as
+// Server-Sent Events every Server.StreamInterval seconds until the client disconnects
+func (s *Server) handleDashboardStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	interval := time.Duration(s.currentConfig().Server.StreamInterval) * time.Second
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	logger.Info("Dashboard stream opened by %s (interval %v)", r.RemoteAddr, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sendDashboardEvents(r.Context(), w); err != nil {
+			logger.LogError("Dashboard stream write failed", err)
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			logger.Info("Dashboard stream closed by %s", r.RemoteAddr)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDashboardEvents writes one round of dashboard events
+func (s *Server) sendDashboardEvents(ctx context.Context, w http.ResponseWriter) error {
+	counts := workflowCounts{Timestamp: time.Now()}
+
+	if s.currentYarnClient() != nil {
+		metrics, err := s.currentYarnClient().GetClusterMetricsContext(ctx)
+		if err != nil {
+			if err := writeEvent(w, eventStreamError, streamError{Source: "yarn", Message: err.Error()}); err != nil {
+				return err
+			}
+		} else {
+			counts.YarnRunning = metrics.AppsRunning
+			if err := writeEvent(w, eventClusterMetrics, metrics); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.currentInfClient() != nil {
+		running, err := s.currentInfClient().GetRunningWorkflows(ctx)
+		if err != nil {
+			if err := writeEvent(w, eventStreamError, streamError{Source: "informatica", Message: err.Error()}); err != nil {
+				return err
+			}
+		} else {
+			counts.InformaticaRunning = len(running)
+		}
+	}
+
+	return writeEvent(w, eventWorkflowCounts, counts)
+}
+
+// writeEvent writes a single SSE frame with a JSON payload
+func writeEvent(w http.ResponseWriter, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
@@ -0,0 +1,73 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+)
+
+// untimedRoutes stream or download their response, which http.TimeoutHandler
+// would buffer in full and cannot flush or hijack, so they run without a
+// deadline. Their handlers stop on their own when the client goes away.
+var untimedRoutes = map[string]bool{
+	"/ws/yarn":                      true,
+	"/api/stream/dashboard":         true,
+	"/api/nfs/download":             true,
+	"/api/nfs/archive":              true,
+	"/api/nfs/export":               true,
+	"/informatica/workflows/export": true,
+}
+
+// untimedRequest reports whether r must not be wrapped in a TimeoutHandler
+func untimedRequest(r *http.Request) bool {
+	if untimedRoutes[r.URL.Path] || r.Header.Get("Upgrade") != "" {
+		return true
+	}
+	// The raw log is a download too; the highlighted view is capped
+	return r.URL.Path == "/api/nfs/log-content" && r.URL.Query().Get("raw") == "true"
+}
+
+// timeoutMiddleware answers requests still running after request_timeout with
+// 503. The deadline is also set on the request context, so the Yarn,
+// Informatica and NFS calls made with it are canceled rather than left running.
+func (s *Server) timeoutMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timeout := time.Duration(s.currentConfig().Server.RequestTimeout) * time.Second
+		if timeout <= 0 || untimedRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		message := fmt.Sprintf("The request took longer than %v and was canceled; please try again", timeout)
+		body, contentType := timeoutBody(r, message)
+		tw := &timeoutContentTypeWriter{ResponseWriter: w, contentType: contentType}
+		http.TimeoutHandler(next, timeout, body).ServeHTTP(tw, r)
+	})
+}
+
+// timeoutBody renders the 503 message in the form the client asked for
+func timeoutBody(r *http.Request, message string) (body, contentType string) {
+	if wantsJSON(r) {
+		encoded, _ := json.Marshal(jsonError{Error: jsonErrorBody{Code: errCodeRequestTimeout, Message: message}})
+		return string(encoded), "application/json"
+	}
+	return fmt.Sprintf(`<div class="text-red-600">%s</div>`, template.HTMLEscapeString(message)), "text/html; charset=utf-8"
+}
+
+// timeoutContentTypeWriter labels the body http.TimeoutHandler writes on a
+// timeout, which it sends without a Content-Type. Responses from the handler
+// itself carry their own headers and pass through unchanged.
+type timeoutContentTypeWriter struct {
+	http.ResponseWriter
+	contentType string
+}
+
+// WriteHeader sets the timeout body's content type on an unlabeled 503
+func (tw *timeoutContentTypeWriter) WriteHeader(status int) {
+	if status == http.StatusServiceUnavailable && tw.Header().Get("Content-Type") == "" {
+		tw.Header().Set("Content-Type", tw.contentType)
+	}
+	tw.ResponseWriter.WriteHeader(status)
+}
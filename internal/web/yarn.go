@@ -0,0 +1,281 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"salam-monitoring/internal/config"
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/yarn"
+
+	"github.com/gorilla/mux"
+)
+
+// yarnAppDetail is an application together with its attempts and the containers
+// of its latest attempt
+type yarnAppDetail struct {
+	App        *yarn.Application  `json:"app"`
+	Attempts   []*yarn.AppAttempt `json:"attempts"`
+	Containers []*yarn.Container  `json:"containers"`
+}
+
+func (s *Server) handleYarnAppDetail(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Yarn application detail request")
+
+	if s.currentYarnClient() == nil {
+		logger.ErrorCtx(r.Context(), "Yarn client not available")
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeYarnUnavailable, "Yarn client not available")
+		return
+	}
+
+	appID := mux.Vars(r)["id"]
+	app, err := s.currentYarnClient().GetApplicationContext(r.Context(), appID)
+	if errors.Is(err, yarn.ErrNotFound) || (err == nil && app == nil) {
+		writeFragmentError(w, r, http.StatusNotFound, errCodeApplicationNotFound, "Application "+appID+" not found")
+		return
+	}
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get Yarn application", err)
+		writeFragmentError(w, r, http.StatusBadGateway, errCodeYarnFailed, fmt.Sprintf("Failed to connect to Yarn RM: %v", err))
+		return
+	}
+
+	detail := yarnAppDetail{
+		App:        app,
+		Attempts:   []*yarn.AppAttempt{},
+		Containers: []*yarn.Container{},
+	}
+
+	// Finished applications may no longer have attempts or containers on the RM,
+	// in which case the detail is returned with empty lists
+	attempts, err := s.currentYarnClient().GetAppAttemptsContext(r.Context(), appID)
+	if err != nil && !errors.Is(err, yarn.ErrNotFound) {
+		logger.LogErrorCtx(r.Context(), "Failed to get Yarn application attempts", err)
+		writeFragmentError(w, r, http.StatusBadGateway, errCodeYarnFailed, fmt.Sprintf("Failed to get attempts: %v", err))
+		return
+	}
+	if len(attempts) > 0 {
+		detail.Attempts = attempts
+		latest := attempts[len(attempts)-1]
+		containers, err := s.currentYarnClient().GetAppContainersContext(r.Context(), appID, latest.AppAttemptID)
+		if err != nil && !errors.Is(err, yarn.ErrNotFound) {
+			logger.LogErrorCtx(r.Context(), "Failed to get Yarn containers", err)
+			writeFragmentError(w, r, http.StatusBadGateway, errCodeYarnFailed, fmt.Sprintf("Failed to get containers: %v", err))
+			return
+		}
+		if len(containers) > 0 {
+			detail.Containers = containers
+		}
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, detail)
+		return
+	}
+	s.renderFragment(w, "yarn-app-detail", detail)
+}
+
+func (s *Server) handleYarnNodes(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Yarn nodes request")
+
+	if s.currentYarnClient() == nil {
+		logger.ErrorCtx(r.Context(), "Yarn client not available")
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeYarnUnavailable, "Yarn client not available")
+		return
+	}
+
+	states := config.SplitList(strings.ToUpper(r.URL.Query().Get("state")))
+	nodes, err := s.currentYarnClient().GetNodesContext(r.Context(), states...)
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get Yarn nodes", err)
+		writeFragmentError(w, r, http.StatusBadGateway, errCodeYarnFailed, fmt.Sprintf("Failed to connect to Yarn RM: %v", err))
+		return
+	}
+
+	if wantsJSON(r) {
+		if nodes == nil {
+			nodes = []*yarn.Node{}
+		}
+		writeJSON(w, nodes)
+		return
+	}
+	s.renderFragment(w, "yarn-nodes", nodes)
+}
+
+// getNodeStateColor returns CSS classes for different node states
+func getNodeStateColor(state string) string {
+	switch state {
+	case "RUNNING":
+		return "bg-green-100 text-green-800"
+	case "NEW", "REBOOTED":
+		return "bg-blue-100 text-blue-800"
+	case "DECOMMISSIONING", "SHUTDOWN":
+		return "bg-yellow-100 text-yellow-800"
+	case "UNHEALTHY", "LOST":
+		return "bg-red-100 text-red-800"
+	default:
+		return "bg-gray-100 text-gray-800"
+	}
+}
+
+func (s *Server) handleYarnQueues(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Yarn queues request")
+
+	if s.currentYarnClient() == nil {
+		logger.ErrorCtx(r.Context(), "Yarn client not available")
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeYarnUnavailable, "Yarn client not available")
+		return
+	}
+
+	info, err := s.currentYarnClient().GetSchedulerInfoContext(r.Context())
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get Yarn scheduler info", err)
+		writeFragmentError(w, r, http.StatusBadGateway, errCodeYarnFailed, fmt.Sprintf("Failed to connect to Yarn RM: %v", err))
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, info)
+		return
+	}
+	s.renderFragment(w, "yarn-queues", flattenQueues(nil, info.Root, 0))
+}
+
+// yarnQueueRow is a queue in the queue table, indented by its depth in the tree
+type yarnQueueRow struct {
+	Queue  *yarn.Queue
+	Indent float64 // left padding in rem
+}
+
+// flattenQueues appends a row for queue and, indented one level deeper, its children
+func flattenQueues(rows []yarnQueueRow, queue *yarn.Queue, depth int) []yarnQueueRow {
+	rows = append(rows, yarnQueueRow{Queue: queue, Indent: 1 + 1.5*float64(depth)})
+	for _, child := range queue.Children {
+		rows = flattenQueues(rows, child, depth+1)
+	}
+	return rows
+}
+
+// maxBulkKill caps how many applications one bulk kill request may name
+const maxBulkKill = 100
+
+// handleYarnKillBulk kills a JSON array of application IDs and reports a result
+// per application. Only application/json bodies are accepted: browsers cannot
+// send those cross-site without a CORS preflight, which this server never grants.
+func (s *Server) handleYarnKillBulk(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Yarn bulk kill request")
+
+	if s.currentYarnClient() == nil {
+		logger.ErrorCtx(r.Context(), "Yarn client not available")
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeYarnUnavailable, "Yarn client not available")
+		return
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		writeJSONError(w, http.StatusUnsupportedMediaType, errCodeUnsupportedMediaType, "Content-Type must be application/json")
+		return
+	}
+
+	var appIDs []string
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&appIDs); err != nil {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "Body must be a JSON array of application IDs")
+		return
+	}
+	if len(appIDs) == 0 {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "At least one application ID required")
+		return
+	}
+	if len(appIDs) > maxBulkKill {
+		writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, fmt.Sprintf("At most %d application IDs per request", maxBulkKill))
+		return
+	}
+	for _, id := range appIDs {
+		if strings.TrimSpace(id) == "" {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidParameter, "Application IDs must not be empty")
+			return
+		}
+	}
+
+	writeJSON(w, s.currentYarnClient().KillApplicationsContext(auditContext(r), appIDs))
+}
+
+// Defaults for the dashboard's recent failures panel
+const (
+	recentFailuresWindow = 6 * time.Hour
+	recentFailuresLimit  = 10
+)
+
+// handleDashboardYarnFailures lists the applications that failed or were killed
+// recently, newest first. hours and limit override the window and count.
+func (s *Server) handleDashboardYarnFailures(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling dashboard Yarn failures request")
+
+	if s.currentYarnClient() == nil {
+		writeFragmentError(w, r, http.StatusServiceUnavailable, errCodeYarnUnavailable, "Yarn client not available")
+		return
+	}
+
+	window := recentFailuresWindow
+	if hours, err := strconv.Atoi(r.URL.Query().Get("hours")); err == nil && hours > 0 {
+		window = time.Duration(hours) * time.Hour
+	}
+	limit := recentFailuresLimit
+	if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && n > 0 {
+		limit = min(n, maxBulkKill)
+	}
+
+	apps, err := s.currentYarnClient().GetRecentFailuresContext(r.Context(), window, limit)
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get recently failed Yarn applications", err)
+		writeFragmentError(w, r, http.StatusBadGateway, errCodeYarnFailed, fmt.Sprintf("Failed to connect to Yarn RM: %v", err))
+		return
+	}
+
+	if wantsJSON(r) {
+		if apps == nil {
+			apps = []*yarn.Application{}
+		}
+		writeJSON(w, apps)
+		return
+	}
+
+	s.renderFragment(w, "yarn-failures", struct {
+		Window time.Duration
+		Apps   []*yarn.Application
+	}{window, apps})
+}
+
+// firstLine returns s up to its first newline; RM diagnostics are often long stack traces
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
+
+// handleYarnSummary returns a compact JSON snapshot of cluster health: app
+// counts, memory and vcore utilization and node health
+func (s *Server) handleYarnSummary(w http.ResponseWriter, r *http.Request) {
+	logger.InfoCtx(r.Context(), "Handling Yarn summary request")
+
+	if s.currentYarnClient() == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, errCodeYarnUnavailable, "Yarn client not available")
+		return
+	}
+
+	metrics, err := s.currentYarnClient().GetClusterMetricsContext(r.Context())
+	if err == nil && metrics == nil {
+		err = errors.New("cluster metrics missing from RM response")
+	}
+	if err != nil {
+		logger.LogErrorCtx(r.Context(), "Failed to get Yarn cluster metrics", err)
+		writeJSONError(w, http.StatusBadGateway, errCodeYarnFailed, fmt.Sprintf("Failed to connect to Yarn RM: %v", err))
+		return
+	}
+
+	writeJSON(w, metrics.Summarize())
+}
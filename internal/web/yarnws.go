@@ -0,0 +1,301 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"salam-monitoring/internal/logger"
+	"salam-monitoring/internal/yarn"
+)
+
+// Message types sent on /ws/yarn. A client first receives a snapshot of the
+// active applications, then one message per change:
+//
+//	{"type":"snapshot","apps":[...]}
+//	{"type":"added","app":{...}}
+//	{"type":"state_changed","app":{...},"previous_state":"ACCEPTED"}
+//	{"type":"removed","app":{...}}
+//	{"type":"error","message":"..."}
+const (
+	yarnMsgSnapshot     = "snapshot"
+	yarnMsgAdded        = "added"
+	yarnMsgStateChanged = "state_changed"
+	yarnMsgRemoved      = "removed"
+	yarnMsgError        = "error"
+)
+
+// yarnActiveStates are the application states /ws/yarn tracks; an application
+// leaving them (finishing, failing or being killed) is reported as removed
+var yarnActiveStates = []string{"NEW", "NEW_SAVING", "SUBMITTED", "ACCEPTED", "RUNNING"}
+
+const (
+	// yarnWSSendBuffer is how many messages may queue for a client before it is
+	// considered too slow and disconnected
+	yarnWSSendBuffer = 64
+	// yarnWSWriteTimeout bounds a single write to a client
+	yarnWSWriteTimeout = 10 * time.Second
+	// yarnWSPingInterval keeps idle connections alive through proxies; a client
+	// that doesn't answer within yarnWSPongTimeout is dropped
+	yarnWSPingInterval = 30 * time.Second
+	yarnWSPongTimeout  = 60 * time.Second
+)
+
+// yarnMessage is one JSON message pushed to /ws/yarn clients
+type yarnMessage struct {
+	Type          string              `json:"type"`
+	App           *yarn.Application   `json:"app,omitempty"`
+	Apps          []*yarn.Application `json:"apps,omitempty"`
+	PreviousState string              `json:"previous_state,omitempty"`
+	Message       string              `json:"message,omitempty"`
+	Timestamp     time.Time           `json:"timestamp"`
+}
+
+// yarnHub polls the RM once per interval while at least one /ws/yarn client is
+// connected and fans the changes out to every client, so the RM load does not
+// grow with the number of open dashboards
+type yarnHub struct {
+	server *Server
+
+	mu      sync.Mutex
+	clients map[chan yarnMessage]bool
+	apps    map[string]*yarn.Application // last polled active applications by ID
+	polled  bool                         // apps holds a successful poll
+	cancel  context.CancelFunc           // stops the poll loop; nil while idle
+}
+
+// newYarnHub creates an idle hub for the server
+func newYarnHub(s *Server) *yarnHub {
+	return &yarnHub{server: s, clients: make(map[chan yarnMessage]bool)}
+}
+
+// subscribe registers a client and starts polling if it is the first one. The
+// returned channel first receives a snapshot when one is available.
+func (h *yarnHub) subscribe() chan yarnMessage {
+	send := make(chan yarnMessage, yarnWSSendBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clients[send] = true
+	if h.polled {
+		send <- yarnMessage{Type: yarnMsgSnapshot, Apps: sortedApps(h.apps), Timestamp: time.Now()}
+	}
+	if h.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		h.cancel = cancel
+		go h.run(ctx)
+	}
+	return send
+}
+
+// unsubscribe removes a client and stops polling after the last one leaves
+func (h *yarnHub) unsubscribe(send chan yarnMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.clients[send] {
+		return
+	}
+	delete(h.clients, send)
+	close(send)
+	h.stopIfIdle()
+}
+
+// stopIfIdle stops polling once no clients remain; h.mu must be held
+func (h *yarnHub) stopIfIdle() {
+	if len(h.clients) == 0 && h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+		h.apps, h.polled = nil, false
+	}
+}
+
+// run polls the RM until ctx is cancelled
+func (h *yarnHub) run(ctx context.Context) {
+	interval := time.Duration(h.server.currentConfig().Server.YarnPushInterval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	logger.Info("Yarn push started, polling the RM every %v", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		h.poll(ctx)
+		select {
+		case <-ctx.Done():
+			logger.Info("Yarn push stopped, no clients connected")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll fetches the active applications and broadcasts the differences from the
+// previous poll; the first successful poll is broadcast as a snapshot
+func (h *yarnHub) poll(ctx context.Context) {
+	pollCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	apps, err := h.server.currentYarnClient().GetApplicationsContext(pollCtx, yarn.AppFilter{States: yarnActiveStates})
+	cancel()
+	if ctx.Err() != nil {
+		return
+	}
+	now := time.Now()
+	if err != nil {
+		logger.LogError("Yarn push poll failed", err)
+		h.broadcast([]yarnMessage{{Type: yarnMsgError, Message: err.Error(), Timestamp: now}})
+		return
+	}
+
+	current := make(map[string]*yarn.Application, len(apps))
+	for _, app := range apps {
+		current[app.ID] = app
+	}
+
+	h.mu.Lock()
+	previous, polled := h.apps, h.polled
+	h.apps, h.polled = current, true
+	h.mu.Unlock()
+
+	if !polled {
+		h.broadcast([]yarnMessage{{Type: yarnMsgSnapshot, Apps: sortedApps(current), Timestamp: now}})
+		return
+	}
+	h.broadcast(diffApps(previous, current, now))
+}
+
+// diffApps lists the applications added, removed or changed state between two polls
+func diffApps(previous, current map[string]*yarn.Application, now time.Time) []yarnMessage {
+	var messages []yarnMessage
+	for _, app := range sortedApps(current) {
+		old, ok := previous[app.ID]
+		switch {
+		case !ok:
+			messages = append(messages, yarnMessage{Type: yarnMsgAdded, App: app, Timestamp: now})
+		case old.State != app.State:
+			messages = append(messages, yarnMessage{Type: yarnMsgStateChanged, App: app, PreviousState: old.State, Timestamp: now})
+		}
+	}
+	for _, app := range sortedApps(previous) {
+		if _, ok := current[app.ID]; !ok {
+			messages = append(messages, yarnMessage{Type: yarnMsgRemoved, App: app, Timestamp: now})
+		}
+	}
+	return messages
+}
+
+// sortedApps returns the applications ordered by ID, which sorts by submission
+func sortedApps(apps map[string]*yarn.Application) []*yarn.Application {
+	sorted := make([]*yarn.Application, 0, len(apps))
+	for _, app := range apps {
+		sorted = append(sorted, app)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	return sorted
+}
+
+// broadcast queues messages for every client. A client whose queue is full is
+// disconnected rather than allowed to hold up the others.
+func (h *yarnHub) broadcast(messages []yarnMessage) {
+	if len(messages) == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for send := range h.clients {
+		for _, msg := range messages {
+			select {
+			case send <- msg:
+			default:
+				logger.Error("Dropping slow /ws/yarn client")
+				delete(h.clients, send)
+				close(send)
+			}
+			if !h.clients[send] {
+				break
+			}
+		}
+	}
+	h.stopIfIdle()
+}
+
+// handleYarnWebSocket upgrades the request to a WebSocket and streams Yarn
+// application changes to it until either side closes the connection
+func (s *Server) handleYarnWebSocket(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{
+		// Same-origin pages always connect; other sites must be named in
+		// cors_allowed_origins, as browsers send cookies and credentials with the
+		// handshake regardless of CORS
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			return origin == "" || sameOrigin(r, origin) || s.corsOriginListed(origin)
+		},
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already written an error response
+		logger.LogErrorCtx(r.Context(), "Yarn WebSocket upgrade failed", err)
+		return
+	}
+	defer conn.Close()
+	logger.InfoCtx(r.Context(), "Yarn WebSocket opened by %s", r.RemoteAddr)
+
+	send := s.yarnHub.subscribe()
+	defer s.yarnHub.unsubscribe(send)
+
+	// The read loop only handles control frames; it ends when the client goes away
+	closed := make(chan struct{})
+	conn.SetReadLimit(512)
+	conn.SetReadDeadline(time.Now().Add(yarnWSPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(yarnWSPongTimeout))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(yarnWSPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-closed:
+			logger.InfoCtx(r.Context(), "Yarn WebSocket closed by %s", r.RemoteAddr)
+			return
+		case msg, ok := <-send:
+			conn.SetWriteDeadline(time.Now().Add(yarnWSWriteTimeout))
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "client too slow"))
+				return
+			}
+			if err := conn.WriteJSON(msg); err != nil {
+				logger.LogErrorCtx(r.Context(), "Yarn WebSocket write failed", err)
+				return
+			}
+		case <-ping.C:
+			conn.SetWriteDeadline(time.Now().Add(yarnWSWriteTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sameOrigin reports whether origin names the host the request was sent to
+func sameOrigin(r *http.Request, origin string) bool {
+	host := origin
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	return strings.EqualFold(host, r.Host)
+}
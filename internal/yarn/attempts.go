@@ -0,0 +1,79 @@
+package yarn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AppAttempt represents one attempt of a Yarn application
+type AppAttempt struct {
+	ID              int64  `json:"id"`
+	AppAttemptID    string `json:"appAttemptId"`
+	AppAttemptState string `json:"appAttemptState"`
+	StartTime       int64  `json:"startTime"`
+	FinishedTime    int64  `json:"finishedTime"`
+	ContainerID     string `json:"containerId"`
+	NodeID          string `json:"nodeId"`
+	NodeHTTPAddress string `json:"nodeHttpAddress"`
+	LogsLink        string `json:"logsLink"`
+}
+
+// Container represents a container allocated to an application attempt
+type Container struct {
+	ContainerID         string `json:"containerId"`
+	ContainerState      string `json:"containerState"`
+	AllocatedMB         int64  `json:"allocatedMB"`
+	AllocatedVCores     int64  `json:"allocatedVCores"`
+	AssignedNodeID      string `json:"assignedNodeId"`
+	NodeHTTPAddress     string `json:"nodeHttpAddress"`
+	Priority            int64  `json:"priority"`
+	StartedTime         int64  `json:"startedTime"`
+	FinishedTime        int64  `json:"finishedTime"`
+	ElapsedTime         int64  `json:"elapsedTime"`
+	ContainerExitStatus int64  `json:"containerExitStatus"`
+	LogURL              string `json:"logUrl"`
+}
+
+// GetAppAttempts calls GetAppAttemptsContext with a background context
+func (c *Client) GetAppAttempts(appID string) ([]*AppAttempt, error) {
+	return c.GetAppAttemptsContext(context.Background(), appID)
+}
+
+// GetAppContainers calls GetAppContainersContext with a background context
+func (c *Client) GetAppContainers(appID, attemptID string) ([]*Container, error) {
+	return c.GetAppContainersContext(context.Background(), appID, attemptID)
+}
+
+// GetAppAttemptsContext retrieves the attempts of an application
+func (c *Client) GetAppAttemptsContext(ctx context.Context, appID string) ([]*AppAttempt, error) {
+	var attemptsResponse struct {
+		AppAttempts struct {
+			AppAttempt []*AppAttempt `json:"appAttempt"`
+		} `json:"appAttempts"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, "/ws/v1/cluster/apps/"+appID+"/appattempts", nil, &attemptsResponse); err != nil {
+		return nil, fmt.Errorf("failed to fetch application attempts: %w", err)
+	}
+	return attemptsResponse.AppAttempts.AppAttempt, nil
+}
+
+// GetAppContainersContext retrieves the running containers of an application attempt.
+// The RM only tracks containers of live attempts, so finished ones yield ErrNotFound.
+func (c *Client) GetAppContainersContext(ctx context.Context, appID, attemptID string) ([]*Container, error) {
+	// Depending on the Hadoop version the list is either wrapped in "containers" or not
+	var containersResponse struct {
+		Containers struct {
+			Container []*Container `json:"container"`
+		} `json:"containers"`
+		Container []*Container `json:"container"`
+	}
+	path := "/ws/v1/cluster/apps/" + appID + "/appattempts/" + attemptID + "/containers"
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &containersResponse); err != nil {
+		return nil, fmt.Errorf("failed to fetch containers: %w", err)
+	}
+	if containersResponse.Container != nil {
+		return containersResponse.Container, nil
+	}
+	return containersResponse.Containers.Container, nil
+}
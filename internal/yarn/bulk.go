@@ -0,0 +1,83 @@
+package yarn
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// maxKillConcurrency bounds how many kill requests are sent to the RM at once
+const maxKillConcurrency = 4
+
+// KillResult is the outcome of killing one application in a bulk kill
+type KillResult struct {
+	ID     string `json:"id"`
+	Killed bool   `json:"killed"`
+	Error  string `json:"error,omitempty"`
+}
+
+// KillApplications calls KillApplicationsContext with a background context
+func (c *Client) KillApplications(appIDs []string) []KillResult {
+	return c.KillApplicationsContext(context.Background(), appIDs)
+}
+
+// KillApplicationsContext kills each application, a few at a time, and reports a
+// result per ID in input order. A failed kill does not stop the others; repeated
+// IDs are killed once.
+func (c *Client) KillApplicationsContext(ctx context.Context, appIDs []string) []KillResult {
+	seen := make(map[string]bool, len(appIDs))
+	results := make([]KillResult, 0, len(appIDs))
+	for _, id := range appIDs {
+		if !seen[id] {
+			seen[id] = true
+			results = append(results, KillResult{ID: id})
+		}
+	}
+
+	sem := make(chan struct{}, maxKillConcurrency)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(result *KillResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := c.KillApplicationContext(ctx, result.ID); err != nil {
+				logger.LogErrorCtx(ctx, "Failed to kill application "+result.ID, err)
+				result.Error = err.Error()
+				return
+			}
+			result.Killed = true
+		}(&results[i])
+	}
+	wg.Wait()
+
+	killed := 0
+	for _, result := range results {
+		if result.Killed {
+			killed++
+		}
+	}
+	logger.InfoCtx(ctx, "Bulk kill finished: %d of %d applications killed", killed, len(results))
+	return results
+}
+
+// KillStaleApplicationsContext kills every application that has been running
+// longer than maxDuration and reports a result per application
+func (c *Client) KillStaleApplicationsContext(ctx context.Context, maxDuration time.Duration) ([]KillResult, error) {
+	apps, err := c.GetStaleApplicationsContext(ctx, maxDuration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stale applications: %w", err)
+	}
+
+	appIDs := make([]string, len(apps))
+	for i, app := range apps {
+		appIDs[i] = app.ID
+	}
+	logger.InfoCtx(ctx, "Killing %d applications running longer than %v", len(appIDs), maxDuration)
+	return c.KillApplicationsContext(ctx, appIDs), nil
+}
@@ -1,11 +1,13 @@
 package yarn
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"salam-monitoring/internal/logger"
@@ -36,6 +38,45 @@ type Application struct {
 	RunningContainers int64   `json:"runningContainers"`
 }
 
+// StartedAt returns the application's start time, or the zero time if the RM
+// has not started it yet
+func (a *Application) StartedAt() time.Time {
+	if a.StartedTime <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(a.StartedTime)
+}
+
+// FinishedAt returns when the application finished, or nil while it is still
+// running (the RM reports a FinishedTime of 0)
+func (a *Application) FinishedAt() *time.Time {
+	if a.FinishedTime <= 0 {
+		return nil
+	}
+	finished := time.UnixMilli(a.FinishedTime)
+	return &finished
+}
+
+// Elapsed returns how long the application has run as reported by the RM. If the
+// RM left ElapsedTime unset or negative it is derived from the start and finish
+// times (or now, for a running application); an unstarted application gives 0.
+func (a *Application) Elapsed() time.Duration {
+	if a.ElapsedTime > 0 {
+		return time.Duration(a.ElapsedTime) * time.Millisecond
+	}
+	if a.StartedTime <= 0 {
+		return 0
+	}
+	end := time.Now()
+	if finished := a.FinishedAt(); finished != nil {
+		end = *finished
+	}
+	if elapsed := end.Sub(a.StartedAt()); elapsed > 0 {
+		return elapsed
+	}
+	return 0
+}
+
 // AppsResponse represents the response from Yarn RM API
 type AppsResponse struct {
 	Apps struct {
@@ -80,108 +121,249 @@ type ClusterMetrics struct {
 	RebootedNodes         int64 `json:"rebootedNodes"`
 }
 
-// Client represents a Yarn Resource Manager client
+// Client represents a Yarn Resource Manager client. With more than one RM URL
+// it follows the active RM of an HA pair, failing over when it stops answering.
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	rmURLs        []string
+	httpClient    *http.Client
+	retry         RetryPolicy
+	healthTimeout time.Duration
+	fixtureDir    string // when set, GETs are served from JSON fixtures in this directory
+	onKill        func(context.Context, KillEvent)
+
+	mu     sync.RWMutex
+	active int  // index into rmURLs of the RM believed to be active
+	probed bool // whether the active RM has been detected yet
+}
+
+// Default timeouts used when ClientOptions leaves them unset
+const (
+	DefaultTimeout       = 30 * time.Second
+	DefaultHealthTimeout = 3 * time.Second
+)
+
+// ClientOptions configures a Client created with NewClientWithOptions. Zero
+// values fall back to the defaults NewClient uses.
+type ClientOptions struct {
+	Timeout       time.Duration     // per-request timeout
+	HealthTimeout time.Duration     // overall bound for IsHealthy, so health checks fail fast
+	Retry         *RetryPolicy      // retry policy for GET calls
+	Transport     http.RoundTripper // HTTP transport, e.g. with custom TLS settings
+	Kerberos      *KerberosConfig   // enables SPNEGO authentication when set
+
+	// OnKill is called after every attempted kill, successful or not, e.g. to
+	// write an audit trail
+	OnKill func(context.Context, KillEvent)
 }
 
-// NewClient creates a new Yarn RM client
-func NewClient(baseURL string) *Client {
-	logger.Info("Creating Yarn client for RM: %s", baseURL)
-	return &Client{
-		baseURL: strings.TrimSuffix(baseURL, "/"),
+// KillEvent describes one attempted application kill
+type KillEvent struct {
+	AppID   string
+	AppName string // set when the kill came from a pattern match
+	Pattern string // the pattern for KillApplicationsByPattern, empty otherwise
+	Err     error  // nil when the RM accepted the kill
+}
+
+// NewClient creates a new Yarn RM client for one or more ResourceManager URLs
+func NewClient(rmURLs []string, opts ...ClientOption) *Client {
+	c := NewClientWithOptions(rmURLs, ClientOptions{})
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewClientWithOptions creates a new Yarn RM client with explicit timeouts, retry
+// policy and transport
+func NewClientWithOptions(rmURLs []string, options ClientOptions) *Client {
+	logger.Info("Creating Yarn client for RM: %s", strings.Join(rmURLs, ", "))
+	urls := make([]string, 0, len(rmURLs))
+	for _, u := range rmURLs {
+		urls = append(urls, strings.TrimSuffix(u, "/"))
+	}
+	if len(urls) == 0 {
+		logger.Error("No Yarn ResourceManager URL configured")
+		urls = append(urls, "")
+	}
+
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultTimeout
+	}
+	if options.HealthTimeout <= 0 {
+		options.HealthTimeout = DefaultHealthTimeout
+	}
+	if options.Kerberos != nil {
+		transport, err := NewSPNEGOTransport(*options.Kerberos, options.Transport)
+		if err != nil {
+			logger.LogError("Failed to set up Kerberos for Yarn client, requests will be unauthenticated", err)
+		} else {
+			logger.Info("Yarn client using SPNEGO authentication as %s@%s", options.Kerberos.Principal, options.Kerberos.Realm)
+			options.Transport = transport
+		}
+	}
+
+	c := &Client{
+		rmURLs: urls,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   options.Timeout,
+			Transport: options.Transport,
 		},
+		retry:         DefaultRetryPolicy,
+		healthTimeout: options.HealthTimeout,
+		onKill:        options.OnKill,
+		probed:        len(urls) < 2,
+	}
+	if options.Retry != nil {
+		WithRetryPolicy(*options.Retry)(c)
 	}
+	if len(urls) == 1 && isFixturePath(urls[0]) {
+		c.fixtureDir = fixtureDir(urls[0])
+		logger.Info("Yarn client serving fixtures from %s", c.fixtureDir)
+	}
+	return c
 }
 
-// GetRunningApplications retrieves all running applications
+// GetRunningApplications calls GetRunningApplicationsContext with a background context
 func (c *Client) GetRunningApplications() ([]*Application, error) {
-	return c.GetApplicationsByState("RUNNING")
+	return c.GetRunningApplicationsContext(context.Background())
 }
 
-// GetApplicationsByState retrieves applications by their state
+// GetApplicationsByState calls GetApplicationsByStateContext with a background context
 func (c *Client) GetApplicationsByState(state string) ([]*Application, error) {
-	url := fmt.Sprintf("%s/ws/v1/cluster/apps?states=%s", c.baseURL, state)
+	return c.GetApplicationsByStateContext(context.Background(), state)
+}
 
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch applications: %w", err)
-	}
-	defer resp.Body.Close()
+// GetApplications calls GetApplicationsContext with a background context
+func (c *Client) GetApplications(filter AppFilter) ([]*Application, error) {
+	return c.GetApplicationsContext(context.Background(), filter)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-	}
+// GetApplication calls GetApplicationContext with a background context
+func (c *Client) GetApplication(appID string) (*Application, error) {
+	return c.GetApplicationContext(context.Background(), appID)
+}
 
-	var appsResponse AppsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&appsResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+// KillApplication calls KillApplicationContext with a background context
+func (c *Client) KillApplication(appID string) error {
+	return c.KillApplicationContext(context.Background(), appID)
+}
 
-	return appsResponse.Apps.App, nil
+// KillApplicationsByPattern calls KillApplicationsByPatternContext with a background context
+func (c *Client) KillApplicationsByPattern(pattern string) ([]string, error) {
+	return c.KillApplicationsByPatternContext(context.Background(), pattern)
 }
 
-// GetApplication retrieves a specific application by ID
-func (c *Client) GetApplication(appID string) (*Application, error) {
-	url := fmt.Sprintf("%s/ws/v1/cluster/apps/%s", c.baseURL, appID)
+// GetStaleApplications calls GetStaleApplicationsContext with a background context
+func (c *Client) GetStaleApplications(maxDuration time.Duration) ([]*Application, error) {
+	return c.GetStaleApplicationsContext(context.Background(), maxDuration)
+}
+
+// GetClusterInfo calls GetClusterInfoContext with a background context
+func (c *Client) GetClusterInfo() (*ClusterInfo, error) {
+	return c.GetClusterInfoContext(context.Background())
+}
+
+// GetClusterMetrics calls GetClusterMetricsContext with a background context
+func (c *Client) GetClusterMetrics() (*ClusterMetrics, error) {
+	return c.GetClusterMetricsContext(context.Background())
+}
+
+// GetRunningApplicationsContext retrieves all running applications
+func (c *Client) GetRunningApplicationsContext(ctx context.Context) ([]*Application, error) {
+	return c.GetApplicationsByStateContext(ctx, "RUNNING")
+}
 
-	resp, err := c.httpClient.Get(url)
+// GetApplicationsByStateContext retrieves applications by their state, or by any
+// of several comma-separated states such as "RUNNING,FAILED,KILLED"
+func (c *Client) GetApplicationsByStateContext(ctx context.Context, state string) ([]*Application, error) {
+	var states []string
+	for _, s := range strings.Split(state, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			states = append(states, s)
+		}
+	}
+	return c.GetApplicationsContext(ctx, AppFilter{States: states})
+}
+
+// GetRecentFailuresContext returns up to limit applications that failed or were
+// killed within the last window, most recently finished first
+func (c *Client) GetRecentFailuresContext(ctx context.Context, window time.Duration, limit int) ([]*Application, error) {
+	apps, err := c.GetApplicationsContext(ctx, AppFilter{
+		States:        []string{"FAILED", "KILLED"},
+		FinishedAfter: time.Now().Add(-window),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch application: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
+	sort.SliceStable(apps, func(i, j int) bool { return apps[i].FinishedTime > apps[j].FinishedTime })
+	if limit > 0 && len(apps) > limit {
+		apps = apps[:limit]
+	}
+	return apps, nil
+}
+
+// GetApplicationsContext retrieves applications matching filter
+func (c *Client) GetApplicationsContext(ctx context.Context, filter AppFilter) ([]*Application, error) {
+	var appsResponse AppsResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/ws/v1/cluster/apps"+filter.query(), nil, &appsResponse); err != nil {
+		return nil, fmt.Errorf("failed to fetch applications: %w", err)
 	}
 
+	return filter.filterApps(appsResponse.Apps.App, c.fixtureDir != ""), nil
+}
+
+// GetApplicationContext retrieves a specific application by ID
+func (c *Client) GetApplicationContext(ctx context.Context, appID string) (*Application, error) {
 	var appResponse struct {
 		App *Application `json:"app"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&appResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.doJSON(ctx, http.MethodGet, "/ws/v1/cluster/apps/"+appID, nil, &appResponse); err != nil {
+		return nil, fmt.Errorf("failed to fetch application: %w", err)
 	}
 
 	return appResponse.App, nil
 }
 
-// KillApplication kills a specific application
-func (c *Client) KillApplication(appID string) error {
-	url := fmt.Sprintf("%s/ws/v1/cluster/apps/%s/state", c.baseURL, appID)
-
-	payload := `{"state":"KILLED"}`
+// KillApplicationContext kills a specific application. The PUT is never retried so a
+// slow RM response can't lead to the kill being sent twice.
+func (c *Client) KillApplicationContext(ctx context.Context, appID string) error {
+	err := c.killApplication(ctx, appID)
+	c.notifyKill(ctx, KillEvent{AppID: appID, Err: err})
+	return err
+}
 
-	req, err := http.NewRequest("PUT", url, strings.NewReader(payload))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+// notifyKill reports an attempted kill to the OnKill callback, if any
+func (c *Client) notifyKill(ctx context.Context, event KillEvent) {
+	if c.onKill != nil {
+		c.onKill(ctx, event)
 	}
-	req.Header.Set("Content-Type", "application/json")
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to kill application: %w", err)
+// killApplication sends the kill request for one application
+func (c *Client) killApplication(ctx context.Context, appID string) error {
+	if c.fixtureDir != "" {
+		return fmt.Errorf("cannot kill %s: Yarn client is serving fixtures from %s", appID, c.fixtureDir)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to kill application: HTTP %d", resp.StatusCode)
+	// PUTs are not retried; after a network error it is left to the operator to resend the kill
+	payload := map[string]string{"state": "KILLED"}
+	if err := c.doJSON(ctx, http.MethodPut, "/ws/v1/cluster/apps/"+appID+"/state", payload, nil); err != nil {
+		return fmt.Errorf("failed to kill application: %w", err)
 	}
 
-	logger.Info("Successfully killed application: %s", appID)
+	logger.InfoCtx(ctx, "Successfully killed application: %s", appID)
 	return nil
 }
 
-// KillApplicationsByPattern kills applications matching a pattern
-func (c *Client) KillApplicationsByPattern(pattern string) ([]string, error) {
+// KillApplicationsByPatternContext kills applications matching a pattern
+func (c *Client) KillApplicationsByPatternContext(ctx context.Context, pattern string) ([]string, error) {
 	regex, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, fmt.Errorf("invalid pattern: %w", err)
 	}
 
-	apps, err := c.GetRunningApplications()
+	apps, err := c.GetRunningApplicationsContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get running applications: %w", err)
 	}
@@ -189,83 +371,56 @@ func (c *Client) KillApplicationsByPattern(pattern string) ([]string, error) {
 	var killedApps []string
 	for _, app := range apps {
 		if regex.MatchString(app.Name) {
-			if err := c.KillApplication(app.ID); err != nil {
-				logger.LogError(fmt.Sprintf("Failed to kill application %s (%s)", app.ID, app.Name), err)
+			err := c.killApplication(ctx, app.ID)
+			c.notifyKill(ctx, KillEvent{AppID: app.ID, AppName: app.Name, Pattern: pattern, Err: err})
+			if err != nil {
+				logger.LogErrorCtx(ctx, fmt.Sprintf("Failed to kill application %s (%s)", app.ID, app.Name), err)
 				continue
 			}
 			killedApps = append(killedApps, app.ID)
 		}
 	}
 
-	logger.Info("Killed %d applications matching pattern: %s", len(killedApps), pattern)
+	logger.InfoCtx(ctx, "Killed %d applications matching pattern: %s", len(killedApps), pattern)
 	return killedApps, nil
 }
 
-// GetStaleApplications returns applications that have been running longer than the specified duration
-func (c *Client) GetStaleApplications(maxDuration time.Duration) ([]*Application, error) {
-	apps, err := c.GetRunningApplications()
+// GetStaleApplicationsContext returns applications that have been running longer than the specified duration
+func (c *Client) GetStaleApplicationsContext(ctx context.Context, maxDuration time.Duration) ([]*Application, error) {
+	apps, err := c.GetRunningApplicationsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var staleApps []*Application
-	now := time.Now().Unix() * 1000 // Convert to milliseconds
-
 	for _, app := range apps {
-		if app.StartedTime > 0 {
-			elapsed := time.Duration(now-app.StartedTime) * time.Millisecond
-			if elapsed > maxDuration {
-				staleApps = append(staleApps, app)
-			}
+		if started := app.StartedAt(); !started.IsZero() && time.Since(started) > maxDuration {
+			staleApps = append(staleApps, app)
 		}
 	}
 
 	return staleApps, nil
 }
 
-// GetClusterInfo retrieves cluster information
-func (c *Client) GetClusterInfo() (*ClusterInfo, error) {
-	url := fmt.Sprintf("%s/ws/v1/cluster/info", c.baseURL)
-
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch cluster info: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-	}
-
+// GetClusterInfoContext retrieves cluster information
+func (c *Client) GetClusterInfoContext(ctx context.Context) (*ClusterInfo, error) {
 	var infoResponse struct {
 		ClusterInfo *ClusterInfo `json:"clusterInfo"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&infoResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.doJSON(ctx, http.MethodGet, "/ws/v1/cluster/info", nil, &infoResponse); err != nil {
+		return nil, fmt.Errorf("failed to fetch cluster info: %w", err)
 	}
 
 	return infoResponse.ClusterInfo, nil
 }
 
-// GetClusterMetrics retrieves cluster metrics
-func (c *Client) GetClusterMetrics() (*ClusterMetrics, error) {
-	url := fmt.Sprintf("%s/ws/v1/cluster/metrics", c.baseURL)
-
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch cluster metrics: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-	}
-
+// GetClusterMetricsContext retrieves cluster metrics
+func (c *Client) GetClusterMetricsContext(ctx context.Context) (*ClusterMetrics, error) {
 	var metricsResponse struct {
 		ClusterMetrics *ClusterMetrics `json:"clusterMetrics"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&metricsResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.doJSON(ctx, http.MethodGet, "/ws/v1/cluster/metrics", nil, &metricsResponse); err != nil {
+		return nil, fmt.Errorf("failed to fetch cluster metrics: %w", err)
 	}
 
 	return metricsResponse.ClusterMetrics, nil
@@ -307,9 +462,18 @@ func FormatMemory(megabytes int64) string {
 	}
 }
 
-// IsHealthy checks if the cluster appears healthy
+// IsHealthy calls IsHealthyContext with a background context
 func (c *Client) IsHealthy() bool {
-	info, err := c.GetClusterInfo()
+	return c.IsHealthyContext(context.Background())
+}
+
+// IsHealthyContext checks if the cluster appears healthy. The check is bounded by
+// the client's health timeout rather than the regular request timeout.
+func (c *Client) IsHealthyContext(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, c.healthTimeout)
+	defer cancel()
+
+	info, err := c.GetClusterInfoContext(ctx)
 	if err != nil {
 		return false
 	}
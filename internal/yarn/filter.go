@@ -0,0 +1,90 @@
+package yarn
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AppFilter narrows an application listing. Empty fields are not filtered on.
+type AppFilter struct {
+	States          []string
+	Queue           string
+	User            string
+	ApplicationType string
+	NameContains    string    // case-insensitive; applied client side as the RM has no name filter
+	FinishedAfter   time.Time // only applications that finished at or after this time
+}
+
+// query builds the RM query string for the filter fields the RM supports
+func (f AppFilter) query() string {
+	q := url.Values{}
+	if len(f.States) > 0 {
+		q.Set("states", strings.Join(f.States, ","))
+	}
+	if f.Queue != "" {
+		q.Set("queue", f.Queue)
+	}
+	if f.User != "" {
+		q.Set("users", f.User)
+	}
+	if f.ApplicationType != "" {
+		q.Set("applicationTypes", f.ApplicationType)
+	}
+	if !f.FinishedAfter.IsZero() {
+		q.Set("finishedTimeBegin", strconv.FormatInt(f.FinishedAfter.UnixMilli(), 10))
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
+// filterApps drops applications that do not match the filter. The RM applies
+// every field except NameContains itself, so only fixture data (local) needs
+// the other fields checked here.
+func (f AppFilter) filterApps(apps []*Application, local bool) []*Application {
+	needle := strings.ToLower(f.NameContains)
+	filtered := apps[:0]
+	for _, app := range apps {
+		if local && !f.matchesRMFields(app) {
+			continue
+		}
+		if needle != "" && !strings.Contains(strings.ToLower(app.Name), needle) {
+			continue
+		}
+		filtered = append(filtered, app)
+	}
+	return filtered
+}
+
+// matchesRMFields checks the fields the RM normally filters on
+func (f AppFilter) matchesRMFields(app *Application) bool {
+	if len(f.States) > 0 && !containsFold(f.States, app.State) {
+		return false
+	}
+	if f.Queue != "" && !strings.EqualFold(f.Queue, app.Queue) {
+		return false
+	}
+	if f.User != "" && f.User != app.User {
+		return false
+	}
+	if f.ApplicationType != "" && !strings.EqualFold(f.ApplicationType, app.ApplicationType) {
+		return false
+	}
+	if !f.FinishedAfter.IsZero() && app.FinishedTime < f.FinishedAfter.UnixMilli() {
+		return false
+	}
+	return true
+}
+
+// containsFold reports whether values contains s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package yarn
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Fixture files read in place of RM endpoints when the client is pointed at a
+// local path instead of an http(s) URL. The path may name the directory or
+// any file in it, e.g. the default ./mock/yarn/apps.json.
+var fixtureFiles = map[string]string{
+	"/ws/v1/cluster/apps":      "apps.json",
+	"/ws/v1/cluster/metrics":   "metrics.json",
+	"/ws/v1/cluster/info":      "info.json",
+	"/ws/v1/cluster/nodes":     "nodes.json",
+	"/ws/v1/cluster/scheduler": "scheduler.json",
+}
+
+// isFixturePath reports whether an RM URL refers to local fixtures
+func isFixturePath(rmURL string) bool {
+	return rmURL != "" && !strings.HasPrefix(rmURL, "http://") && !strings.HasPrefix(rmURL, "https://")
+}
+
+// fixtureDir returns the directory holding the fixtures for a local RM path
+func fixtureDir(path string) string {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return path
+	}
+	return filepath.Dir(path)
+}
+
+// fixtureResponse serves an RM GET from the fixture directory. Unknown paths
+// and missing files yield a 404, like an RM without that resource.
+func (c *Client) fixtureResponse(path string) (*http.Response, error) {
+	endpoint := path
+	if i := strings.IndexByte(endpoint, '?'); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+
+	// A single application is looked up in the apps fixture
+	if appID, ok := strings.CutPrefix(endpoint, "/ws/v1/cluster/apps/"); ok {
+		if strings.Contains(appID, "/") {
+			return fixtureStatus(http.StatusNotFound), nil
+		}
+		return c.fixtureApp(appID)
+	}
+
+	name, ok := fixtureFiles[endpoint]
+	if !ok {
+		return fixtureStatus(http.StatusNotFound), nil
+	}
+	data, err := os.ReadFile(filepath.Join(c.fixtureDir, name))
+	if os.IsNotExist(err) {
+		return fixtureStatus(http.StatusNotFound), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Yarn fixture: %w", err)
+	}
+	return fixtureJSON(data), nil
+}
+
+// fixtureApp finds one application in apps.json and wraps it as the RM would
+func (c *Client) fixtureApp(appID string) (*http.Response, error) {
+	data, err := os.ReadFile(filepath.Join(c.fixtureDir, fixtureFiles["/ws/v1/cluster/apps"]))
+	if os.IsNotExist(err) {
+		return fixtureStatus(http.StatusNotFound), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Yarn fixture: %w", err)
+	}
+
+	var appsResponse AppsResponse
+	if err := json.Unmarshal(data, &appsResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode Yarn fixture: %w", err)
+	}
+	for _, app := range appsResponse.Apps.App {
+		if app.ID == appID {
+			body, err := json.Marshal(map[string]*Application{"app": app})
+			if err != nil {
+				return nil, err
+			}
+			return fixtureJSON(body), nil
+		}
+	}
+	return fixtureStatus(http.StatusNotFound), nil
+}
+
+// fixtureJSON wraps a fixture body in a 200 response
+func fixtureJSON(body []byte) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+// fixtureStatus builds an empty response with the given status
+func fixtureStatus(status int) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+}
@@ -0,0 +1,104 @@
+package yarn
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// mockRM is the fixture file the server uses in test mode
+const mockRM = "../../mock/yarn/apps.json"
+
+func TestFixtureApplications(t *testing.T) {
+	client := NewClient([]string{mockRM})
+	ctx := context.Background()
+
+	all, err := client.GetApplicationsContext(ctx, AppFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 4 {
+		t.Errorf("got %d applications, want all 4 in the fixture", len(all))
+	}
+
+	// The state filter is applied locally since the fixture ignores the query
+	running, err := client.GetRunningApplicationsContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(running) != 2 {
+		t.Fatalf("got %d running applications, want 2", len(running))
+	}
+	for _, app := range running {
+		if app.State != "RUNNING" {
+			t.Errorf("%s has state %s, want RUNNING", app.ID, app.State)
+		}
+	}
+}
+
+func TestFixtureApplication(t *testing.T) {
+	client := NewClient([]string{mockRM})
+	ctx := context.Background()
+
+	app, err := client.GetApplicationContext(ctx, "application_1718000000000_0102")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.Name != "spark_ingest_orders" || app.State != "RUNNING" {
+		t.Errorf("got %s in state %s, want spark_ingest_orders RUNNING", app.Name, app.State)
+	}
+
+	for _, id := range []string{"application_1718000000000_9999", "application_1718000000000_0102/appattempts"} {
+		if _, err := client.GetApplicationContext(ctx, id); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetApplication(%s) = %v, want ErrNotFound", id, err)
+		}
+	}
+}
+
+func TestFixtureCluster(t *testing.T) {
+	// The directory itself works as well as a file in it
+	client := NewClient([]string{"../../mock/yarn"})
+	ctx := context.Background()
+
+	info, err := client.GetClusterInfoContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.State != "STARTED" || info.HAState != "ACTIVE" || info.ResourceManagerVersion != "3.3.6" {
+		t.Errorf("got cluster info %+v", info)
+	}
+	if !client.IsHealthyContext(ctx) {
+		t.Error("fixture cluster reported unhealthy")
+	}
+
+	metrics, err := client.GetClusterMetricsContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metrics.AppsRunning != 2 || metrics.TotalMB != 49152 || metrics.ActiveNodes != 2 {
+		t.Errorf("got appsRunning=%d totalMB=%d activeNodes=%d, want 2, 49152 and 2",
+			metrics.AppsRunning, metrics.TotalMB, metrics.ActiveNodes)
+	}
+}
+
+func TestFixtureMissingFile(t *testing.T) {
+	client := NewClient([]string{t.TempDir()})
+
+	if _, err := client.GetClusterMetricsContext(context.Background()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got %v, want ErrNotFound for a missing fixture", err)
+	}
+}
+
+func TestFixtureRefusesKill(t *testing.T) {
+	var events []KillEvent
+	client := NewClientWithOptions([]string{mockRM}, ClientOptions{
+		OnKill: func(_ context.Context, e KillEvent) { events = append(events, e) },
+	})
+
+	if err := client.KillApplicationContext(context.Background(), "application_1718000000000_0101"); err == nil {
+		t.Error("expected the fixture client to refuse a kill")
+	}
+	if len(events) != 1 || events[0].Err == nil {
+		t.Errorf("got kill events %+v, want one failed kill", events)
+	}
+}
@@ -0,0 +1,106 @@
+package yarn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// haProbeTimeout bounds each /cluster/info probe while looking for the active RM
+const haProbeTimeout = 5 * time.Second
+
+// activeURL returns the base URL of the RM believed to be active, detecting it
+// on first use when several RMs are configured
+func (c *Client) activeURL(ctx context.Context) string {
+	c.mu.RLock()
+	probed, active := c.probed, c.active
+	c.mu.RUnlock()
+
+	if !probed {
+		return c.rmURLs[c.detectActive(ctx, active)]
+	}
+	return c.rmURLs[active]
+}
+
+// failover is called when the RM at failed stopped answering; it re-probes the
+// configured RMs and switches to whichever reports itself active
+func (c *Client) failover(ctx context.Context, failed string) {
+	if len(c.rmURLs) < 2 {
+		return
+	}
+
+	c.mu.RLock()
+	current := c.rmURLs[c.active]
+	c.mu.RUnlock()
+	if current != failed {
+		return // another request already failed over
+	}
+
+	logger.InfoCtx(ctx, "Yarn RM %s unreachable, probing for the active ResourceManager", failed)
+	c.detectActive(ctx, c.indexOf(failed))
+}
+
+// detectActive probes every RM's /ws/v1/cluster/info and records the first with
+// haState ACTIVE. If none is active it moves past the RM that was in use so the
+// next request tries another one. It returns the chosen index.
+func (c *Client) detectActive(ctx context.Context, current int) int {
+	chosen := (current + 1) % len(c.rmURLs)
+	for i, url := range c.rmURLs {
+		state, err := c.probeHAState(ctx, url)
+		if err != nil {
+			logger.InfoCtx(ctx, "Yarn RM %s not reachable: %v", url, err)
+			continue
+		}
+		// HA disabled clusters report NOT_ENABLED; treat a single answering RM as active
+		if state == "ACTIVE" || state == "NOT_ENABLED" {
+			chosen = i
+			break
+		}
+	}
+
+	c.mu.Lock()
+	if c.active != chosen {
+		logger.InfoCtx(ctx, "Using Yarn ResourceManager %s", c.rmURLs[chosen])
+	}
+	c.active = chosen
+	c.probed = true
+	c.mu.Unlock()
+	return chosen
+}
+
+// probeHAState returns the haState reported by a single RM
+func (c *Client) probeHAState(ctx context.Context, baseURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, haProbeTimeout)
+	defer cancel()
+
+	req, err := c.newRequest(ctx, http.MethodGet, baseURL+"/ws/v1/cluster/info", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var infoResponse struct {
+		ClusterInfo *ClusterInfo `json:"clusterInfo"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&infoResponse); err != nil || infoResponse.ClusterInfo == nil {
+		return "", err
+	}
+	return infoResponse.ClusterInfo.HAState, nil
+}
+
+// indexOf returns the index of an RM base URL, or 0 if it is not configured
+func (c *Client) indexOf(url string) int {
+	for i, u := range c.rmURLs {
+		if u == url {
+			return i
+		}
+	}
+	return 0
+}
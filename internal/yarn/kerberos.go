@@ -0,0 +1,81 @@
+package yarn
+
+import (
+	"fmt"
+	"net/http"
+
+	krbclient "github.com/jcmturner/gokrb5/v8/client"
+	krbconfig "github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+)
+
+// DefaultKrb5Conf is the Kerberos configuration read when none is given
+const DefaultKrb5Conf = "/etc/krb5.conf"
+
+// KerberosConfig enables SPNEGO authentication against a kerberized RM
+type KerberosConfig struct {
+	KeytabPath string // keytab holding the principal's key
+	Principal  string // principal name without the realm, e.g. "svc_monitor"
+	Realm      string // Kerberos realm, e.g. "CORP.EXAMPLE.COM"
+	SPN        string // service principal of the RM; empty derives HTTP/<rm host> per request
+	Krb5Conf   string // krb5.conf path; empty uses DefaultKrb5Conf
+}
+
+// spnegoTransport adds an "Authorization: Negotiate" header to every request.
+// The Kerberos client logs in on first use and again once its TGT expires, and
+// each request gets a fresh service token.
+type spnegoTransport struct {
+	base http.RoundTripper
+	spn  string
+
+	// authenticate sets the Negotiate header on req for spn
+	authenticate func(req *http.Request, spn string) error
+}
+
+// NewSPNEGOTransport wraps base (nil for http.DefaultTransport) so requests are
+// authenticated with the keytab in cfg. The keytab and krb5.conf are loaded
+// up front so misconfiguration is reported at startup; the KDC is only
+// contacted on the first request.
+func NewSPNEGOTransport(cfg KerberosConfig, base http.RoundTripper) (http.RoundTripper, error) {
+	if cfg.Principal == "" || cfg.Realm == "" || cfg.KeytabPath == "" {
+		return nil, fmt.Errorf("kerberos principal, realm and keytab are required")
+	}
+	if cfg.Krb5Conf == "" {
+		cfg.Krb5Conf = DefaultKrb5Conf
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	kt, err := keytab.Load(cfg.KeytabPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load keytab %s: %w", cfg.KeytabPath, err)
+	}
+	conf, err := krbconfig.Load(cfg.Krb5Conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", cfg.Krb5Conf, err)
+	}
+
+	// Active Directory KDCs reject PA-FX-FAST, which Hadoop clusters often sit behind
+	cl := krbclient.NewWithKeytab(cfg.Principal, cfg.Realm, kt, conf, krbclient.DisablePAFXFAST(true))
+	return &spnegoTransport{
+		base: base,
+		spn:  cfg.SPN,
+		authenticate: func(req *http.Request, spn string) error {
+			return spnego.SetSPNEGOHeader(cl, req, spn)
+		},
+	}, nil
+}
+
+// RoundTrip sets the Negotiate header on a copy of req and forwards it
+func (t *spnegoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	authReq := req.Clone(req.Context())
+	if err := t.authenticate(authReq, t.spn); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("kerberos authentication failed: %w", err)
+	}
+	return t.base.RoundTrip(authReq)
+}
@@ -0,0 +1,115 @@
+package yarn
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// stubNegotiate stands in for the Kerberos client, setting a fixed token
+func stubNegotiate(req *http.Request, spn string) error {
+	req.Header.Set("Authorization", "Negotiate dGVzdC10b2tlbg==")
+	return nil
+}
+
+func TestSPNEGOTransportSendsNegotiateHeader(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]string{} // method -> Authorization header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.Method] = r.Header.Get("Authorization")
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"clusterInfo":{"state":"STARTED"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions([]string{server.URL}, ClientOptions{
+		Transport: &spnegoTransport{base: http.DefaultTransport, authenticate: stubNegotiate},
+	})
+	if _, err := client.GetClusterInfoContext(context.Background()); err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	if err := client.KillApplicationContext(context.Background(), "application_1700000000000_0001"); err != nil {
+		t.Fatalf("PUT failed: %v", err)
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodPut} {
+		if got := seen[method]; got != "Negotiate dGVzdC10b2tlbg==" {
+			t.Errorf("%s sent Authorization %q, want the Negotiate token", method, got)
+		}
+	}
+}
+
+func TestSPNEGOTransportPassesSPN(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var gotSPN string
+	transport := &spnegoTransport{
+		base: http.DefaultTransport,
+		spn:  "HTTP/rm1.corp.example.com",
+		authenticate: func(req *http.Request, spn string) error {
+			gotSPN = spn
+			return stubNegotiate(req, spn)
+		},
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if gotSPN != "HTTP/rm1.corp.example.com" {
+		t.Errorf("authenticated for SPN %q, want the configured one", gotSPN)
+	}
+	if req.Header.Get("Authorization") != "" {
+		t.Error("RoundTrip modified the caller's request")
+	}
+}
+
+func TestSPNEGOTransportAuthFailure(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	kdcDown := errors.New("cannot contact KDC")
+	client := NewClientWithOptions([]string{server.URL}, ClientOptions{
+		Retry: &RetryPolicy{MaxAttempts: 1},
+		Transport: &spnegoTransport{
+			base:         http.DefaultTransport,
+			authenticate: func(*http.Request, string) error { return kdcDown },
+		},
+	})
+
+	_, err := client.GetClusterInfoContext(context.Background())
+	if !errors.Is(err, kdcDown) || !strings.Contains(err.Error(), "kerberos authentication failed") {
+		t.Errorf("got %v, want a kerberos authentication error", err)
+	}
+	if got := calls.Load(); got != 0 {
+		t.Errorf("RM saw %d unauthenticated requests, want 0", got)
+	}
+}
+
+func TestNewSPNEGOTransportRequiresConfig(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.keytab")
+	for name, cfg := range map[string]KerberosConfig{
+		"no principal":   {Realm: "CORP.EXAMPLE.COM", KeytabPath: missing},
+		"no realm":       {Principal: "svc_monitor", KeytabPath: missing},
+		"no keytab":      {Principal: "svc_monitor", Realm: "CORP.EXAMPLE.COM"},
+		"missing keytab": {Principal: "svc_monitor", Realm: "CORP.EXAMPLE.COM", KeytabPath: missing},
+	} {
+		if _, err := NewSPNEGOTransport(cfg, nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
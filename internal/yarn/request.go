@@ -0,0 +1,64 @@
+package yarn
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"salam-monitoring/internal/logger"
+)
+
+// ErrNotFound is returned when the RM has no record of the requested resource,
+// e.g. the containers of an attempt that has already finished
+var ErrNotFound = errors.New("not found on ResourceManager")
+
+// doJSON sends a request for path to the active RM and decodes the response into
+// out. A non-nil body is sent as JSON and out may be nil when the response body
+// is not needed. Retries, failover and fixtures are handled by send; any status
+// outside 2xx is an error, with 404 reported as ErrNotFound.
+func (c *Client) doJSON(ctx context.Context, method, path string, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	resp, err := c.send(ctx, method, path, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("HTTP error: %d", resp.StatusCode)
+	}
+
+	if out == nil {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// newRequest builds a request bound to ctx that forwards the caller's request ID to the RM
+func (c *Client) newRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if id := logger.RequestID(ctx); id != "" {
+		req.Header.Set("X-Request-ID", id)
+	}
+	return req, nil
+}
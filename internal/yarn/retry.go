@@ -0,0 +1,123 @@
+package yarn
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"salam-monitoring/internal/logger"
+)
+
+// RetryPolicy controls how idempotent GET calls are retried when the RM is
+// unreachable or returns a 5xx, e.g. during a ResourceManager failover
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first; 1 disables retries
+	BaseDelay   time.Duration // delay before the second attempt, doubled for each later one
+	Jitter      float64       // up to this fraction of the delay is added at random
+}
+
+// DefaultRetryPolicy is used when the client is given no retry policy
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	Jitter:      0.5,
+}
+
+// ClientOption configures optional Client behaviour
+type ClientOption func(*Client)
+
+// WithRetryPolicy overrides the retry policy; attempts below 1 are treated as 1
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		if policy.MaxAttempts < 1 {
+			policy.MaxAttempts = 1
+		}
+		c.retry = policy
+	}
+}
+
+// delay returns the backoff before the given retry (1 for the first retry)
+func (p RetryPolicy) delay(retry int) time.Duration {
+	d := p.BaseDelay << (retry - 1)
+	if p.Jitter > 0 {
+		d += time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// send performs a request for path against the active RM. GETs are retried on
+// network errors and 5xx responses according to the client's retry policy; other
+// methods are sent once so a slow RM can't make a kill happen twice. Network
+// errors also trigger an RM failover before the next call. 4xx responses are
+// returned as-is.
+func (c *Client) send(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	if c.fixtureDir != "" {
+		if method != http.MethodGet {
+			return nil, fmt.Errorf("cannot %s %s: Yarn client is serving fixtures from %s", method, path, c.fixtureDir)
+		}
+		return c.fixtureResponse(path)
+	}
+
+	attempts := 1
+	if method == http.MethodGet {
+		attempts = c.retry.MaxAttempts
+	}
+
+	var lastErr error
+	var lastResp *http.Response
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			wait := c.retry.delay(attempt - 1)
+			logger.InfoCtx(ctx, "Retrying Yarn request %s in %v (attempt %d/%d)", path, wait, attempt, attempts)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		baseURL := c.activeURL(ctx)
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+		req, err := c.newRequest(ctx, method, baseURL+path, reqBody)
+		if err != nil {
+			return nil, err
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if lastResp != nil {
+				lastResp.Body.Close()
+			}
+			lastErr, lastResp = err, nil
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			c.failover(ctx, baseURL)
+			continue
+		}
+		if resp.StatusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+
+		// Keep the last 5xx so the caller reports the RM's status if every attempt fails
+		if lastResp != nil {
+			lastResp.Body.Close()
+		}
+		lastErr, lastResp = nil, resp
+	}
+
+	if lastResp != nil {
+		return lastResp, nil
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,104 @@
+package yarn
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetry retries without making the tests wait
+var fastRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+// newFlakyRM starts an RM that answers the first failures requests with status
+// and the rest with a healthy cluster info; calls counts every request served
+func newFlakyRM(t *testing.T, failures int, status int) (*Client, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if int(calls.Add(1)) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"clusterInfo":{"state":"STARTED","haState":"ACTIVE"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return NewClient([]string{server.URL}, WithRetryPolicy(fastRetry)), &calls
+}
+
+func TestSendRetriesServerErrors(t *testing.T) {
+	client, calls := newFlakyRM(t, 2, http.StatusServiceUnavailable)
+
+	info, err := client.GetClusterInfoContext(context.Background())
+	if err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+	if info.State != "STARTED" {
+		t.Errorf("got state %q, want STARTED", info.State)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("RM saw %d requests, want 3", got)
+	}
+}
+
+func TestSendGivesUpAfterMaxAttempts(t *testing.T) {
+	client, calls := newFlakyRM(t, 100, http.StatusBadGateway)
+
+	if _, err := client.GetClusterInfoContext(context.Background()); err == nil {
+		t.Fatal("expected an error once every attempt failed")
+	}
+	if got := calls.Load(); got != int32(fastRetry.MaxAttempts) {
+		t.Errorf("RM saw %d requests, want %d", got, fastRetry.MaxAttempts)
+	}
+}
+
+func TestSendDoesNotRetryClientErrors(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			client, calls := newFlakyRM(t, 1, status)
+
+			_, err := client.GetClusterInfoContext(context.Background())
+			if err == nil {
+				t.Fatal("expected the 4xx to be returned as an error")
+			}
+			if status == http.StatusNotFound && !errors.Is(err, ErrNotFound) {
+				t.Errorf("got %v, want ErrNotFound", err)
+			}
+			if got := calls.Load(); got != 1 {
+				t.Errorf("RM saw %d requests, want 1", got)
+			}
+		})
+	}
+}
+
+func TestKillIsNotRetried(t *testing.T) {
+	var puts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			puts.Add(1)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var events []KillEvent
+	client := NewClientWithOptions([]string{server.URL}, ClientOptions{
+		Retry:  &fastRetry,
+		OnKill: func(_ context.Context, e KillEvent) { events = append(events, e) },
+	})
+
+	err := client.KillApplicationContext(context.Background(), "application_1700000000000_0001")
+	if err == nil {
+		t.Fatal("expected the kill to fail")
+	}
+	if got := puts.Load(); got != 1 {
+		t.Errorf("RM saw %d kill requests, want 1", got)
+	}
+	if len(events) != 1 || events[0].Err == nil {
+		t.Errorf("got kill events %+v, want one failed kill", events)
+	}
+}
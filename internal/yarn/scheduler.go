@@ -0,0 +1,95 @@
+package yarn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Queue is a capacity scheduler queue. Capacities are percentages: Capacity,
+// UsedCapacity and MaxCapacity are relative to the parent queue, the Absolute*
+// variants to the whole cluster.
+type Queue struct {
+	Name                 string   `json:"name"`
+	Type                 string   `json:"type,omitempty"`
+	State                string   `json:"state,omitempty"`
+	Capacity             float64  `json:"capacity"`
+	UsedCapacity         float64  `json:"usedCapacity"`
+	MaxCapacity          float64  `json:"maxCapacity"`
+	AbsoluteCapacity     float64  `json:"absoluteCapacity"`
+	AbsoluteUsedCapacity float64  `json:"absoluteUsedCapacity"`
+	AbsoluteMaxCapacity  float64  `json:"absoluteMaxCapacity"`
+	NumApplications      int64    `json:"numApplications"`
+	Children             []*Queue `json:"children,omitempty"`
+}
+
+// UnmarshalJSON decodes the RM's queue layout, where the name is "queueName" and
+// child queues are wrapped in {"queues": {"queue": [...]}}
+func (q *Queue) UnmarshalJSON(data []byte) error {
+	type plainQueue Queue
+	var raw struct {
+		plainQueue
+		QueueName string `json:"queueName"`
+		Queues    struct {
+			Queue []*Queue `json:"queue"`
+		} `json:"queues"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*q = Queue(raw.plainQueue)
+	q.Name = raw.QueueName
+	q.Children = raw.Queues.Queue
+	return nil
+}
+
+// Saturated reports whether the queue has reached its maximum share of the cluster
+func (q *Queue) Saturated() bool {
+	return q.AbsoluteMaxCapacity > 0 && q.AbsoluteUsedCapacity >= q.AbsoluteMaxCapacity
+}
+
+// SchedulerInfo describes the RM scheduler and its queue tree
+type SchedulerInfo struct {
+	Type string `json:"type"`
+	Root *Queue `json:"root"`
+}
+
+// GetSchedulerInfo calls GetSchedulerInfoContext with a background context
+func (c *Client) GetSchedulerInfo() (*SchedulerInfo, error) {
+	return c.GetSchedulerInfoContext(context.Background())
+}
+
+// GetSchedulerInfoContext retrieves the scheduler type and queue tree. Only the
+// capacity scheduler reports queue capacities; other schedulers return a root
+// queue without them.
+func (c *Client) GetSchedulerInfoContext(ctx context.Context) (*SchedulerInfo, error) {
+	var schedulerResponse struct {
+		Scheduler struct {
+			SchedulerInfo json.RawMessage `json:"schedulerInfo"`
+		} `json:"scheduler"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, "/ws/v1/cluster/scheduler", nil, &schedulerResponse); err != nil {
+		return nil, fmt.Errorf("failed to fetch scheduler info: %w", err)
+	}
+	if len(schedulerResponse.Scheduler.SchedulerInfo) == 0 {
+		return nil, fmt.Errorf("scheduler info missing from RM response")
+	}
+
+	// The scheduler info doubles as the root queue
+	info := &SchedulerInfo{Root: &Queue{}}
+	if err := json.Unmarshal(schedulerResponse.Scheduler.SchedulerInfo, info.Root); err != nil {
+		return nil, fmt.Errorf("failed to decode scheduler info: %w", err)
+	}
+	info.Type = info.Root.Type
+	if info.Root.Name == "" {
+		info.Root.Name = "root"
+	}
+	// The root's relative capacities are already cluster-wide
+	if info.Root.AbsoluteCapacity == 0 && info.Root.AbsoluteMaxCapacity == 0 {
+		info.Root.AbsoluteCapacity = info.Root.Capacity
+		info.Root.AbsoluteUsedCapacity = info.Root.UsedCapacity
+		info.Root.AbsoluteMaxCapacity = info.Root.MaxCapacity
+	}
+	return info, nil
+}
@@ -0,0 +1,57 @@
+package yarn
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownSortField is returned by SortApplications for a field it cannot sort on
+var ErrUnknownSortField = errors.New("unknown sort field")
+
+// appLess compares two applications on one field
+type appLess func(a, b *Application) bool
+
+// appSortFields are the fields SortApplications accepts, by query parameter name
+var appSortFields = map[string]appLess{
+	"name":        func(a, b *Application) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) },
+	"state":       func(a, b *Application) bool { return a.State < b.State },
+	"progress":    func(a, b *Application) bool { return a.Progress < b.Progress },
+	"startedtime": func(a, b *Application) bool { return a.StartedTime < b.StartedTime },
+	"elapsedtime": func(a, b *Application) bool { return a.ElapsedTime < b.ElapsedTime },
+	"allocatedmb": func(a, b *Application) bool { return a.AllocatedMB < b.AllocatedMB },
+}
+
+// LessFunc returns the comparison SortApplications uses for field (matched
+// case-insensitively, e.g. elapsedTime). Ties are broken by application ID so
+// the order is stable across refreshes; descending reverses the whole order.
+func LessFunc(field string, descending bool) (func(a, b *Application) bool, error) {
+	less, ok := appSortFields[strings.ToLower(field)]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownSortField, field)
+	}
+	return func(a, b *Application) bool {
+		x, y := a, b
+		if descending {
+			x, y = b, a
+		}
+		if less(x, y) {
+			return true
+		}
+		if less(y, x) {
+			return false
+		}
+		return x.ID < y.ID
+	}, nil
+}
+
+// SortApplications sorts apps in place on field, ascending unless descending is set
+func SortApplications(apps []*Application, field string, descending bool) error {
+	less, err := LessFunc(field, descending)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(apps, func(i, j int) bool { return less(apps[i], apps[j]) })
+	return nil
+}
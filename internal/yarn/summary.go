@@ -0,0 +1,63 @@
+package yarn
+
+import "math"
+
+// ClusterSummary is a compact snapshot of cluster health derived from
+// ClusterMetrics. Utilization is a percentage of the cluster total, rounded to
+// one decimal, and 0 when the RM reports no capacity at all.
+type ClusterSummary struct {
+	Apps struct {
+		Running int64 `json:"running"`
+		Pending int64 `json:"pending"`
+		Failed  int64 `json:"failed"`
+		Killed  int64 `json:"killed"`
+	} `json:"apps"`
+	Memory struct {
+		AllocatedMB        int64   `json:"allocated_mb"`
+		TotalMB            int64   `json:"total_mb"`
+		UtilizationPercent float64 `json:"utilization_percent"`
+	} `json:"memory"`
+	VCores struct {
+		Allocated          int64   `json:"allocated"`
+		Total              int64   `json:"total"`
+		UtilizationPercent float64 `json:"utilization_percent"`
+	} `json:"vcores"`
+	Nodes struct {
+		Total     int64 `json:"total"`
+		Active    int64 `json:"active"`
+		Unhealthy int64 `json:"unhealthy"`
+		Lost      int64 `json:"lost"`
+	} `json:"nodes"`
+}
+
+// Summarize computes the cluster summary from the RM's metrics
+func (m *ClusterMetrics) Summarize() ClusterSummary {
+	var s ClusterSummary
+	s.Apps.Running = m.AppsRunning
+	s.Apps.Pending = m.AppsPending
+	s.Apps.Failed = m.AppsFailed
+	s.Apps.Killed = m.AppsKilled
+
+	s.Memory.AllocatedMB = m.AllocatedMB
+	s.Memory.TotalMB = m.TotalMB
+	s.Memory.UtilizationPercent = percent(m.AllocatedMB, m.TotalMB)
+
+	s.VCores.Allocated = m.AllocatedVirtualCores
+	s.VCores.Total = m.TotalVirtualCores
+	s.VCores.UtilizationPercent = percent(m.AllocatedVirtualCores, m.TotalVirtualCores)
+
+	s.Nodes.Total = m.TotalNodes
+	s.Nodes.Active = m.ActiveNodes
+	s.Nodes.Unhealthy = m.UnhealthyNodes
+	s.Nodes.Lost = m.LostNodes
+	return s
+}
+
+// percent returns part as a percentage of total rounded to one decimal, or 0
+// when total is not positive
+func percent(part, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*1000) / 10
+}
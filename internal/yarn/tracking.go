@@ -0,0 +1,72 @@
+package yarn
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// TrackingURL returns an absolute link to the application's UI (Spark, MapReduce
+// history, ...), or "" when the RM has not assigned one yet. The RM reports
+// this URL in several shapes; see ResolveTrackingURL.
+func (c *Client) TrackingURL(ctx context.Context, app *Application) string {
+	base := ""
+	if c.fixtureDir == "" {
+		base = c.activeURL(ctx)
+	}
+	return ResolveTrackingURL(base, app.TrackingURL)
+}
+
+// ResolveTrackingURL normalizes a tracking URL reported by the RM at base:
+//   - absolute http(s) URLs are returned unchanged
+//   - host:port/path without a scheme gets the scheme of base (http if unknown)
+//   - paths such as /proxy/application_.../ are resolved against base
+//
+// Placeholders like "N/A" or "UNASSIGNED", relative paths with no base, and
+// any other scheme give "".
+func ResolveTrackingURL(base, raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.EqualFold(raw, "N/A") || strings.EqualFold(raw, "UNASSIGNED") {
+		return ""
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil || (baseURL.Scheme != "http" && baseURL.Scheme != "https") || baseURL.Host == "" {
+		baseURL = nil
+	}
+
+	u, err := url.Parse(raw)
+	switch {
+	case err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "":
+		return u.String()
+	case !strings.HasPrefix(raw, "/") && looksLikeHostPort(raw):
+		scheme := "http"
+		if baseURL != nil {
+			scheme = baseURL.Scheme
+		}
+		if u, err := url.Parse(scheme + "://" + raw); err == nil && u.Host != "" {
+			return u.String()
+		}
+		return ""
+	case err == nil && u.Scheme == "" && u.Host == "" && baseURL != nil:
+		return baseURL.ResolveReference(u).String()
+	default:
+		return ""
+	}
+}
+
+// looksLikeHostPort reports whether s starts with host:port, as some RM
+// versions write tracking URLs without a scheme
+func looksLikeHostPort(s string) bool {
+	host, _, _ := strings.Cut(s, "/")
+	name, port, ok := strings.Cut(host, ":")
+	if !ok || name == "" || port == "" {
+		return false
+	}
+	for _, r := range port {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
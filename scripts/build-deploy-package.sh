@@ -45,8 +45,8 @@ cat > "$PACKAGE_DIR/.env.production" << 'EOF'
 ENV=prod
 
 # Server Configuration
-SERVER_PORT=8080
-SERVER_HOST=0.0.0.0
+PORT=8080
+HOST=0.0.0.0
 
 # NFS Paths
 NFS_ROOT_PROD=/home/informaticaadmin/nfs_backup/monitoring
@@ -55,17 +55,18 @@ NFS_ROOT_PROD=/home/informaticaadmin/nfs_backup/monitoring
 YARN_RM_URL=http://rm-host:8088
 
 # Informatica Database (Oracle)
-INF_DB_HOST=172.16.1.100
-INF_DB_PORT=1521
-INF_DB_SERVICE=ORCL
-INF_DB_USER=repo_read
-INF_DB_PASSWORD=change_this_password
-TIME_OFFSET_HOURS=3
+INFORMATICA_DB_TYPE=oracle
+INFORMATICA_DB_HOST=172.16.1.100
+INFORMATICA_DB_PORT=1521
+INFORMATICA_DB_NAME=ORCL
+INFORMATICA_DB_USER=repo_read
+INFORMATICA_DB_PASS=change_this_password
+INFORMATICA_TIME_OFFSET=3
 
 # Logging
 LOG_LEVEL=info
-LOG_FILE=true
-LOG_JSON=false
+LOG_FILE_ENABLED=true
+LOG_JSON_ENABLED=false
 LOG_DIR=/opt/salam-monitoring/logs
 EOF
 